@@ -5,7 +5,18 @@ type ConfigGetter interface {
 }
 
 type Config struct {
-	GCTTL                int  `yaml:"gcTTL"`
-	SyncPeriod           int  `yaml:"syncPeriod"`
-	KeepTreeDataInMemory bool `yaml:"keepTreeDataInMemory"`
+	GCTTL                int        `yaml:"gcTTL"`
+	SyncPeriod           int        `yaml:"syncPeriod"`
+	KeepTreeDataInMemory bool       `yaml:"keepTreeDataInMemory"`
+	TreeLimits           TreeLimits `yaml:"treeLimits"`
+}
+
+// TreeLimits restrict the changes of every tree in the space, zero values mean no limit
+type TreeLimits struct {
+	MaxChangeSize      int `yaml:"maxChangeSize"`
+	MaxChanges         int `yaml:"maxChanges"`
+	MaxIdentityChanges int `yaml:"maxIdentityChanges"`
+	IdentityWindowSec  int `yaml:"identityWindowSec"`
+	MaxPeerChanges     int `yaml:"maxPeerChanges"`
+	PeerWindowSec      int `yaml:"peerWindowSec"`
 }
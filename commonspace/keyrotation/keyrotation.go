@@ -0,0 +1,219 @@
+package keyrotation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/app"
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
+	"github.com/anyproto/any-sync/commonspace/settings"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/any-sync/util/periodicsync"
+)
+
+const CName = "common.commonspace.keyrotation"
+
+var log = logger.NewNamed(CName)
+
+var ErrRotationNotNeeded = errors.New("read key rotation is not needed")
+
+const (
+	checkPeriodSec = 60
+	minBackoff     = time.Minute
+	maxBackoff     = time.Hour
+)
+
+// KeyRotation changes the read key of the space when the rotation policy from the space settings requires it,
+// the records are issued only by the accounts which can change the read key
+type KeyRotation interface {
+	app.ComponentRunnable
+	CheckRotation(ctx context.Context) (err error)
+}
+
+func New(sender pendingacl.RecordSender) KeyRotation {
+	return &keyRotation{sender: sender}
+}
+
+// pendingAcl is the part of syncacl.SyncAcl used by the component
+type pendingAcl interface {
+	list.AclList
+	AddPendingRecord(rec pendingacl.Record)
+	PendingRecordsCount() int
+	SendPendingRecords(ctx context.Context, sender pendingacl.RecordSender) (err error)
+}
+
+type keyRotation struct {
+	sender       pendingacl.RecordSender
+	acl          pendingAcl
+	policy       func() *spacesyncproto.ReadKeyRotationPolicy
+	changes      *spacestate.ChangeCounter
+	periodicCall periodicsync.PeriodicSync
+	log          logger.CtxLogger
+	now          func() time.Time
+
+	mx          sync.Mutex
+	failures    int
+	nextAttempt time.Time
+}
+
+func (k *keyRotation) Init(a *app.App) (err error) {
+	state := a.MustComponent(spacestate.CName).(*spacestate.SpaceState)
+	sett := a.MustComponent(settings.CName).(settings.Settings)
+	acl := a.MustComponent(syncacl.CName).(syncacl.SyncAcl)
+	// the records conflicting with the records which are not synced yet are sent again after the sync
+	acl.SetPendingRecordSender(k.sender)
+	k.acl = acl
+	k.changes = state.ChangeCounter
+	k.policy = func() *spacesyncproto.ReadKeyRotationPolicy {
+		return sett.SettingsObject().ReadKeyRotationPolicy()
+	}
+	k.log = log.With(zap.String("spaceId", state.SpaceId))
+	k.now = time.Now
+	k.periodicCall = periodicsync.NewPeriodicSync(checkPeriodSec, time.Minute, k.CheckRotation, k.log)
+	return
+}
+
+func (k *keyRotation) Name() (name string) {
+	return CName
+}
+
+func (k *keyRotation) Run(ctx context.Context) (err error) {
+	k.periodicCall.Run()
+	return
+}
+
+func (k *keyRotation) Close(ctx context.Context) (err error) {
+	k.periodicCall.Close()
+	return
+}
+
+// CheckRotation adds the read key change if the policy requires it and sends it to the consensus node,
+// after the failure of sending the next attempt is made with the exponential backoff
+func (k *keyRotation) CheckRotation(ctx context.Context) (err error) {
+	k.mx.Lock()
+	defer k.mx.Unlock()
+	now := k.now()
+	if now.Before(k.nextAttempt) {
+		return
+	}
+	// if there are pending records, we are sending them first, including the previous rotation
+	if k.acl.PendingRecordsCount() == 0 {
+		policy := k.policy()
+		k.acl.RLock()
+		needed := canRotate(k.acl) && isRotationNeeded(policy, k.acl, k.changes, now)
+		k.acl.RUnlock()
+		if !needed {
+			return
+		}
+		k.log.Info("rotating read key")
+		k.acl.AddPendingRecord(k.rotationRecord(policy))
+	}
+	err = k.acl.SendPendingRecords(ctx, k.sender)
+	var conflictErr pendingacl.ConflictError
+	switch {
+	case err == nil:
+		k.failures = 0
+		k.nextAttempt = time.Time{}
+	case errors.As(err, &conflictErr):
+		// the record is removed from the queue, so the policy is checked again on the next call
+		k.log.Info("pending record was dropped", zap.Error(err))
+		err = nil
+	default:
+		k.failures++
+		k.nextAttempt = now.Add(backoff(k.failures))
+	}
+	return
+}
+
+// rotationRecord checks the policy again when the record is rebuilt,
+// so the rotation made concurrently by another account is not repeated
+func (k *keyRotation) rotationRecord(policy *spacesyncproto.ReadKeyRotationPolicy) pendingacl.Record {
+	return pendingacl.Record{
+		Name: "readKeyRotation",
+		Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+			if !canRotate(acl) {
+				return nil, list.ErrInsufficientPermissions
+			}
+			if !isRotationNeeded(policy, acl, k.changes, k.now()) {
+				return nil, ErrRotationNotNeeded
+			}
+			metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+			if err != nil {
+				return nil, err
+			}
+			return acl.RecordBuilder().BuildReadKeyChange(list.ReadKeyChangePayload{
+				MetadataKey: metadataKey,
+				ReadKey:     crypto.NewAES(),
+			})
+		},
+	}
+}
+
+// canRotate should be called while holding the read lock of the acl
+func canRotate(acl list.AclList) bool {
+	state := acl.AclState()
+	if state.AccountKey() == nil {
+		return false
+	}
+	return state.Capabilities(state.AccountKey().GetPublic()).Has(aclrecordproto.AclCapability_CapabilityChangeReadKey)
+}
+
+// isRotationNeeded should be called while holding the read lock of the acl,
+// MaxChanges is checked against the tree changes encrypted with the current read key which were counted by the space
+func isRotationNeeded(policy *spacesyncproto.ReadKeyRotationPolicy, acl list.AclList, changes *spacestate.ChangeCounter, now time.Time) bool {
+	if policy == nil {
+		return false
+	}
+	keyRecordId := acl.AclState().CurrentReadKeyId()
+	if policy.IntervalSec > 0 {
+		rec, err := acl.Get(keyRecordId)
+		if err != nil {
+			return false
+		}
+		timestamp := rec.Timestamp
+		if rec.AcceptorTimestamp != 0 {
+			timestamp = rec.AcceptorTimestamp
+		}
+		if now.Unix()-timestamp >= policy.IntervalSec {
+			return true
+		}
+	}
+	if policy.MaxAclRecords > 0 {
+		var recordsAfter uint32
+		acl.IterateFrom(keyRecordId, func(rec *list.AclRecord) bool {
+			if rec.Id != keyRecordId {
+				recordsAfter++
+			}
+			return true
+		})
+		if recordsAfter >= policy.MaxAclRecords {
+			return true
+		}
+	}
+	if policy.MaxChanges > 0 && changes != nil && changes.Count(keyRecordId) >= policy.MaxChanges {
+		return true
+	}
+	return false
+}
+
+func backoff(failures int) time.Duration {
+	delay := minBackoff
+	for i := 1; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
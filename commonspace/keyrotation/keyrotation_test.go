@@ -0,0 +1,214 @@
+package keyrotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/spacestate"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+)
+
+type testAcl struct {
+	list.AclList
+	pending []pendingacl.Record
+}
+
+func (t *testAcl) AddPendingRecord(rec pendingacl.Record) {
+	t.pending = append(t.pending, rec)
+}
+
+func (t *testAcl) PendingRecordsCount() int {
+	return len(t.pending)
+}
+
+func (t *testAcl) SendPendingRecords(ctx context.Context, sender pendingacl.RecordSender) (err error) {
+	for len(t.pending) > 0 {
+		t.RLock()
+		rawRec, err := t.pending[0].Build(t.AclList)
+		t.RUnlock()
+		if err != nil {
+			t.pending = t.pending[1:]
+			return pendingacl.ConflictError{Name: "test", Err: err}
+		}
+		res, err := sender.AddRecord(ctx, t.Id(), rawRec)
+		if err != nil {
+			return err
+		}
+		t.pending = t.pending[1:]
+		t.Lock()
+		err = t.AddRawRecord(res)
+		t.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testRecordSender struct {
+	err   error
+	calls int
+}
+
+func (t *testRecordSender) AddRecord(ctx context.Context, logId string, rec *consensusproto.RawRecord) (record *consensusproto.RawRecordWithId, err error) {
+	t.calls++
+	if t.err != nil {
+		return nil, t.err
+	}
+	return list.WrapAclRecord(rec), nil
+}
+
+type fixture struct {
+	*keyRotation
+	acl     *testAcl
+	sender  *testRecordSender
+	changes *spacestate.ChangeCounter
+	policy  *spacesyncproto.ReadKeyRotationPolicy
+	now     time.Time
+}
+
+func newFixture(t *testing.T) *fixture {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	aclList, err := list.NewTestDerivedAcl("spaceId", keys)
+	require.NoError(t, err)
+	return newFixtureWithAcl(aclList)
+}
+
+func newFixtureWithAcl(aclList list.AclList) *fixture {
+	fx := &fixture{
+		acl:     &testAcl{AclList: aclList},
+		sender:  &testRecordSender{},
+		changes: spacestate.NewChangeCounter(),
+		now:     time.Now(),
+	}
+	fx.keyRotation = &keyRotation{
+		sender:  fx.sender,
+		acl:     fx.acl,
+		changes: fx.changes,
+		policy: func() *spacesyncproto.ReadKeyRotationPolicy {
+			return fx.policy
+		},
+		log: logger.NewNamed(CName),
+		now: func() time.Time {
+			return fx.now
+		},
+	}
+	return fx
+}
+
+func TestKeyRotation_CheckRotation(t *testing.T) {
+	ctx := context.Background()
+	t.Run("no policy", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.now = fx.now.Add(time.Hour * 24 * 365)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, 0, fx.sender.calls)
+	})
+	t.Run("interval", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.policy = &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60}
+		keyId := fx.acl.AclState().CurrentReadKeyId()
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, keyId, fx.acl.AclState().CurrentReadKeyId())
+
+		fx.now = fx.now.Add(time.Minute * 2)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.NotEqual(t, keyId, fx.acl.AclState().CurrentReadKeyId())
+		require.Equal(t, fx.acl.Head().Id, fx.acl.AclState().CurrentReadKeyId())
+	})
+	t.Run("max records", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.policy = &spacesyncproto.ReadKeyRotationPolicy{MaxAclRecords: 2}
+		keyId := fx.acl.AclState().CurrentReadKeyId()
+		addInvite := func() {
+			inv, err := fx.acl.RecordBuilder().BuildInvite()
+			require.NoError(t, err)
+			require.NoError(t, fx.acl.AddRawRecord(list.WrapAclRecord(inv.InviteRec)))
+		}
+		addInvite()
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, keyId, fx.acl.AclState().CurrentReadKeyId())
+
+		addInvite()
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, fx.acl.Head().Id, fx.acl.AclState().CurrentReadKeyId())
+	})
+	t.Run("max changes", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.policy = &spacesyncproto.ReadKeyRotationPolicy{MaxChanges: 3}
+		keyId := fx.acl.AclState().CurrentReadKeyId()
+		addChanges := func(readKeyId string, count int) {
+			changes := make([]*objecttree.Change, 0, count)
+			for i := 0; i < count; i++ {
+				changes = append(changes, &objecttree.Change{ReadKeyId: readKeyId})
+			}
+			fx.changes.ChangesAdded(changes)
+		}
+		// the changes encrypted with the other keys and not encrypted are not counted
+		addChanges("otherKeyId", 5)
+		addChanges("", 5)
+		addChanges(keyId, 2)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, keyId, fx.acl.AclState().CurrentReadKeyId())
+
+		addChanges(keyId, 1)
+		require.NoError(t, fx.CheckRotation(ctx))
+		newKeyId := fx.acl.AclState().CurrentReadKeyId()
+		require.NotEqual(t, keyId, newKeyId)
+		require.Equal(t, fx.acl.Head().Id, newKeyId)
+
+		// the count starts again for the new key
+		addChanges(keyId, 3)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, newKeyId, fx.acl.AclState().CurrentReadKeyId())
+	})
+	t.Run("only accounts which can change the read key rotate", func(t *testing.T) {
+		ownerFx := newFixture(t)
+		keys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		aclList, err := list.NewTestAclWithRoot(keys, ownerFx.acl.Root())
+		require.NoError(t, err)
+		fx := newFixtureWithAcl(aclList)
+		fx.policy = &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60}
+		fx.now = fx.now.Add(time.Hour)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, 0, fx.sender.calls)
+	})
+	t.Run("backoff on consensus failure", func(t *testing.T) {
+		fx := newFixture(t)
+		fx.policy = &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60}
+		fx.now = fx.now.Add(time.Hour)
+		consensusErr := errors.New("consensus error")
+		fx.sender.err = consensusErr
+		require.Equal(t, consensusErr, fx.CheckRotation(ctx))
+		require.Equal(t, 1, fx.acl.PendingRecordsCount())
+
+		// the next attempt is postponed
+		fx.sender.err = nil
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, 1, fx.sender.calls)
+
+		fx.now = fx.now.Add(minBackoff)
+		require.NoError(t, fx.CheckRotation(ctx))
+		require.Equal(t, 2, fx.sender.calls)
+		require.Equal(t, 0, fx.acl.PendingRecordsCount())
+		require.Equal(t, fx.acl.Head().Id, fx.acl.AclState().CurrentReadKeyId())
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, minBackoff, backoff(1))
+	require.Equal(t, minBackoff*4, backoff(3))
+	require.Equal(t, maxBackoff, backoff(100))
+}
@@ -22,6 +22,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// AclInviteType defines how the account joining with the invite is added to the space
+type AclInviteType int32
+
+const (
+	// RequestToJoin invite requires the join request to be accepted by an admin
+	AclInviteType_RequestToJoin AclInviteType = 0
+	// AnyoneCanJoin invite lets the holder of the invite key join with preset permissions
+	AclInviteType_AnyoneCanJoin AclInviteType = 1
+	// PublicReader invite lets the holder of the invite key read the content without joining the space
+	AclInviteType_PublicReader AclInviteType = 2
+)
+
+var AclInviteType_name = map[int32]string{
+	0: "RequestToJoin",
+	1: "AnyoneCanJoin",
+	2: "PublicReader",
+}
+
+var AclInviteType_value = map[string]int32{
+	"RequestToJoin": 0,
+	"AnyoneCanJoin": 1,
+	"PublicReader":  2,
+}
+
+func (x AclInviteType) String() string {
+	return proto.EnumName(AclInviteType_name, int32(x))
+}
+
+func (AclInviteType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{0}
+}
+
 // AclUserPermissions contains different possible user roles
 type AclUserPermissions int32
 
@@ -54,7 +86,66 @@ func (x AclUserPermissions) String() string {
 }
 
 func (AclUserPermissions) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{0}
+	return fileDescriptor_c8e9f754f34e929b, []int{1}
+}
+
+// AclCapability is a single action which can be allowed to a role
+type AclCapability int32
+
+const (
+	AclCapability_CapabilityNone AclCapability = 0
+	// CapabilityRead allows to read the content of the space
+	AclCapability_CapabilityRead AclCapability = 1
+	// CapabilityWrite allows to add changes to the objects of the space
+	AclCapability_CapabilityWrite AclCapability = 2
+	// CapabilityComment allows to comment, the meaning is defined by the application
+	AclCapability_CapabilityComment AclCapability = 3
+	// CapabilityInvite allows to create and revoke invites
+	AclCapability_CapabilityInvite AclCapability = 4
+	// CapabilityAcceptRequests allows to accept and decline join requests
+	AclCapability_CapabilityAcceptRequests AclCapability = 5
+	// CapabilityRemoveAccounts allows to remove accounts from the space
+	AclCapability_CapabilityRemoveAccounts AclCapability = 6
+	// CapabilityChangePermissions allows to change roles of other accounts
+	AclCapability_CapabilityChangePermissions AclCapability = 7
+	// CapabilityChangeReadKey allows to rotate the read key of the space
+	AclCapability_CapabilityChangeReadKey AclCapability = 8
+	// CapabilityManageRoles allows to define and remove custom roles
+	AclCapability_CapabilityManageRoles AclCapability = 9
+)
+
+var AclCapability_name = map[int32]string{
+	0: "CapabilityNone",
+	1: "CapabilityRead",
+	2: "CapabilityWrite",
+	3: "CapabilityComment",
+	4: "CapabilityInvite",
+	5: "CapabilityAcceptRequests",
+	6: "CapabilityRemoveAccounts",
+	7: "CapabilityChangePermissions",
+	8: "CapabilityChangeReadKey",
+	9: "CapabilityManageRoles",
+}
+
+var AclCapability_value = map[string]int32{
+	"CapabilityNone":              0,
+	"CapabilityRead":              1,
+	"CapabilityWrite":             2,
+	"CapabilityComment":           3,
+	"CapabilityInvite":            4,
+	"CapabilityAcceptRequests":    5,
+	"CapabilityRemoveAccounts":    6,
+	"CapabilityChangePermissions": 7,
+	"CapabilityChangeReadKey":     8,
+	"CapabilityManageRoles":       9,
+}
+
+func (x AclCapability) String() string {
+	return proto.EnumName(AclCapability_name, int32(x))
+}
+
+func (AclCapability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{2}
 }
 
 // AclRoot is a root of access control list
@@ -169,6 +260,15 @@ func (m *AclRoot) GetEncryptedOwnerMetadata() []byte {
 // AclAccountInvite contains the public invite key, the private part of which is sent to the user directly
 type AclAccountInvite struct {
 	InviteKey []byte `protobuf:"bytes,1,opt,name=inviteKey,proto3" json:"inviteKey,omitempty"`
+	// ExpireTimestamp is the unix time after which the invite can't be used, zero means it never expires
+	ExpireTimestamp int64 `protobuf:"varint,2,opt,name=expireTimestamp,proto3" json:"expireTimestamp,omitempty"`
+	// MaxUses is the maximum number of join requests for the invite, zero means it is not limited
+	MaxUses    uint32        `protobuf:"varint,3,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	InviteType AclInviteType `protobuf:"varint,4,opt,name=inviteType,proto3,enum=aclrecord.AclInviteType" json:"inviteType,omitempty"`
+	// Permissions are given to the account joining with AnyoneCanJoin invite
+	Permissions AclUserPermissions `protobuf:"varint,5,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	// EncryptedReadKey is the current read key encrypted with the invite key, set only for AnyoneCanJoin and PublicReader invites
+	EncryptedReadKey []byte `protobuf:"bytes,6,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
 }
 
 func (m *AclAccountInvite) Reset()         { *m = AclAccountInvite{} }
@@ -211,6 +311,41 @@ func (m *AclAccountInvite) GetInviteKey() []byte {
 	return nil
 }
 
+func (m *AclAccountInvite) GetExpireTimestamp() int64 {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return 0
+}
+
+func (m *AclAccountInvite) GetMaxUses() uint32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *AclAccountInvite) GetInviteType() AclInviteType {
+	if m != nil {
+		return m.InviteType
+	}
+	return AclInviteType_RequestToJoin
+}
+
+func (m *AclAccountInvite) GetPermissions() AclUserPermissions {
+	if m != nil {
+		return m.Permissions
+	}
+	return AclUserPermissions_None
+}
+
+func (m *AclAccountInvite) GetEncryptedReadKey() []byte {
+	if m != nil {
+		return m.EncryptedReadKey
+	}
+	return nil
+}
+
 // AclAccountRequestJoin contains the reference to the invite record and the data of the person who wants to join, confirmed by the private invite key
 type AclAccountRequestJoin struct {
 	InviteIdentity          []byte `protobuf:"bytes,1,opt,name=inviteIdentity,proto3" json:"inviteIdentity,omitempty"`
@@ -281,19 +416,108 @@ func (m *AclAccountRequestJoin) GetMetadata() []byte {
 	return nil
 }
 
+// AclAccountInviteJoin contains the reference to AnyoneCanJoin invite record and the data of the person who joins without approval
+type AclAccountInviteJoin struct {
+	Identity                []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	InviteRecordId          string `protobuf:"bytes,2,opt,name=inviteRecordId,proto3" json:"inviteRecordId,omitempty"`
+	InviteIdentitySignature []byte `protobuf:"bytes,3,opt,name=inviteIdentitySignature,proto3" json:"inviteIdentitySignature,omitempty"`
+	// Metadata is encrypted with metadata key of the space
+	Metadata []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// EncryptedReadKey is the read key encrypted with the identity of the joining person
+	EncryptedReadKey []byte             `protobuf:"bytes,5,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
+	Permissions      AclUserPermissions `protobuf:"varint,6,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+}
+
+func (m *AclAccountInviteJoin) Reset()         { *m = AclAccountInviteJoin{} }
+func (m *AclAccountInviteJoin) String() string { return proto.CompactTextString(m) }
+func (*AclAccountInviteJoin) ProtoMessage()    {}
+func (*AclAccountInviteJoin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{3}
+}
+func (m *AclAccountInviteJoin) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclAccountInviteJoin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclAccountInviteJoin.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclAccountInviteJoin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclAccountInviteJoin.Merge(m, src)
+}
+func (m *AclAccountInviteJoin) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclAccountInviteJoin) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclAccountInviteJoin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclAccountInviteJoin proto.InternalMessageInfo
+
+func (m *AclAccountInviteJoin) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *AclAccountInviteJoin) GetInviteRecordId() string {
+	if m != nil {
+		return m.InviteRecordId
+	}
+	return ""
+}
+
+func (m *AclAccountInviteJoin) GetInviteIdentitySignature() []byte {
+	if m != nil {
+		return m.InviteIdentitySignature
+	}
+	return nil
+}
+
+func (m *AclAccountInviteJoin) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *AclAccountInviteJoin) GetEncryptedReadKey() []byte {
+	if m != nil {
+		return m.EncryptedReadKey
+	}
+	return nil
+}
+
+func (m *AclAccountInviteJoin) GetPermissions() AclUserPermissions {
+	if m != nil {
+		return m.Permissions
+	}
+	return AclUserPermissions_None
+}
+
 // AclAccountRequestAccept contains the reference to join record and all read keys, encrypted with the identity of the requestor
 type AclAccountRequestAccept struct {
 	Identity         []byte             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
 	RequestRecordId  string             `protobuf:"bytes,2,opt,name=requestRecordId,proto3" json:"requestRecordId,omitempty"`
 	EncryptedReadKey []byte             `protobuf:"bytes,3,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
 	Permissions      AclUserPermissions `protobuf:"varint,4,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	// Role is the name of the custom role given to the account, permissions are used by clients not supporting roles
+	Role string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
 }
 
 func (m *AclAccountRequestAccept) Reset()         { *m = AclAccountRequestAccept{} }
 func (m *AclAccountRequestAccept) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRequestAccept) ProtoMessage()    {}
 func (*AclAccountRequestAccept) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{3}
+	return fileDescriptor_c8e9f754f34e929b, []int{4}
 }
 func (m *AclAccountRequestAccept) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -350,6 +574,13 @@ func (m *AclAccountRequestAccept) GetPermissions() AclUserPermissions {
 	return AclUserPermissions_None
 }
 
+func (m *AclAccountRequestAccept) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
 // AclAccountRequestDecline contains the reference to join record
 type AclAccountRequestDecline struct {
 	RequestRecordId string `protobuf:"bytes,1,opt,name=requestRecordId,proto3" json:"requestRecordId,omitempty"`
@@ -359,7 +590,7 @@ func (m *AclAccountRequestDecline) Reset()         { *m = AclAccountRequestDecli
 func (m *AclAccountRequestDecline) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRequestDecline) ProtoMessage()    {}
 func (*AclAccountRequestDecline) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{4}
+	return fileDescriptor_c8e9f754f34e929b, []int{5}
 }
 func (m *AclAccountRequestDecline) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -404,7 +635,7 @@ func (m *AclAccountInviteRevoke) Reset()         { *m = AclAccountInviteRevoke{}
 func (m *AclAccountInviteRevoke) String() string { return proto.CompactTextString(m) }
 func (*AclAccountInviteRevoke) ProtoMessage()    {}
 func (*AclAccountInviteRevoke) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{5}
+	return fileDescriptor_c8e9f754f34e929b, []int{6}
 }
 func (m *AclAccountInviteRevoke) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -450,7 +681,7 @@ func (m *AclEncryptedReadKey) Reset()         { *m = AclEncryptedReadKey{} }
 func (m *AclEncryptedReadKey) String() string { return proto.CompactTextString(m) }
 func (*AclEncryptedReadKey) ProtoMessage()    {}
 func (*AclEncryptedReadKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{6}
+	return fileDescriptor_c8e9f754f34e929b, []int{7}
 }
 func (m *AclEncryptedReadKey) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -497,13 +728,15 @@ func (m *AclEncryptedReadKey) GetEncryptedReadKey() []byte {
 type AclAccountPermissionChange struct {
 	Identity    []byte             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
 	Permissions AclUserPermissions `protobuf:"varint,2,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	// Role is the name of the custom role given to the account, permissions are used by clients not supporting roles
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 }
 
 func (m *AclAccountPermissionChange) Reset()         { *m = AclAccountPermissionChange{} }
 func (m *AclAccountPermissionChange) String() string { return proto.CompactTextString(m) }
 func (*AclAccountPermissionChange) ProtoMessage()    {}
 func (*AclAccountPermissionChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{7}
+	return fileDescriptor_c8e9f754f34e929b, []int{8}
 }
 func (m *AclAccountPermissionChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -546,6 +779,156 @@ func (m *AclAccountPermissionChange) GetPermissions() AclUserPermissions {
 	return AclUserPermissions_None
 }
 
+func (m *AclAccountPermissionChange) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+// AclRole is a named set of capabilities defined in the space
+type AclRole struct {
+	Name         string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capabilities []AclCapability `protobuf:"varint,2,rep,packed,name=capabilities,proto3,enum=aclrecord.AclCapability" json:"capabilities,omitempty"`
+}
+
+func (m *AclRole) Reset()         { *m = AclRole{} }
+func (m *AclRole) String() string { return proto.CompactTextString(m) }
+func (*AclRole) ProtoMessage()    {}
+func (*AclRole) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{9}
+}
+func (m *AclRole) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclRole) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclRole.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclRole) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclRole.Merge(m, src)
+}
+func (m *AclRole) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclRole) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclRole.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclRole proto.InternalMessageInfo
+
+func (m *AclRole) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AclRole) GetCapabilities() []AclCapability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+// AclRoleDefine adds a new custom role or changes the capabilities of the existing one
+type AclRoleDefine struct {
+	Role *AclRole `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+}
+
+func (m *AclRoleDefine) Reset()         { *m = AclRoleDefine{} }
+func (m *AclRoleDefine) String() string { return proto.CompactTextString(m) }
+func (*AclRoleDefine) ProtoMessage()    {}
+func (*AclRoleDefine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{10}
+}
+func (m *AclRoleDefine) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclRoleDefine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclRoleDefine.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclRoleDefine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclRoleDefine.Merge(m, src)
+}
+func (m *AclRoleDefine) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclRoleDefine) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclRoleDefine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclRoleDefine proto.InternalMessageInfo
+
+func (m *AclRoleDefine) GetRole() *AclRole {
+	if m != nil {
+		return m.Role
+	}
+	return nil
+}
+
+// AclRoleRemove removes the custom role which is not given to any account
+type AclRoleRemove struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *AclRoleRemove) Reset()         { *m = AclRoleRemove{} }
+func (m *AclRoleRemove) String() string { return proto.CompactTextString(m) }
+func (*AclRoleRemove) ProtoMessage()    {}
+func (*AclRoleRemove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{11}
+}
+func (m *AclRoleRemove) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclRoleRemove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclRoleRemove.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclRoleRemove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclRoleRemove.Merge(m, src)
+}
+func (m *AclRoleRemove) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclRoleRemove) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclRoleRemove.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclRoleRemove proto.InternalMessageInfo
+
+func (m *AclRoleRemove) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 // AclReadKeyChange changes the key for a space
 type AclReadKeyChange struct {
 	AccountKeys    []*AclEncryptedReadKey `protobuf:"bytes,1,rep,name=accountKeys,proto3" json:"accountKeys,omitempty"`
@@ -554,13 +937,15 @@ type AclReadKeyChange struct {
 	EncryptedMetadataPrivKey []byte `protobuf:"bytes,3,opt,name=encryptedMetadataPrivKey,proto3" json:"encryptedMetadataPrivKey,omitempty"`
 	// EncryptedOldReadKey is encrypted with new read key
 	EncryptedOldReadKey []byte `protobuf:"bytes,4,opt,name=encryptedOldReadKey,proto3" json:"encryptedOldReadKey,omitempty"`
+	// InviteKeys are new read keys encrypted with the keys of AnyoneCanJoin and PublicReader invites
+	InviteKeys []*AclEncryptedReadKey `protobuf:"bytes,5,rep,name=inviteKeys,proto3" json:"inviteKeys,omitempty"`
 }
 
 func (m *AclReadKeyChange) Reset()         { *m = AclReadKeyChange{} }
 func (m *AclReadKeyChange) String() string { return proto.CompactTextString(m) }
 func (*AclReadKeyChange) ProtoMessage()    {}
 func (*AclReadKeyChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{8}
+	return fileDescriptor_c8e9f754f34e929b, []int{12}
 }
 func (m *AclReadKeyChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -617,6 +1002,13 @@ func (m *AclReadKeyChange) GetEncryptedOldReadKey() []byte {
 	return nil
 }
 
+func (m *AclReadKeyChange) GetInviteKeys() []*AclEncryptedReadKey {
+	if m != nil {
+		return m.InviteKeys
+	}
+	return nil
+}
+
 // AclAccountRemove removes an account and changes read key for space
 type AclAccountRemove struct {
 	Identities    [][]byte          `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
@@ -627,7 +1019,7 @@ func (m *AclAccountRemove) Reset()         { *m = AclAccountRemove{} }
 func (m *AclAccountRemove) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRemove) ProtoMessage()    {}
 func (*AclAccountRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{9}
+	return fileDescriptor_c8e9f754f34e929b, []int{13}
 }
 func (m *AclAccountRemove) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -678,7 +1070,7 @@ func (m *AclAccountRequestRemove) Reset()         { *m = AclAccountRequestRemove
 func (m *AclAccountRequestRemove) String() string { return proto.CompactTextString(m) }
 func (*AclAccountRequestRemove) ProtoMessage()    {}
 func (*AclAccountRequestRemove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{10}
+	return fileDescriptor_c8e9f754f34e929b, []int{14}
 }
 func (m *AclAccountRequestRemove) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -707,34 +1099,25 @@ func (m *AclAccountRequestRemove) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AclAccountRequestRemove proto.InternalMessageInfo
 
-// AclContentValue contains possible values for Acl
-type AclContentValue struct {
-	// Types that are valid to be assigned to Value:
-	//
-	//	*AclContentValue_Invite
-	//	*AclContentValue_InviteRevoke
-	//	*AclContentValue_RequestJoin
-	//	*AclContentValue_RequestAccept
-	//	*AclContentValue_PermissionChange
-	//	*AclContentValue_AccountRemove
-	//	*AclContentValue_ReadKeyChange
-	//	*AclContentValue_RequestDecline
-	//	*AclContentValue_AccountRequestRemove
-	Value isAclContentValue_Value `protobuf_oneof:"value"`
+// AclOwnershipTransfer is the offer of the owner to make another account an owner, it should be accepted by that account
+type AclOwnershipTransfer struct {
+	Identity []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// KeepOwnership is true if the current owner stays an owner after the transfer, otherwise it becomes an admin
+	KeepOwnership bool `protobuf:"varint,2,opt,name=keepOwnership,proto3" json:"keepOwnership,omitempty"`
 }
 
-func (m *AclContentValue) Reset()         { *m = AclContentValue{} }
-func (m *AclContentValue) String() string { return proto.CompactTextString(m) }
-func (*AclContentValue) ProtoMessage()    {}
-func (*AclContentValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{11}
+func (m *AclOwnershipTransfer) Reset()         { *m = AclOwnershipTransfer{} }
+func (m *AclOwnershipTransfer) String() string { return proto.CompactTextString(m) }
+func (*AclOwnershipTransfer) ProtoMessage()    {}
+func (*AclOwnershipTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{15}
 }
-func (m *AclContentValue) XXX_Unmarshal(b []byte) error {
+func (m *AclOwnershipTransfer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *AclContentValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AclOwnershipTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_AclContentValue.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AclOwnershipTransfer.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -744,22 +1127,189 @@ func (m *AclContentValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *AclContentValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AclContentValue.Merge(m, src)
+func (m *AclOwnershipTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclOwnershipTransfer.Merge(m, src)
 }
-func (m *AclContentValue) XXX_Size() int {
+func (m *AclOwnershipTransfer) XXX_Size() int {
 	return m.Size()
 }
-func (m *AclContentValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_AclContentValue.DiscardUnknown(m)
+func (m *AclOwnershipTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclOwnershipTransfer.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AclContentValue proto.InternalMessageInfo
+var xxx_messageInfo_AclOwnershipTransfer proto.InternalMessageInfo
 
-type isAclContentValue_Value interface {
-	isAclContentValue_Value()
-	MarshalTo([]byte) (int, error)
-	Size() int
+func (m *AclOwnershipTransfer) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *AclOwnershipTransfer) GetKeepOwnership() bool {
+	if m != nil {
+		return m.KeepOwnership
+	}
+	return false
+}
+
+// AclOwnershipAccept accepts the ownership transfer, it is made by the new owner
+type AclOwnershipAccept struct {
+	TransferRecordId string `protobuf:"bytes,1,opt,name=transferRecordId,proto3" json:"transferRecordId,omitempty"`
+}
+
+func (m *AclOwnershipAccept) Reset()         { *m = AclOwnershipAccept{} }
+func (m *AclOwnershipAccept) String() string { return proto.CompactTextString(m) }
+func (*AclOwnershipAccept) ProtoMessage()    {}
+func (*AclOwnershipAccept) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{16}
+}
+func (m *AclOwnershipAccept) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclOwnershipAccept) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclOwnershipAccept.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclOwnershipAccept) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclOwnershipAccept.Merge(m, src)
+}
+func (m *AclOwnershipAccept) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclOwnershipAccept) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclOwnershipAccept.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclOwnershipAccept proto.InternalMessageInfo
+
+func (m *AclOwnershipAccept) GetTransferRecordId() string {
+	if m != nil {
+		return m.TransferRecordId
+	}
+	return ""
+}
+
+// AclAccountMetadataUpdate replaces the metadata of the account, it is made by the account itself
+type AclAccountMetadataUpdate struct {
+	Identity []byte `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Metadata is encrypted with the current metadata key
+	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (m *AclAccountMetadataUpdate) Reset()         { *m = AclAccountMetadataUpdate{} }
+func (m *AclAccountMetadataUpdate) String() string { return proto.CompactTextString(m) }
+func (*AclAccountMetadataUpdate) ProtoMessage()    {}
+func (*AclAccountMetadataUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{17}
+}
+func (m *AclAccountMetadataUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclAccountMetadataUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclAccountMetadataUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclAccountMetadataUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclAccountMetadataUpdate.Merge(m, src)
+}
+func (m *AclAccountMetadataUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclAccountMetadataUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclAccountMetadataUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclAccountMetadataUpdate proto.InternalMessageInfo
+
+func (m *AclAccountMetadataUpdate) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *AclAccountMetadataUpdate) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// AclContentValue contains possible values for Acl
+type AclContentValue struct {
+	// Types that are valid to be assigned to Value:
+	//	*AclContentValue_Invite
+	//	*AclContentValue_InviteRevoke
+	//	*AclContentValue_RequestJoin
+	//	*AclContentValue_RequestAccept
+	//	*AclContentValue_PermissionChange
+	//	*AclContentValue_AccountRemove
+	//	*AclContentValue_ReadKeyChange
+	//	*AclContentValue_RequestDecline
+	//	*AclContentValue_AccountRequestRemove
+	//	*AclContentValue_InviteJoin
+	//	*AclContentValue_RoleDefine
+	//	*AclContentValue_RoleRemove
+	//	*AclContentValue_OwnershipTransfer
+	//	*AclContentValue_OwnershipAccept
+	//	*AclContentValue_MetadataUpdate
+	Value isAclContentValue_Value `protobuf_oneof:"value"`
+}
+
+func (m *AclContentValue) Reset()         { *m = AclContentValue{} }
+func (m *AclContentValue) String() string { return proto.CompactTextString(m) }
+func (*AclContentValue) ProtoMessage()    {}
+func (*AclContentValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{18}
+}
+func (m *AclContentValue) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclContentValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclContentValue.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclContentValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclContentValue.Merge(m, src)
+}
+func (m *AclContentValue) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclContentValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclContentValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclContentValue proto.InternalMessageInfo
+
+type isAclContentValue_Value interface {
+	isAclContentValue_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
 type AclContentValue_Invite struct {
@@ -789,6 +1339,24 @@ type AclContentValue_RequestDecline struct {
 type AclContentValue_AccountRequestRemove struct {
 	AccountRequestRemove *AclAccountRequestRemove `protobuf:"bytes,9,opt,name=accountRequestRemove,proto3,oneof" json:"accountRequestRemove,omitempty"`
 }
+type AclContentValue_InviteJoin struct {
+	InviteJoin *AclAccountInviteJoin `protobuf:"bytes,10,opt,name=inviteJoin,proto3,oneof" json:"inviteJoin,omitempty"`
+}
+type AclContentValue_RoleDefine struct {
+	RoleDefine *AclRoleDefine `protobuf:"bytes,11,opt,name=roleDefine,proto3,oneof" json:"roleDefine,omitempty"`
+}
+type AclContentValue_RoleRemove struct {
+	RoleRemove *AclRoleRemove `protobuf:"bytes,12,opt,name=roleRemove,proto3,oneof" json:"roleRemove,omitempty"`
+}
+type AclContentValue_OwnershipTransfer struct {
+	OwnershipTransfer *AclOwnershipTransfer `protobuf:"bytes,13,opt,name=ownershipTransfer,proto3,oneof" json:"ownershipTransfer,omitempty"`
+}
+type AclContentValue_OwnershipAccept struct {
+	OwnershipAccept *AclOwnershipAccept `protobuf:"bytes,14,opt,name=ownershipAccept,proto3,oneof" json:"ownershipAccept,omitempty"`
+}
+type AclContentValue_MetadataUpdate struct {
+	MetadataUpdate *AclAccountMetadataUpdate `protobuf:"bytes,15,opt,name=metadataUpdate,proto3,oneof" json:"metadataUpdate,omitempty"`
+}
 
 func (*AclContentValue_Invite) isAclContentValue_Value()               {}
 func (*AclContentValue_InviteRevoke) isAclContentValue_Value()         {}
@@ -799,6 +1367,12 @@ func (*AclContentValue_AccountRemove) isAclContentValue_Value()        {}
 func (*AclContentValue_ReadKeyChange) isAclContentValue_Value()        {}
 func (*AclContentValue_RequestDecline) isAclContentValue_Value()       {}
 func (*AclContentValue_AccountRequestRemove) isAclContentValue_Value() {}
+func (*AclContentValue_InviteJoin) isAclContentValue_Value()           {}
+func (*AclContentValue_RoleDefine) isAclContentValue_Value()           {}
+func (*AclContentValue_RoleRemove) isAclContentValue_Value()           {}
+func (*AclContentValue_OwnershipTransfer) isAclContentValue_Value()    {}
+func (*AclContentValue_OwnershipAccept) isAclContentValue_Value()      {}
+func (*AclContentValue_MetadataUpdate) isAclContentValue_Value()       {}
 
 func (m *AclContentValue) GetValue() isAclContentValue_Value {
 	if m != nil {
@@ -870,6 +1444,48 @@ func (m *AclContentValue) GetAccountRequestRemove() *AclAccountRequestRemove {
 	return nil
 }
 
+func (m *AclContentValue) GetInviteJoin() *AclAccountInviteJoin {
+	if x, ok := m.GetValue().(*AclContentValue_InviteJoin); ok {
+		return x.InviteJoin
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetRoleDefine() *AclRoleDefine {
+	if x, ok := m.GetValue().(*AclContentValue_RoleDefine); ok {
+		return x.RoleDefine
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetRoleRemove() *AclRoleRemove {
+	if x, ok := m.GetValue().(*AclContentValue_RoleRemove); ok {
+		return x.RoleRemove
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetOwnershipTransfer() *AclOwnershipTransfer {
+	if x, ok := m.GetValue().(*AclContentValue_OwnershipTransfer); ok {
+		return x.OwnershipTransfer
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetOwnershipAccept() *AclOwnershipAccept {
+	if x, ok := m.GetValue().(*AclContentValue_OwnershipAccept); ok {
+		return x.OwnershipAccept
+	}
+	return nil
+}
+
+func (m *AclContentValue) GetMetadataUpdate() *AclAccountMetadataUpdate {
+	if x, ok := m.GetValue().(*AclContentValue_MetadataUpdate); ok {
+		return x.MetadataUpdate
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*AclContentValue) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -882,6 +1498,12 @@ func (*AclContentValue) XXX_OneofWrappers() []interface{} {
 		(*AclContentValue_ReadKeyChange)(nil),
 		(*AclContentValue_RequestDecline)(nil),
 		(*AclContentValue_AccountRequestRemove)(nil),
+		(*AclContentValue_InviteJoin)(nil),
+		(*AclContentValue_RoleDefine)(nil),
+		(*AclContentValue_RoleRemove)(nil),
+		(*AclContentValue_OwnershipTransfer)(nil),
+		(*AclContentValue_OwnershipAccept)(nil),
+		(*AclContentValue_MetadataUpdate)(nil),
 	}
 }
 
@@ -894,7 +1516,7 @@ func (m *AclData) Reset()         { *m = AclData{} }
 func (m *AclData) String() string { return proto.CompactTextString(m) }
 func (*AclData) ProtoMessage()    {}
 func (*AclData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8e9f754f34e929b, []int{12}
+	return fileDescriptor_c8e9f754f34e929b, []int{19}
 }
 func (m *AclData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -930,1188 +1552,7542 @@ func (m *AclData) GetAclContent() []*AclContentValue {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("aclrecord.AclUserPermissions", AclUserPermissions_name, AclUserPermissions_value)
-	proto.RegisterType((*AclRoot)(nil), "aclrecord.AclRoot")
-	proto.RegisterType((*AclAccountInvite)(nil), "aclrecord.AclAccountInvite")
-	proto.RegisterType((*AclAccountRequestJoin)(nil), "aclrecord.AclAccountRequestJoin")
-	proto.RegisterType((*AclAccountRequestAccept)(nil), "aclrecord.AclAccountRequestAccept")
-	proto.RegisterType((*AclAccountRequestDecline)(nil), "aclrecord.AclAccountRequestDecline")
-	proto.RegisterType((*AclAccountInviteRevoke)(nil), "aclrecord.AclAccountInviteRevoke")
-	proto.RegisterType((*AclEncryptedReadKey)(nil), "aclrecord.AclEncryptedReadKey")
-	proto.RegisterType((*AclAccountPermissionChange)(nil), "aclrecord.AclAccountPermissionChange")
-	proto.RegisterType((*AclReadKeyChange)(nil), "aclrecord.AclReadKeyChange")
-	proto.RegisterType((*AclAccountRemove)(nil), "aclrecord.AclAccountRemove")
-	proto.RegisterType((*AclAccountRequestRemove)(nil), "aclrecord.AclAccountRequestRemove")
-	proto.RegisterType((*AclContentValue)(nil), "aclrecord.AclContentValue")
-	proto.RegisterType((*AclData)(nil), "aclrecord.AclData")
+// AclRawStateSnapshot is the locally stored snapshot of acl state, signed by the account
+type AclRawStateSnapshot struct {
+	Payload   []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func init() {
-	proto.RegisterFile("commonspace/object/acl/aclrecordproto/protos/aclrecord.proto", fileDescriptor_c8e9f754f34e929b)
+func (m *AclRawStateSnapshot) Reset()         { *m = AclRawStateSnapshot{} }
+func (m *AclRawStateSnapshot) String() string { return proto.CompactTextString(m) }
+func (*AclRawStateSnapshot) ProtoMessage()    {}
+func (*AclRawStateSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{20}
+}
+func (m *AclRawStateSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclRawStateSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclRawStateSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclRawStateSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclRawStateSnapshot.Merge(m, src)
+}
+func (m *AclRawStateSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclRawStateSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclRawStateSnapshot.DiscardUnknown(m)
 }
 
-var fileDescriptor_c8e9f754f34e929b = []byte{
-	// 880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x8f, 0xdb, 0x44,
-	0x14, 0xb7, 0x93, 0xdd, 0xcd, 0xe6, 0x79, 0xbb, 0x35, 0x53, 0x68, 0xcd, 0x02, 0x56, 0x30, 0x2a,
-	0x8a, 0x2a, 0xd4, 0xad, 0x82, 0x40, 0xa8, 0x42, 0x6a, 0xdd, 0xdd, 0x8a, 0xa4, 0xa5, 0x50, 0x4d,
-	0xc5, 0x87, 0x90, 0x38, 0xcc, 0x8e, 0x9f, 0x8a, 0xc1, 0x1f, 0x61, 0x3c, 0x09, 0xca, 0x7f, 0xc1,
-	0x1f, 0xc3, 0x95, 0x3b, 0x07, 0x0e, 0x3d, 0x72, 0x44, 0xbb, 0x37, 0x6e, 0xfc, 0x07, 0x68, 0xc6,
-	0x4e, 0xfc, 0x11, 0x27, 0xcb, 0x1e, 0x76, 0x63, 0xbf, 0xf7, 0x7e, 0xef, 0xe3, 0xf7, 0xde, 0xbc,
-	0x31, 0x7c, 0xca, 0xd3, 0x38, 0x4e, 0x93, 0x6c, 0xca, 0x38, 0x1e, 0xa7, 0x67, 0x3f, 0x22, 0x97,
-	0xc7, 0x8c, 0x47, 0xea, 0x4f, 0x20, 0x4f, 0x45, 0x30, 0x15, 0xa9, 0x4c, 0x8f, 0xf5, 0xff, 0xac,
-	0x94, 0xde, 0xd5, 0x02, 0xd2, 0x5f, 0x09, 0xbc, 0x7f, 0x3b, 0xd0, 0xf3, 0x79, 0x44, 0xd3, 0x54,
-	0x92, 0x23, 0xd8, 0x0f, 0x03, 0x4c, 0x64, 0x28, 0x17, 0x8e, 0x39, 0x30, 0x87, 0x07, 0x74, 0xf5,
-	0x4e, 0xde, 0x86, 0x7e, 0xcc, 0x32, 0x89, 0xe2, 0x29, 0x2e, 0x9c, 0x8e, 0x56, 0x96, 0x02, 0xe2,
-	0x40, 0x4f, 0xa7, 0x32, 0x09, 0x9c, 0xee, 0xc0, 0x1c, 0xf6, 0xe9, 0xf2, 0x95, 0xdc, 0x01, 0x1b,
-	0x13, 0x2e, 0x16, 0x53, 0x89, 0x01, 0x45, 0x16, 0x28, 0xf8, 0x8e, 0x86, 0xaf, 0xc9, 0x55, 0x0c,
-	0x19, 0xc6, 0x98, 0x49, 0x16, 0x4f, 0x9d, 0xdd, 0x81, 0x39, 0xec, 0xd2, 0x52, 0x40, 0x3e, 0x80,
-	0xd7, 0x96, 0xd9, 0xbc, 0x08, 0x5f, 0x26, 0x4c, 0xce, 0x04, 0x3a, 0x7b, 0xda, 0xd5, 0xba, 0x82,
-	0xbc, 0x0f, 0x87, 0x31, 0x4a, 0x16, 0x30, 0xc9, 0x9e, 0xcf, 0xce, 0x54, 0xd4, 0x9e, 0x36, 0x6d,
-	0x48, 0xc9, 0x7d, 0x70, 0x56, 0x79, 0x3c, 0x5b, 0xaa, 0x44, 0x38, 0x57, 0x88, 0x7d, 0x8d, 0xd8,
-	0xa8, 0x27, 0x1f, 0xc3, 0xcd, 0x95, 0xee, 0xcb, 0x5f, 0x12, 0x14, 0x4b, 0x03, 0xa7, 0xaf, 0x91,
-	0x1b, 0xb4, 0xde, 0x3d, 0xb0, 0x7d, 0x1e, 0xf9, 0x9c, 0xa7, 0xb3, 0x44, 0x4e, 0x92, 0x79, 0x28,
-	0x51, 0xd5, 0x1e, 0xea, 0x27, 0x15, 0x38, 0x27, 0xbf, 0x14, 0x78, 0xbf, 0x9b, 0xf0, 0x46, 0x09,
-	0xa1, 0xf8, 0xf3, 0x0c, 0x33, 0xf9, 0x24, 0x0d, 0x13, 0x55, 0x67, 0x6e, 0x36, 0xa9, 0x77, 0xae,
-	0x21, 0x2d, 0xed, 0xa8, 0xee, 0xfb, 0x24, 0xd0, 0x4d, 0xec, 0xd3, 0x86, 0x94, 0x7c, 0x02, 0xb7,
-	0xea, 0xc8, 0x92, 0xeb, 0xae, 0x76, 0xbc, 0x49, 0xad, 0xa6, 0x67, 0xc9, 0x6d, 0xd1, 0xe1, 0xd5,
-	0xbb, 0xf7, 0xa7, 0x09, 0xb7, 0xd6, 0xf2, 0xf7, 0x39, 0xc7, 0xe9, 0xf6, 0xa9, 0x1b, 0xc2, 0x75,
-	0x91, 0x1b, 0x37, 0xd2, 0x6e, 0x8a, 0x5b, 0xe7, 0xac, 0xbb, 0x61, 0xce, 0x1e, 0x80, 0x35, 0x45,
-	0x11, 0x87, 0x59, 0x16, 0xa6, 0x49, 0xa6, 0x93, 0x3d, 0x1c, 0xbd, 0x73, 0xb7, 0x3c, 0x25, 0x3e,
-	0x8f, 0xbe, 0xca, 0x50, 0x3c, 0x2f, 0x8d, 0x68, 0x15, 0xe1, 0x9d, 0x82, 0xb3, 0x56, 0xcd, 0x29,
-	0xf2, 0x28, 0x4c, 0xb0, 0x2d, 0x65, 0xb3, 0x35, 0x65, 0xef, 0x21, 0xdc, 0x6c, 0x8e, 0x01, 0xc5,
-	0x79, 0xfa, 0x13, 0xb6, 0x34, 0xcb, 0x6c, 0x6b, 0x96, 0xf7, 0x3d, 0xdc, 0xf0, 0x79, 0xf4, 0xb8,
-	0x59, 0xdf, 0x36, 0x46, 0xdb, 0x78, 0xea, 0xb4, 0xf3, 0xe4, 0x2d, 0xe0, 0xa8, 0x4c, 0xb0, 0x24,
-	0xe3, 0xe4, 0x07, 0x96, 0xbc, 0xc4, 0xad, 0x51, 0x1a, 0x0c, 0x77, 0xae, 0xcc, 0xf0, 0x3f, 0xa6,
-	0x3e, 0x23, 0x45, 0x26, 0x45, 0xc4, 0x87, 0x60, 0xb1, 0x3c, 0x99, 0xa7, 0xb8, 0xc8, 0x1c, 0x73,
-	0xd0, 0x1d, 0x5a, 0x23, 0xb7, 0xee, 0xb5, 0x49, 0x06, 0xad, 0x42, 0x5a, 0xb6, 0x42, 0xe7, 0xca,
-	0x5b, 0xa1, 0x7b, 0xc9, 0x56, 0xb8, 0x07, 0x37, 0xca, 0x73, 0x1f, 0x35, 0x96, 0x5e, 0x9b, 0xca,
-	0x9b, 0x55, 0xf7, 0x01, 0xc5, 0x38, 0x9d, 0x23, 0x71, 0x01, 0x0a, 0x36, 0x43, 0xcc, 0x4b, 0x3d,
-	0xa0, 0x15, 0x09, 0xf1, 0xe1, 0x9a, 0xa8, 0x92, 0xa3, 0x0b, 0xb1, 0x46, 0x6f, 0xd5, 0xd9, 0xa8,
-	0xf1, 0x47, 0xeb, 0x08, 0xef, 0xcd, 0x96, 0x33, 0x99, 0x47, 0xf7, 0x7e, 0xdb, 0x85, 0xeb, 0x3e,
-	0x8f, 0x4e, 0xd2, 0x44, 0x62, 0x22, 0xbf, 0x66, 0xd1, 0x0c, 0xc9, 0x47, 0xb0, 0x97, 0x8f, 0x9f,
-	0xee, 0xf6, 0x5a, 0xa8, 0xda, 0x1c, 0x8f, 0x0d, 0x5a, 0x18, 0x93, 0xcf, 0xe0, 0x20, 0xac, 0xcc,
-	0x76, 0x91, 0xe7, 0xbb, 0x5b, 0xc0, 0xb9, 0xe1, 0xd8, 0xa0, 0x35, 0x20, 0x39, 0x05, 0x4b, 0x94,
-	0x8b, 0x4f, 0xb7, 0xc1, 0x1a, 0x0d, 0x5a, 0xfd, 0x54, 0x16, 0xe4, 0xd8, 0xa0, 0x55, 0x18, 0x79,
-	0xa2, 0x78, 0xab, 0xac, 0x1f, 0xdd, 0x17, 0x6b, 0xe4, 0x6d, 0xf3, 0x93, 0x5b, 0x8e, 0x0d, 0x5a,
-	0x87, 0x92, 0x17, 0x60, 0x4f, 0x1b, 0xa7, 0x42, 0x5f, 0x5b, 0xd6, 0xe8, 0x76, 0xab, 0xbb, 0xe6,
-	0x11, 0x1a, 0x1b, 0x74, 0xcd, 0x01, 0x39, 0x81, 0x6b, 0xac, 0x3a, 0x09, 0xfa, 0x8a, 0xdb, 0xc4,
-	0x76, 0x6e, 0xa2, 0x32, 0xab, 0x61, 0x94, 0x93, 0xfa, 0x74, 0xf4, 0x2e, 0x9d, 0x8e, 0xbc, 0xbc,
-	0xea, 0x71, 0x7b, 0x06, 0x87, 0xa2, 0xb6, 0xdb, 0xf4, 0x85, 0x68, 0x8d, 0xde, 0xdb, 0xc6, 0x55,
-	0x61, 0x3a, 0x36, 0x68, 0x03, 0x4c, 0xbe, 0x85, 0xd7, 0x59, 0xcb, 0xac, 0xe9, 0xbb, 0xf2, 0x92,
-	0x06, 0xac, 0xca, 0x6c, 0xf5, 0xf0, 0xa8, 0x07, 0xbb, 0x73, 0x35, 0xa2, 0xde, 0x63, 0xfd, 0x2d,
-	0x73, 0xca, 0x24, 0x23, 0xf7, 0x01, 0xd8, 0x6a, 0x80, 0x8b, 0x55, 0x71, 0x54, 0x8f, 0x51, 0x9d,
-	0x6e, 0x5a, 0xb1, 0xbe, 0xf3, 0x39, 0x90, 0xf5, 0xfd, 0x44, 0xf6, 0x61, 0xe7, 0x8b, 0x34, 0x41,
-	0xdb, 0x20, 0x7d, 0xd8, 0xd5, 0x17, 0xba, 0x6d, 0xaa, 0x47, 0x3f, 0x88, 0xc3, 0xc4, 0xee, 0x10,
-	0x80, 0xbd, 0x6f, 0x44, 0x28, 0x51, 0xd8, 0x5d, 0xf5, 0xac, 0xc8, 0x45, 0x61, 0xef, 0x3c, 0x7a,
-	0xf0, 0xc7, 0xb9, 0x6b, 0xbe, 0x3a, 0x77, 0xcd, 0xbf, 0xcf, 0x5d, 0xf3, 0xd7, 0x0b, 0xd7, 0x78,
-	0x75, 0xe1, 0x1a, 0x7f, 0x5d, 0xb8, 0xc6, 0x77, 0xb7, 0xff, 0xd7, 0x47, 0xdc, 0xd9, 0x9e, 0xfe,
-	0xf9, 0xf0, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcb, 0x8c, 0x12, 0xda, 0xf4, 0x09, 0x00, 0x00,
+var xxx_messageInfo_AclRawStateSnapshot proto.InternalMessageInfo
+
+func (m *AclRawStateSnapshot) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
 }
 
-func (m *AclRoot) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclRawStateSnapshot) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclRoot) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AclStateSnapshotPayload contains the acl state at a specific record, encrypted with the key derived from the account key
+type AclStateSnapshotPayload struct {
+	AclId          string `protobuf:"bytes,1,opt,name=aclId,proto3" json:"aclId,omitempty"`
+	RecordId       string `protobuf:"bytes,2,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	Identity       []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	EncryptedState []byte `protobuf:"bytes,4,opt,name=encryptedState,proto3" json:"encryptedState,omitempty"`
 }
 
-func (m *AclRoot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.EncryptedOwnerMetadata) > 0 {
-		i -= len(m.EncryptedOwnerMetadata)
-		copy(dAtA[i:], m.EncryptedOwnerMetadata)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedOwnerMetadata)))
-		i--
-		dAtA[i] = 0x4a
-	}
-	if len(m.EncryptedMetadataPrivKey) > 0 {
-		i -= len(m.EncryptedMetadataPrivKey)
-		copy(dAtA[i:], m.EncryptedMetadataPrivKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedMetadataPrivKey)))
-		i--
-		dAtA[i] = 0x42
+func (m *AclStateSnapshotPayload) Reset()         { *m = AclStateSnapshotPayload{} }
+func (m *AclStateSnapshotPayload) String() string { return proto.CompactTextString(m) }
+func (*AclStateSnapshotPayload) ProtoMessage()    {}
+func (*AclStateSnapshotPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{21}
+}
+func (m *AclStateSnapshotPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclStateSnapshotPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclStateSnapshotPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.MetadataPubKey) > 0 {
-		i -= len(m.MetadataPubKey)
-		copy(dAtA[i:], m.MetadataPubKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPubKey)))
-		i--
-		dAtA[i] = 0x3a
+}
+func (m *AclStateSnapshotPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclStateSnapshotPayload.Merge(m, src)
+}
+func (m *AclStateSnapshotPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclStateSnapshotPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclStateSnapshotPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclStateSnapshotPayload proto.InternalMessageInfo
+
+func (m *AclStateSnapshotPayload) GetAclId() string {
+	if m != nil {
+		return m.AclId
 	}
-	if len(m.IdentitySignature) > 0 {
-		i -= len(m.IdentitySignature)
-		copy(dAtA[i:], m.IdentitySignature)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.IdentitySignature)))
-		i--
-		dAtA[i] = 0x32
+	return ""
+}
+
+func (m *AclStateSnapshotPayload) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	if m.Timestamp != 0 {
-		i = encodeVarintAclrecord(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.EncryptedReadKey) > 0 {
-		i -= len(m.EncryptedReadKey)
-		copy(dAtA[i:], m.EncryptedReadKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.SpaceId) > 0 {
-		i -= len(m.SpaceId)
-		copy(dAtA[i:], m.SpaceId)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.SpaceId)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.MasterKey) > 0 {
-		i -= len(m.MasterKey)
-		copy(dAtA[i:], m.MasterKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MasterKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Identity) > 0 {
-		i -= len(m.Identity)
-		copy(dAtA[i:], m.Identity)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *AclStateSnapshotPayload) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountInvite) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclStateSnapshotPayload) GetEncryptedState() []byte {
+	if m != nil {
+		return m.EncryptedState
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclAccountInvite) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AclStateSnapshot is the materialized state of the acl after applying all records up to recordId
+type AclStateSnapshot struct {
+	RecordId           string                          `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	ReadKeyChanges     []string                        `protobuf:"bytes,2,rep,name=readKeyChanges,proto3" json:"readKeyChanges,omitempty"`
+	Keys               []*AclSnapshotKeys              `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	Accounts           []*AclSnapshotAccount           `protobuf:"bytes,4,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	StatesAtRecord     []*AclSnapshotRecordState       `protobuf:"bytes,5,rep,name=statesAtRecord,proto3" json:"statesAtRecord,omitempty"`
+	Invites            []*AclSnapshotInvite            `protobuf:"bytes,6,rep,name=invites,proto3" json:"invites,omitempty"`
+	Requests           []*AclSnapshotRequest           `protobuf:"bytes,7,rep,name=requests,proto3" json:"requests,omitempty"`
+	Roles              []*AclRole                      `protobuf:"bytes,8,rep,name=roles,proto3" json:"roles,omitempty"`
+	OwnershipTransfers []*AclSnapshotOwnershipTransfer `protobuf:"bytes,9,rep,name=ownershipTransfers,proto3" json:"ownershipTransfers,omitempty"`
 }
 
-func (m *AclAccountInvite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.InviteKey) > 0 {
-		i -= len(m.InviteKey)
-		copy(dAtA[i:], m.InviteKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteKey)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclStateSnapshot) Reset()         { *m = AclStateSnapshot{} }
+func (m *AclStateSnapshot) String() string { return proto.CompactTextString(m) }
+func (*AclStateSnapshot) ProtoMessage()    {}
+func (*AclStateSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{22}
+}
+func (m *AclStateSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclStateSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclStateSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AclStateSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclStateSnapshot.Merge(m, src)
+}
+func (m *AclStateSnapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclStateSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclStateSnapshot.DiscardUnknown(m)
 }
 
-func (m *AclAccountRequestJoin) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AclStateSnapshot proto.InternalMessageInfo
+
+func (m *AclStateSnapshot) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AclAccountRequestJoin) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclStateSnapshot) GetReadKeyChanges() []string {
+	if m != nil {
+		return m.ReadKeyChanges
+	}
+	return nil
 }
 
-func (m *AclAccountRequestJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		i -= len(m.Metadata)
-		copy(dAtA[i:], m.Metadata)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Metadata)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.InviteIdentitySignature) > 0 {
-		i -= len(m.InviteIdentitySignature)
-		copy(dAtA[i:], m.InviteIdentitySignature)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteIdentitySignature)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.InviteRecordId) > 0 {
-		i -= len(m.InviteRecordId)
-		copy(dAtA[i:], m.InviteRecordId)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.InviteIdentity) > 0 {
-		i -= len(m.InviteIdentity)
-		copy(dAtA[i:], m.InviteIdentity)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteIdentity)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclStateSnapshot) GetKeys() []*AclSnapshotKeys {
+	if m != nil {
+		return m.Keys
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountRequestAccept) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclStateSnapshot) GetAccounts() []*AclSnapshotAccount {
+	if m != nil {
+		return m.Accounts
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclAccountRequestAccept) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclStateSnapshot) GetStatesAtRecord() []*AclSnapshotRecordState {
+	if m != nil {
+		return m.StatesAtRecord
+	}
+	return nil
 }
 
-func (m *AclAccountRequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Permissions != 0 {
-		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
-		i--
-		dAtA[i] = 0x20
-	}
-	if len(m.EncryptedReadKey) > 0 {
-		i -= len(m.EncryptedReadKey)
-		copy(dAtA[i:], m.EncryptedReadKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.RequestRecordId) > 0 {
-		i -= len(m.RequestRecordId)
-		copy(dAtA[i:], m.RequestRecordId)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestRecordId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Identity) > 0 {
-		i -= len(m.Identity)
-		copy(dAtA[i:], m.Identity)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclStateSnapshot) GetInvites() []*AclSnapshotInvite {
+	if m != nil {
+		return m.Invites
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountRequestDecline) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclStateSnapshot) GetRequests() []*AclSnapshotRequest {
+	if m != nil {
+		return m.Requests
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclAccountRequestDecline) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclStateSnapshot) GetRoles() []*AclRole {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
 }
 
-func (m *AclAccountRequestDecline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.RequestRecordId) > 0 {
-		i -= len(m.RequestRecordId)
-		copy(dAtA[i:], m.RequestRecordId)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestRecordId)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclStateSnapshot) GetOwnershipTransfers() []*AclSnapshotOwnershipTransfer {
+	if m != nil {
+		return m.OwnershipTransfers
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountInviteRevoke) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// AclSnapshotKeys are the decrypted keys introduced by some record
+type AclSnapshotKeys struct {
+	RecordId        string `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	ReadKey         []byte `protobuf:"bytes,2,opt,name=readKey,proto3" json:"readKey,omitempty"`
+	MetadataPrivKey []byte `protobuf:"bytes,3,opt,name=metadataPrivKey,proto3" json:"metadataPrivKey,omitempty"`
+	MetadataPubKey  []byte `protobuf:"bytes,4,opt,name=metadataPubKey,proto3" json:"metadataPubKey,omitempty"`
 }
 
-func (m *AclAccountInviteRevoke) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclSnapshotKeys) Reset()         { *m = AclSnapshotKeys{} }
+func (m *AclSnapshotKeys) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotKeys) ProtoMessage()    {}
+func (*AclSnapshotKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{23}
+}
+func (m *AclSnapshotKeys) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotKeys.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AclSnapshotKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotKeys.Merge(m, src)
+}
+func (m *AclSnapshotKeys) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotKeys.DiscardUnknown(m)
 }
 
-func (m *AclAccountInviteRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.InviteRecordId) > 0 {
-		i -= len(m.InviteRecordId)
-		copy(dAtA[i:], m.InviteRecordId)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_AclSnapshotKeys proto.InternalMessageInfo
+
+func (m *AclSnapshotKeys) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *AclEncryptedReadKey) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclSnapshotKeys) GetReadKey() []byte {
+	if m != nil {
+		return m.ReadKey
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclEncryptedReadKey) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AclEncryptedReadKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.EncryptedReadKey) > 0 {
-		i -= len(m.EncryptedReadKey)
-		copy(dAtA[i:], m.EncryptedReadKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Identity) > 0 {
-		i -= len(m.Identity)
-		copy(dAtA[i:], m.Identity)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclSnapshotKeys) GetMetadataPrivKey() []byte {
+	if m != nil {
+		return m.MetadataPrivKey
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountPermissionChange) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclSnapshotKeys) GetMetadataPubKey() []byte {
+	if m != nil {
+		return m.MetadataPubKey
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclAccountPermissionChange) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AclSnapshotAccount is the state of a single account
+type AclSnapshotAccount struct {
+	Identity        []byte             `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	Permissions     AclUserPermissions `protobuf:"varint,2,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	Role            string             `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Capabilities    uint64             `protobuf:"varint,4,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	RequestMetadata []byte             `protobuf:"bytes,5,opt,name=requestMetadata,proto3" json:"requestMetadata,omitempty"`
+	KeyRecordId     string             `protobuf:"bytes,6,opt,name=keyRecordId,proto3" json:"keyRecordId,omitempty"`
 }
 
-func (m *AclAccountPermissionChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Permissions != 0 {
-		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Identity) > 0 {
-		i -= len(m.Identity)
-		copy(dAtA[i:], m.Identity)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
-		i--
-		dAtA[i] = 0xa
+func (m *AclSnapshotAccount) Reset()         { *m = AclSnapshotAccount{} }
+func (m *AclSnapshotAccount) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotAccount) ProtoMessage()    {}
+func (*AclSnapshotAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{24}
+}
+func (m *AclSnapshotAccount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotAccount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AclSnapshotAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotAccount.Merge(m, src)
+}
+func (m *AclSnapshotAccount) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotAccount.DiscardUnknown(m)
 }
 
-func (m *AclReadKeyChange) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AclSnapshotAccount proto.InternalMessageInfo
+
+func (m *AclSnapshotAccount) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclReadKeyChange) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclSnapshotAccount) GetPermissions() AclUserPermissions {
+	if m != nil {
+		return m.Permissions
+	}
+	return AclUserPermissions_None
 }
 
-func (m *AclReadKeyChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.EncryptedOldReadKey) > 0 {
-		i -= len(m.EncryptedOldReadKey)
-		copy(dAtA[i:], m.EncryptedOldReadKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedOldReadKey)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.EncryptedMetadataPrivKey) > 0 {
-		i -= len(m.EncryptedMetadataPrivKey)
-		copy(dAtA[i:], m.EncryptedMetadataPrivKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedMetadataPrivKey)))
-		i--
-		dAtA[i] = 0x1a
+func (m *AclSnapshotAccount) GetRole() string {
+	if m != nil {
+		return m.Role
 	}
-	if len(m.MetadataPubKey) > 0 {
-		i -= len(m.MetadataPubKey)
-		copy(dAtA[i:], m.MetadataPubKey)
-		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPubKey)))
-		i--
-		dAtA[i] = 0x12
+	return ""
+}
+
+func (m *AclSnapshotAccount) GetCapabilities() uint64 {
+	if m != nil {
+		return m.Capabilities
 	}
-	if len(m.AccountKeys) > 0 {
-		for iNdEx := len(m.AccountKeys) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AccountKeys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAclrecord(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	return 0
+}
+
+func (m *AclSnapshotAccount) GetRequestMetadata() []byte {
+	if m != nil {
+		return m.RequestMetadata
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *AclAccountRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclSnapshotAccount) GetKeyRecordId() string {
+	if m != nil {
+		return m.KeyRecordId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AclAccountRemove) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AclSnapshotRecordState contains the accounts which were added or changed by the record
+// and the identities of the removed accounts, the states are in the order of the records
+type AclSnapshotRecordState struct {
+	RecordId          string                `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	Accounts          []*AclSnapshotAccount `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	RemovedIdentities [][]byte              `protobuf:"bytes,3,rep,name=removedIdentities,proto3" json:"removedIdentities,omitempty"`
 }
 
-func (m *AclAccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.ReadKeyChange != nil {
-		{
-			size, err := m.ReadKeyChange.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Identities) > 0 {
-		for iNdEx := len(m.Identities) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Identities[iNdEx])
-			copy(dAtA[i:], m.Identities[iNdEx])
-			i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identities[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *AclSnapshotRecordState) Reset()         { *m = AclSnapshotRecordState{} }
+func (m *AclSnapshotRecordState) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotRecordState) ProtoMessage()    {}
+func (*AclSnapshotRecordState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{25}
+}
+func (m *AclSnapshotRecordState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotRecordState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotRecordState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *AclAccountRequestRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *AclSnapshotRecordState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotRecordState.Merge(m, src)
+}
+func (m *AclSnapshotRecordState) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotRecordState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotRecordState.DiscardUnknown(m)
 }
 
-func (m *AclAccountRequestRemove) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_AclSnapshotRecordState proto.InternalMessageInfo
+
+func (m *AclSnapshotRecordState) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
+	}
+	return ""
 }
 
-func (m *AclAccountRequestRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *AclSnapshotRecordState) GetAccounts() []*AclSnapshotAccount {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
 }
 
-func (m *AclContentValue) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AclSnapshotRecordState) GetRemovedIdentities() [][]byte {
+	if m != nil {
+		return m.RemovedIdentities
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AclContentValue) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AclSnapshotInvite is the invite which was not revoked
+type AclSnapshotInvite struct {
+	RecordId         string             `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	InviteKey        []byte             `protobuf:"bytes,2,opt,name=inviteKey,proto3" json:"inviteKey,omitempty"`
+	InviteType       AclInviteType      `protobuf:"varint,3,opt,name=inviteType,proto3,enum=aclrecord.AclInviteType" json:"inviteType,omitempty"`
+	Permissions      AclUserPermissions `protobuf:"varint,4,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	ExpireTimestamp  int64              `protobuf:"varint,5,opt,name=expireTimestamp,proto3" json:"expireTimestamp,omitempty"`
+	MaxUses          uint32             `protobuf:"varint,6,opt,name=maxUses,proto3" json:"maxUses,omitempty"`
+	UsedCount        uint32             `protobuf:"varint,7,opt,name=usedCount,proto3" json:"usedCount,omitempty"`
+	EncryptedReadKey []byte             `protobuf:"bytes,8,opt,name=encryptedReadKey,proto3" json:"encryptedReadKey,omitempty"`
 }
 
-func (m *AclContentValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Value != nil {
-		{
-			size := m.Value.Size()
-			i -= size
-			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *AclSnapshotInvite) Reset()         { *m = AclSnapshotInvite{} }
+func (m *AclSnapshotInvite) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotInvite) ProtoMessage()    {}
+func (*AclSnapshotInvite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{26}
+}
+func (m *AclSnapshotInvite) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotInvite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotInvite.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *AclContentValue_Invite) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclSnapshotInvite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotInvite.Merge(m, src)
+}
+func (m *AclSnapshotInvite) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotInvite) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotInvite.DiscardUnknown(m)
 }
 
-func (m *AclContentValue_Invite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Invite != nil {
-		{
-			size, err := m.Invite.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+var xxx_messageInfo_AclSnapshotInvite proto.InternalMessageInfo
+
+func (m *AclSnapshotInvite) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	return len(dAtA) - i, nil
-}
-func (m *AclContentValue_InviteRevoke) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *AclContentValue_InviteRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.InviteRevoke != nil {
-		{
-			size, err := m.InviteRevoke.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *AclSnapshotInvite) GetInviteKey() []byte {
+	if m != nil {
+		return m.InviteKey
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *AclContentValue_RequestJoin) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *AclSnapshotInvite) GetInviteType() AclInviteType {
+	if m != nil {
+		return m.InviteType
+	}
+	return AclInviteType_RequestToJoin
 }
 
-func (m *AclContentValue_RequestJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestJoin != nil {
-		{
-			size, err := m.RequestJoin.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (m *AclSnapshotInvite) GetPermissions() AclUserPermissions {
+	if m != nil {
+		return m.Permissions
 	}
-	return len(dAtA) - i, nil
+	return AclUserPermissions_None
 }
-func (m *AclContentValue_RequestAccept) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *AclSnapshotInvite) GetExpireTimestamp() int64 {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return 0
 }
 
-func (m *AclContentValue_RequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestAccept != nil {
-		{
-			size, err := m.RequestAccept.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+func (m *AclSnapshotInvite) GetMaxUses() uint32 {
+	if m != nil {
+		return m.MaxUses
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *AclContentValue_PermissionChange) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *AclSnapshotInvite) GetUsedCount() uint32 {
+	if m != nil {
+		return m.UsedCount
+	}
+	return 0
 }
 
-func (m *AclContentValue_PermissionChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.PermissionChange != nil {
-		{
-			size, err := m.PermissionChange.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+func (m *AclSnapshotInvite) GetEncryptedReadKey() []byte {
+	if m != nil {
+		return m.EncryptedReadKey
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *AclContentValue_AccountRemove) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+// AclSnapshotRequest is the join or remove request, the closed requests are kept as well
+type AclSnapshotRequest struct {
+	RecordId       string `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	Identity       []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Metadata       []byte `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Type           int32  `protobuf:"varint,4,opt,name=type,proto3" json:"type,omitempty"`
+	Pending        bool   `protobuf:"varint,5,opt,name=pending,proto3" json:"pending,omitempty"`
+	InviteRecordId string `protobuf:"bytes,6,opt,name=inviteRecordId,proto3" json:"inviteRecordId,omitempty"`
+	// closedRecordId is the id of the record which closed the request if it is not pending
+	ClosedRecordId string `protobuf:"bytes,7,opt,name=closedRecordId,proto3" json:"closedRecordId,omitempty"`
 }
 
-func (m *AclContentValue_AccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AccountRemove != nil {
-		{
-			size, err := m.AccountRemove.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+func (m *AclSnapshotRequest) Reset()         { *m = AclSnapshotRequest{} }
+func (m *AclSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotRequest) ProtoMessage()    {}
+func (*AclSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{27}
+}
+func (m *AclSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x32
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *AclContentValue_ReadKeyChange) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotRequest.Merge(m, src)
+}
+func (m *AclSnapshotRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotRequest.DiscardUnknown(m)
 }
 
-func (m *AclContentValue_ReadKeyChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.ReadKeyChange != nil {
-		{
-			size, err := m.ReadKeyChange.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+var xxx_messageInfo_AclSnapshotRequest proto.InternalMessageInfo
+
+func (m *AclSnapshotRequest) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
-func (m *AclContentValue_RequestDecline) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *AclSnapshotRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
 }
 
-func (m *AclContentValue_RequestDecline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestDecline != nil {
-		{
-			size, err := m.RequestDecline.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
+func (m *AclSnapshotRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func (m *AclContentValue_AccountRequestRemove) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *AclSnapshotRequest) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
 }
 
-func (m *AclContentValue_AccountRequestRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.AccountRequestRemove != nil {
-		{
-			size, err := m.AccountRequestRemove.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAclrecord(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x4a
+func (m *AclSnapshotRequest) GetPending() bool {
+	if m != nil {
+		return m.Pending
 	}
-	return len(dAtA) - i, nil
+	return false
 }
-func (m *AclData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *AclSnapshotRequest) GetInviteRecordId() string {
+	if m != nil {
+		return m.InviteRecordId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AclData) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AclSnapshotRequest) GetClosedRecordId() string {
+	if m != nil {
+		return m.ClosedRecordId
+	}
+	return ""
 }
 
-func (m *AclData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.AclContent) > 0 {
-		for iNdEx := len(m.AclContent) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.AclContent[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAclrecord(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+// AclSnapshotOwnershipTransfer is the ownership transfer which was not accepted yet
+type AclSnapshotOwnershipTransfer struct {
+	RecordId         string `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	OwnerIdentity    []byte `protobuf:"bytes,2,opt,name=ownerIdentity,proto3" json:"ownerIdentity,omitempty"`
+	NewOwnerIdentity []byte `protobuf:"bytes,3,opt,name=newOwnerIdentity,proto3" json:"newOwnerIdentity,omitempty"`
+	KeepOwnership    bool   `protobuf:"varint,4,opt,name=keepOwnership,proto3" json:"keepOwnership,omitempty"`
+}
+
+func (m *AclSnapshotOwnershipTransfer) Reset()         { *m = AclSnapshotOwnershipTransfer{} }
+func (m *AclSnapshotOwnershipTransfer) String() string { return proto.CompactTextString(m) }
+func (*AclSnapshotOwnershipTransfer) ProtoMessage()    {}
+func (*AclSnapshotOwnershipTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{28}
+}
+func (m *AclSnapshotOwnershipTransfer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclSnapshotOwnershipTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclSnapshotOwnershipTransfer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AclSnapshotOwnershipTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclSnapshotOwnershipTransfer.Merge(m, src)
+}
+func (m *AclSnapshotOwnershipTransfer) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclSnapshotOwnershipTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclSnapshotOwnershipTransfer.DiscardUnknown(m)
 }
 
-func encodeVarintAclrecord(dAtA []byte, offset int, v uint64) int {
-	offset -= sovAclrecord(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_AclSnapshotOwnershipTransfer proto.InternalMessageInfo
+
+func (m *AclSnapshotOwnershipTransfer) GetRecordId() string {
+	if m != nil {
+		return m.RecordId
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *AclRoot) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Identity)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.MasterKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.SpaceId)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedReadKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	if m.Timestamp != 0 {
-		n += 1 + sovAclrecord(uint64(m.Timestamp))
-	}
-	l = len(m.IdentitySignature)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.MetadataPubKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedMetadataPrivKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedOwnerMetadata)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+
+func (m *AclSnapshotOwnershipTransfer) GetOwnerIdentity() []byte {
+	if m != nil {
+		return m.OwnerIdentity
 	}
-	return n
+	return nil
 }
 
-func (m *AclAccountInvite) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.InviteKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+func (m *AclSnapshotOwnershipTransfer) GetNewOwnerIdentity() []byte {
+	if m != nil {
+		return m.NewOwnerIdentity
 	}
-	return n
+	return nil
 }
 
-func (m *AclAccountRequestJoin) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.InviteIdentity)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.InviteRecordId)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.InviteIdentitySignature)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.Metadata)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+func (m *AclSnapshotOwnershipTransfer) GetKeepOwnership() bool {
+	if m != nil {
+		return m.KeepOwnership
 	}
-	return n
+	return false
 }
 
-func (m *AclAccountRequestAccept) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Identity)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.RequestRecordId)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedReadKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+// AclPendingRecords are the operations of the local account which wait to be sent to the consensus node,
+// they are saved encrypted, so the queue is not lost on restart
+type AclPendingRecords struct {
+	Records []*AclPendingRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (m *AclPendingRecords) Reset()         { *m = AclPendingRecords{} }
+func (m *AclPendingRecords) String() string { return proto.CompactTextString(m) }
+func (*AclPendingRecords) ProtoMessage()    {}
+func (*AclPendingRecords) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{29}
+}
+func (m *AclPendingRecords) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclPendingRecords) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclPendingRecords.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Permissions != 0 {
-		n += 1 + sovAclrecord(uint64(m.Permissions))
+}
+func (m *AclPendingRecords) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclPendingRecords.Merge(m, src)
+}
+func (m *AclPendingRecords) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclPendingRecords) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclPendingRecords.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclPendingRecords proto.InternalMessageInfo
+
+func (m *AclPendingRecords) GetRecords() []*AclPendingRecord {
+	if m != nil {
+		return m.Records
 	}
-	return n
+	return nil
 }
 
-func (m *AclAccountRequestDecline) Size() (n int) {
-	if m == nil {
-		return 0
+// AclPendingRecord is the operation which is built again against the head of the acl before it is sent
+type AclPendingRecord struct {
+	// Types that are valid to be assigned to Value:
+	//	*AclPendingRecord_RequestAccept
+	//	*AclPendingRecord_RequestDecline
+	//	*AclPendingRecord_PermissionChange
+	//	*AclPendingRecord_AccountRemove
+	Value isAclPendingRecord_Value `protobuf_oneof:"value"`
+}
+
+func (m *AclPendingRecord) Reset()         { *m = AclPendingRecord{} }
+func (m *AclPendingRecord) String() string { return proto.CompactTextString(m) }
+func (*AclPendingRecord) ProtoMessage()    {}
+func (*AclPendingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{30}
+}
+func (m *AclPendingRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclPendingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclPendingRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.RequestRecordId)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+}
+func (m *AclPendingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclPendingRecord.Merge(m, src)
+}
+func (m *AclPendingRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclPendingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclPendingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclPendingRecord proto.InternalMessageInfo
+
+type isAclPendingRecord_Value interface {
+	isAclPendingRecord_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type AclPendingRecord_RequestAccept struct {
+	RequestAccept *AclPendingRequestAccept `protobuf:"bytes,1,opt,name=requestAccept,proto3,oneof" json:"requestAccept,omitempty"`
+}
+type AclPendingRecord_RequestDecline struct {
+	RequestDecline *AclAccountRequestDecline `protobuf:"bytes,2,opt,name=requestDecline,proto3,oneof" json:"requestDecline,omitempty"`
+}
+type AclPendingRecord_PermissionChange struct {
+	PermissionChange *AclAccountPermissionChange `protobuf:"bytes,3,opt,name=permissionChange,proto3,oneof" json:"permissionChange,omitempty"`
+}
+type AclPendingRecord_AccountRemove struct {
+	AccountRemove *AclPendingAccountRemove `protobuf:"bytes,4,opt,name=accountRemove,proto3,oneof" json:"accountRemove,omitempty"`
+}
+
+func (*AclPendingRecord_RequestAccept) isAclPendingRecord_Value()    {}
+func (*AclPendingRecord_RequestDecline) isAclPendingRecord_Value()   {}
+func (*AclPendingRecord_PermissionChange) isAclPendingRecord_Value() {}
+func (*AclPendingRecord_AccountRemove) isAclPendingRecord_Value()    {}
+
+func (m *AclPendingRecord) GetValue() isAclPendingRecord_Value {
+	if m != nil {
+		return m.Value
 	}
-	return n
+	return nil
 }
 
-func (m *AclAccountInviteRevoke) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AclPendingRecord) GetRequestAccept() *AclPendingRequestAccept {
+	if x, ok := m.GetValue().(*AclPendingRecord_RequestAccept); ok {
+		return x.RequestAccept
 	}
-	var l int
-	_ = l
-	l = len(m.InviteRecordId)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+	return nil
+}
+
+func (m *AclPendingRecord) GetRequestDecline() *AclAccountRequestDecline {
+	if x, ok := m.GetValue().(*AclPendingRecord_RequestDecline); ok {
+		return x.RequestDecline
 	}
-	return n
+	return nil
 }
 
-func (m *AclEncryptedReadKey) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AclPendingRecord) GetPermissionChange() *AclAccountPermissionChange {
+	if x, ok := m.GetValue().(*AclPendingRecord_PermissionChange); ok {
+		return x.PermissionChange
 	}
-	var l int
-	_ = l
-	l = len(m.Identity)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+	return nil
+}
+
+func (m *AclPendingRecord) GetAccountRemove() *AclPendingAccountRemove {
+	if x, ok := m.GetValue().(*AclPendingRecord_AccountRemove); ok {
+		return x.AccountRemove
 	}
-	l = len(m.EncryptedReadKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*AclPendingRecord) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*AclPendingRecord_RequestAccept)(nil),
+		(*AclPendingRecord_RequestDecline)(nil),
+		(*AclPendingRecord_PermissionChange)(nil),
+		(*AclPendingRecord_AccountRemove)(nil),
 	}
-	return n
 }
 
-func (m *AclAccountPermissionChange) Size() (n int) {
-	if m == nil {
-		return 0
+type AclPendingRequestAccept struct {
+	RequestRecordId string             `protobuf:"bytes,1,opt,name=requestRecordId,proto3" json:"requestRecordId,omitempty"`
+	Permissions     AclUserPermissions `protobuf:"varint,2,opt,name=permissions,proto3,enum=aclrecord.AclUserPermissions" json:"permissions,omitempty"`
+	Role            string             `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+}
+
+func (m *AclPendingRequestAccept) Reset()         { *m = AclPendingRequestAccept{} }
+func (m *AclPendingRequestAccept) String() string { return proto.CompactTextString(m) }
+func (*AclPendingRequestAccept) ProtoMessage()    {}
+func (*AclPendingRequestAccept) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{31}
+}
+func (m *AclPendingRequestAccept) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclPendingRequestAccept) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclPendingRequestAccept.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.Identity)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
+}
+func (m *AclPendingRequestAccept) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclPendingRequestAccept.Merge(m, src)
+}
+func (m *AclPendingRequestAccept) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclPendingRequestAccept) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclPendingRequestAccept.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclPendingRequestAccept proto.InternalMessageInfo
+
+func (m *AclPendingRequestAccept) GetRequestRecordId() string {
+	if m != nil {
+		return m.RequestRecordId
 	}
-	if m.Permissions != 0 {
-		n += 1 + sovAclrecord(uint64(m.Permissions))
+	return ""
+}
+
+func (m *AclPendingRequestAccept) GetPermissions() AclUserPermissions {
+	if m != nil {
+		return m.Permissions
 	}
-	return n
+	return AclUserPermissions_None
 }
 
-func (m *AclReadKeyChange) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AclPendingRequestAccept) GetRole() string {
+	if m != nil {
+		return m.Role
 	}
-	var l int
-	_ = l
-	if len(m.AccountKeys) > 0 {
-		for _, e := range m.AccountKeys {
-			l = e.Size()
-			n += 1 + l + sovAclrecord(uint64(l))
-		}
-	}
-	l = len(m.MetadataPubKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedMetadataPrivKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	l = len(m.EncryptedOldReadKey)
-	if l > 0 {
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	return n
+	return ""
 }
 
-func (m *AclAccountRemove) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Identities) > 0 {
-		for _, b := range m.Identities {
-			l = len(b)
-			n += 1 + l + sovAclrecord(uint64(l))
+// AclPendingAccountRemove contains the new keys of the space which are given to the remaining accounts
+type AclPendingAccountRemove struct {
+	Identities      [][]byte `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	MetadataPrivKey []byte   `protobuf:"bytes,2,opt,name=metadataPrivKey,proto3" json:"metadataPrivKey,omitempty"`
+	ReadKey         []byte   `protobuf:"bytes,3,opt,name=readKey,proto3" json:"readKey,omitempty"`
+}
+
+func (m *AclPendingAccountRemove) Reset()         { *m = AclPendingAccountRemove{} }
+func (m *AclPendingAccountRemove) String() string { return proto.CompactTextString(m) }
+func (*AclPendingAccountRemove) ProtoMessage()    {}
+func (*AclPendingAccountRemove) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8e9f754f34e929b, []int{32}
+}
+func (m *AclPendingAccountRemove) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AclPendingAccountRemove) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AclPendingAccountRemove.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.ReadKeyChange != nil {
-		l = m.ReadKeyChange.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
-	}
-	return n
+}
+func (m *AclPendingAccountRemove) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclPendingAccountRemove.Merge(m, src)
+}
+func (m *AclPendingAccountRemove) XXX_Size() int {
+	return m.Size()
+}
+func (m *AclPendingAccountRemove) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclPendingAccountRemove.DiscardUnknown(m)
 }
 
-func (m *AclAccountRequestRemove) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_AclPendingAccountRemove proto.InternalMessageInfo
+
+func (m *AclPendingAccountRemove) GetIdentities() [][]byte {
+	if m != nil {
+		return m.Identities
 	}
-	var l int
-	_ = l
-	return n
+	return nil
 }
 
-func (m *AclContentValue) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
+func (m *AclPendingAccountRemove) GetMetadataPrivKey() []byte {
+	if m != nil {
+		return m.MetadataPrivKey
 	}
-	return n
+	return nil
 }
 
-func (m *AclContentValue_Invite) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Invite != nil {
-		l = m.Invite.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+func (m *AclPendingAccountRemove) GetReadKey() []byte {
+	if m != nil {
+		return m.ReadKey
 	}
-	return n
+	return nil
 }
-func (m *AclContentValue_InviteRevoke) Size() (n int) {
-	if m == nil {
-		return 0
+
+func init() {
+	proto.RegisterEnum("aclrecord.AclInviteType", AclInviteType_name, AclInviteType_value)
+	proto.RegisterEnum("aclrecord.AclUserPermissions", AclUserPermissions_name, AclUserPermissions_value)
+	proto.RegisterEnum("aclrecord.AclCapability", AclCapability_name, AclCapability_value)
+	proto.RegisterType((*AclRoot)(nil), "aclrecord.AclRoot")
+	proto.RegisterType((*AclAccountInvite)(nil), "aclrecord.AclAccountInvite")
+	proto.RegisterType((*AclAccountRequestJoin)(nil), "aclrecord.AclAccountRequestJoin")
+	proto.RegisterType((*AclAccountInviteJoin)(nil), "aclrecord.AclAccountInviteJoin")
+	proto.RegisterType((*AclAccountRequestAccept)(nil), "aclrecord.AclAccountRequestAccept")
+	proto.RegisterType((*AclAccountRequestDecline)(nil), "aclrecord.AclAccountRequestDecline")
+	proto.RegisterType((*AclAccountInviteRevoke)(nil), "aclrecord.AclAccountInviteRevoke")
+	proto.RegisterType((*AclEncryptedReadKey)(nil), "aclrecord.AclEncryptedReadKey")
+	proto.RegisterType((*AclAccountPermissionChange)(nil), "aclrecord.AclAccountPermissionChange")
+	proto.RegisterType((*AclRole)(nil), "aclrecord.AclRole")
+	proto.RegisterType((*AclRoleDefine)(nil), "aclrecord.AclRoleDefine")
+	proto.RegisterType((*AclRoleRemove)(nil), "aclrecord.AclRoleRemove")
+	proto.RegisterType((*AclReadKeyChange)(nil), "aclrecord.AclReadKeyChange")
+	proto.RegisterType((*AclAccountRemove)(nil), "aclrecord.AclAccountRemove")
+	proto.RegisterType((*AclAccountRequestRemove)(nil), "aclrecord.AclAccountRequestRemove")
+	proto.RegisterType((*AclOwnershipTransfer)(nil), "aclrecord.AclOwnershipTransfer")
+	proto.RegisterType((*AclOwnershipAccept)(nil), "aclrecord.AclOwnershipAccept")
+	proto.RegisterType((*AclAccountMetadataUpdate)(nil), "aclrecord.AclAccountMetadataUpdate")
+	proto.RegisterType((*AclContentValue)(nil), "aclrecord.AclContentValue")
+	proto.RegisterType((*AclData)(nil), "aclrecord.AclData")
+	proto.RegisterType((*AclRawStateSnapshot)(nil), "aclrecord.AclRawStateSnapshot")
+	proto.RegisterType((*AclStateSnapshotPayload)(nil), "aclrecord.AclStateSnapshotPayload")
+	proto.RegisterType((*AclStateSnapshot)(nil), "aclrecord.AclStateSnapshot")
+	proto.RegisterType((*AclSnapshotKeys)(nil), "aclrecord.AclSnapshotKeys")
+	proto.RegisterType((*AclSnapshotAccount)(nil), "aclrecord.AclSnapshotAccount")
+	proto.RegisterType((*AclSnapshotRecordState)(nil), "aclrecord.AclSnapshotRecordState")
+	proto.RegisterType((*AclSnapshotInvite)(nil), "aclrecord.AclSnapshotInvite")
+	proto.RegisterType((*AclSnapshotRequest)(nil), "aclrecord.AclSnapshotRequest")
+	proto.RegisterType((*AclSnapshotOwnershipTransfer)(nil), "aclrecord.AclSnapshotOwnershipTransfer")
+	proto.RegisterType((*AclPendingRecords)(nil), "aclrecord.AclPendingRecords")
+	proto.RegisterType((*AclPendingRecord)(nil), "aclrecord.AclPendingRecord")
+	proto.RegisterType((*AclPendingRequestAccept)(nil), "aclrecord.AclPendingRequestAccept")
+	proto.RegisterType((*AclPendingAccountRemove)(nil), "aclrecord.AclPendingAccountRemove")
+}
+
+func init() {
+	proto.RegisterFile("commonspace/object/acl/aclrecordproto/protos/aclrecord.proto", fileDescriptor_c8e9f754f34e929b)
+}
+
+var fileDescriptor_c8e9f754f34e929b = []byte{
+	// 1956 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x19, 0x4d, 0x6f, 0xdc, 0xc6,
+	0x75, 0x49, 0xee, 0xe7, 0x5b, 0xed, 0x8a, 0x1a, 0x7f, 0x31, 0xb6, 0xa2, 0x28, 0x4c, 0xe2, 0x0a,
+	0x42, 0x60, 0x17, 0x5b, 0x24, 0x4d, 0x8d, 0xa0, 0xf1, 0x46, 0x0a, 0xa2, 0xb5, 0xeb, 0x5a, 0x18,
+	0xdb, 0x4d, 0xd0, 0xa2, 0x07, 0x8a, 0x3b, 0xb1, 0x59, 0xef, 0x92, 0x5b, 0x92, 0x2b, 0x67, 0x0f,
+	0xbd, 0xf4, 0xde, 0x8f, 0x63, 0x8b, 0x22, 0x7f, 0xa0, 0xc7, 0xde, 0x7b, 0xef, 0x31, 0xa7, 0xb6,
+	0xe8, 0x29, 0xb0, 0x81, 0xde, 0x7b, 0xe8, 0xbd, 0x98, 0xe1, 0x90, 0x9c, 0x19, 0xce, 0x52, 0x56,
+	0x82, 0xb6, 0x07, 0x49, 0x9c, 0x37, 0xef, 0xbd, 0x99, 0xf7, 0xfd, 0xde, 0x08, 0xde, 0xf7, 0xa3,
+	0xf9, 0x3c, 0x0a, 0x93, 0x85, 0xe7, 0x93, 0x9b, 0xd1, 0xc9, 0xcf, 0x88, 0x9f, 0xde, 0xf4, 0xfc,
+	0x19, 0xfd, 0x89, 0x89, 0x1f, 0xc5, 0xd3, 0x45, 0x1c, 0xa5, 0xd1, 0x4d, 0xf6, 0x3b, 0x29, 0xa1,
+	0x37, 0x18, 0x00, 0xf5, 0x0a, 0x80, 0xfb, 0x2f, 0x13, 0x3a, 0x63, 0x7f, 0x86, 0xa3, 0x28, 0x45,
+	0x57, 0xa1, 0x1b, 0x4c, 0x49, 0x98, 0x06, 0xe9, 0xca, 0x31, 0x76, 0x8d, 0xbd, 0x0d, 0x5c, 0xac,
+	0xd1, 0x36, 0xf4, 0xe6, 0x5e, 0x92, 0x92, 0xf8, 0x2e, 0x59, 0x39, 0x26, 0xdb, 0x2c, 0x01, 0xc8,
+	0x81, 0x0e, 0xbb, 0xca, 0x64, 0xea, 0x58, 0xbb, 0xc6, 0x5e, 0x0f, 0xe7, 0x4b, 0xb4, 0x0f, 0x36,
+	0x09, 0xfd, 0x78, 0xb5, 0x48, 0xc9, 0x14, 0x13, 0x6f, 0x4a, 0xc9, 0x9b, 0x8c, 0xbc, 0x02, 0xa7,
+	0x67, 0xa4, 0xc1, 0x9c, 0x24, 0xa9, 0x37, 0x5f, 0x38, 0xad, 0x5d, 0x63, 0xcf, 0xc2, 0x25, 0x00,
+	0xbd, 0x0d, 0x5b, 0xf9, 0x6d, 0x1e, 0x04, 0x8f, 0x43, 0x2f, 0x5d, 0xc6, 0xc4, 0x69, 0x33, 0x56,
+	0xd5, 0x0d, 0x74, 0x1d, 0x86, 0x73, 0x92, 0x7a, 0x53, 0x2f, 0xf5, 0x8e, 0x97, 0x27, 0xf4, 0xd4,
+	0x0e, 0x43, 0x55, 0xa0, 0xe8, 0x16, 0x38, 0xc5, 0x3d, 0xee, 0xe5, 0x5b, 0x71, 0x70, 0x4a, 0x29,
+	0xba, 0x8c, 0x62, 0xed, 0x3e, 0x7a, 0x17, 0x2e, 0x17, 0x7b, 0xf7, 0x9f, 0x85, 0x24, 0xce, 0x11,
+	0x9c, 0x1e, 0xa3, 0x5c, 0xb3, 0xeb, 0xfe, 0xc1, 0x04, 0x7b, 0xec, 0xcf, 0xc6, 0xbe, 0x1f, 0x2d,
+	0xc3, 0x74, 0x12, 0x9e, 0x06, 0x29, 0xa1, 0xc2, 0x07, 0xec, 0xeb, 0x2e, 0xc9, 0xb5, 0x5f, 0x02,
+	0xd0, 0x1e, 0x6c, 0x92, 0xcf, 0x17, 0x41, 0x4c, 0x1e, 0x16, 0x0a, 0x32, 0x99, 0x82, 0x54, 0x30,
+	0x35, 0xc5, 0xdc, 0xfb, 0xfc, 0x51, 0x42, 0x12, 0x66, 0x8a, 0x01, 0xce, 0x97, 0xe8, 0x3d, 0x80,
+	0x8c, 0xe1, 0xc3, 0xd5, 0x82, 0x30, 0x23, 0x0c, 0x47, 0xce, 0x8d, 0xd2, 0x37, 0xc6, 0xfe, 0x6c,
+	0x52, 0xec, 0x63, 0x01, 0x17, 0x7d, 0x00, 0xfd, 0x05, 0x89, 0xe7, 0x41, 0x92, 0x04, 0x51, 0x98,
+	0x30, 0xd3, 0x0c, 0x47, 0xaf, 0xca, 0xa4, 0x8f, 0x12, 0x12, 0x1f, 0x97, 0x48, 0x58, 0xa4, 0xd0,
+	0x7a, 0x41, 0x5b, 0xef, 0x05, 0xee, 0x9f, 0x0d, 0xb8, 0x54, 0x6a, 0x07, 0x93, 0x9f, 0x2f, 0x49,
+	0x92, 0xde, 0x89, 0x82, 0x90, 0xda, 0x34, 0xbb, 0xd4, 0x44, 0xf6, 0x52, 0x05, 0x5a, 0xe2, 0x61,
+	0x76, 0xbb, 0xc9, 0x94, 0xe9, 0xaa, 0x87, 0x15, 0x28, 0x7a, 0x0f, 0xae, 0xc8, 0x94, 0xa5, 0x5f,
+	0x59, 0x8c, 0xf1, 0xba, 0x6d, 0x1a, 0x29, 0xb9, 0x1f, 0x71, 0x6f, 0x2e, 0xd6, 0xee, 0x17, 0x26,
+	0x5c, 0x54, 0xad, 0xcb, 0xae, 0x5f, 0x17, 0x5e, 0xff, 0xd7, 0x2b, 0x6b, 0xcd, 0xd3, 0x5a, 0x13,
+	0xa4, 0x8a, 0x2f, 0xb4, 0xcf, 0xeb, 0x0b, 0xee, 0x57, 0x06, 0x5c, 0xa9, 0xd8, 0x77, 0xec, 0xfb,
+	0x64, 0x51, 0x9f, 0x81, 0xf6, 0x60, 0x33, 0xce, 0x90, 0x15, 0x1d, 0xa9, 0x60, 0xad, 0x38, 0xd6,
+	0xcb, 0x89, 0xd3, 0x3c, 0xb7, 0x6b, 0x23, 0x68, 0xc6, 0xd1, 0x8c, 0x30, 0x7d, 0xf5, 0x30, 0xfb,
+	0x76, 0x0f, 0xc1, 0xa9, 0x48, 0x78, 0x48, 0xfc, 0x59, 0x10, 0x12, 0x9d, 0x18, 0x86, 0x56, 0x0c,
+	0xf7, 0x36, 0x5c, 0x56, 0xfd, 0x08, 0x93, 0xd3, 0xe8, 0x29, 0xd1, 0x78, 0x8b, 0xa1, 0xf3, 0x16,
+	0xf7, 0xa7, 0x70, 0x61, 0xec, 0xcf, 0x3e, 0x52, 0x65, 0xae, 0xd3, 0xb2, 0x4e, 0x77, 0xe6, 0x9a,
+	0x48, 0xfd, 0x95, 0x01, 0x57, 0xcb, 0x1b, 0x96, 0x1a, 0x3a, 0x78, 0xe2, 0x85, 0x8f, 0x49, 0xed,
+	0x31, 0x8a, 0xda, 0xcd, 0xaf, 0xad, 0x76, 0x4b, 0x50, 0xfb, 0x4f, 0x78, 0x29, 0x9b, 0x11, 0xba,
+	0x1d, 0x7a, 0x73, 0xc2, 0xf5, 0xc2, 0xbe, 0xd1, 0xfb, 0xb0, 0xe1, 0x7b, 0x0b, 0xef, 0x24, 0x98,
+	0x05, 0x69, 0x40, 0xe8, 0xa1, 0x56, 0x35, 0x03, 0x1e, 0xe4, 0x18, 0x2b, 0x2c, 0x61, 0xbb, 0xdf,
+	0x85, 0x01, 0x67, 0x7e, 0x48, 0x3e, 0xa3, 0x86, 0xbc, 0xce, 0x6f, 0x40, 0x8f, 0xe8, 0x8f, 0x90,
+	0xcc, 0x86, 0xe2, 0xf1, 0x5b, 0xbd, 0x51, 0x10, 0x62, 0x32, 0x8f, 0x4e, 0xb5, 0x77, 0x73, 0xff,
+	0x98, 0x95, 0x04, 0xae, 0x59, 0xae, 0xc0, 0xdb, 0xd0, 0xf7, 0x32, 0xdd, 0xde, 0x25, 0xab, 0xc4,
+	0x31, 0x76, 0xad, 0xbd, 0xfe, 0x68, 0x47, 0x3e, 0x48, 0x35, 0x2e, 0x16, 0x49, 0x34, 0x55, 0xd0,
+	0x3c, 0x77, 0x15, 0xb4, 0xce, 0xa8, 0x82, 0xdf, 0x86, 0x0b, 0x65, 0x9d, 0x9b, 0x29, 0x45, 0x5e,
+	0xb7, 0x85, 0xbe, 0x9f, 0x17, 0x22, 0x26, 0x56, 0xeb, 0xa5, 0xc4, 0x12, 0x28, 0xdc, 0xa5, 0x58,
+	0x3e, 0xb9, 0x52, 0x77, 0x00, 0xb8, 0x73, 0x05, 0x24, 0x53, 0xd5, 0x06, 0x16, 0x20, 0x68, 0x0c,
+	0x83, 0x58, 0x54, 0x2e, 0x53, 0x44, 0x7f, 0x74, 0x4d, 0x31, 0x9b, 0x88, 0x82, 0x65, 0x0a, 0xf7,
+	0x15, 0x4d, 0xde, 0xca, 0x4e, 0x77, 0x3f, 0x65, 0x29, 0x9f, 0x55, 0xf9, 0xe4, 0x49, 0xb0, 0x78,
+	0x18, 0x7b, 0x61, 0xf2, 0x19, 0x89, 0x6b, 0x43, 0xe0, 0x4d, 0x18, 0x3c, 0x25, 0x64, 0x51, 0x10,
+	0xb1, 0x1b, 0x75, 0xb1, 0x0c, 0x74, 0x6f, 0x03, 0x12, 0x39, 0xf3, 0x3c, 0xb9, 0x0f, 0x76, 0xca,
+	0xcf, 0x50, 0x52, 0x40, 0x05, 0xee, 0x62, 0x31, 0x19, 0xe5, 0xc6, 0x7b, 0xb4, 0x98, 0x7a, 0x69,
+	0x7d, 0x88, 0x8a, 0x05, 0xc3, 0x54, 0x6a, 0xdc, 0x3f, 0xba, 0xb0, 0x49, 0x83, 0x25, 0x0a, 0x53,
+	0x12, 0xa6, 0x3f, 0xf2, 0x66, 0x4b, 0x82, 0xde, 0x81, 0x76, 0x66, 0x23, 0xc7, 0xd0, 0xa9, 0x56,
+	0xca, 0x63, 0x47, 0x0d, 0xcc, 0x91, 0xd1, 0xc7, 0xb0, 0x11, 0x08, 0xb9, 0x8d, 0xdb, 0xe5, 0xf5,
+	0x1a, 0xe2, 0x0c, 0xf1, 0xa8, 0x81, 0x25, 0x42, 0x74, 0x08, 0xfd, 0xb8, 0x6c, 0x16, 0x98, 0xdb,
+	0xf6, 0x47, 0xbb, 0x5a, 0x3e, 0x42, 0x53, 0x71, 0xd4, 0xc0, 0x22, 0x19, 0xba, 0x03, 0x03, 0xbe,
+	0xcc, 0x54, 0xcd, 0xfc, 0xb8, 0x3f, 0x72, 0xeb, 0xf8, 0x64, 0x98, 0x47, 0x0d, 0x2c, 0x93, 0xa2,
+	0x07, 0x60, 0x2f, 0x94, 0xa4, 0xc8, 0xca, 0x44, 0x7f, 0xf4, 0x96, 0x96, 0x9d, 0x9a, 0x41, 0x8f,
+	0x1a, 0xb8, 0xc2, 0x00, 0x1d, 0xc0, 0xc0, 0x13, 0x3d, 0xdf, 0x69, 0xd7, 0x68, 0x3b, 0x43, 0xa1,
+	0x37, 0x93, 0x68, 0x28, 0x13, 0x39, 0x1a, 0x3a, 0x67, 0x46, 0x43, 0x26, 0x9e, 0x00, 0x40, 0xf7,
+	0x60, 0x18, 0x4b, 0xb5, 0x8d, 0x35, 0xcc, 0xfd, 0xd1, 0x1b, 0x75, 0xba, 0xe2, 0xa8, 0x47, 0x0d,
+	0xac, 0x10, 0xa3, 0x4f, 0xe1, 0xa2, 0xa7, 0x89, 0x2d, 0xa7, 0x77, 0xb6, 0x01, 0x0a, 0x31, 0xb5,
+	0x1c, 0xd0, 0x38, 0xcf, 0x37, 0xcc, 0x31, 0x80, 0xf1, 0x7b, 0xad, 0xc6, 0xc1, 0xb8, 0x5f, 0x08,
+	0x44, 0xe8, 0x16, 0x40, 0x5c, 0xa4, 0x7e, 0xa7, 0xcf, 0x58, 0x38, 0xd5, 0x94, 0x9f, 0xed, 0x53,
+	0xda, 0x12, 0x3b, 0xa7, 0xe5, 0xe2, 0x6c, 0xac, 0xa3, 0x2d, 0x84, 0x10, 0xb0, 0xd1, 0x7d, 0xd8,
+	0x8a, 0xd4, 0xac, 0xe2, 0x0c, 0x74, 0x12, 0x54, 0x92, 0xcf, 0x51, 0x03, 0x57, 0x69, 0xd1, 0x04,
+	0x36, 0x23, 0x39, 0x99, 0x38, 0x43, 0xc6, 0xee, 0xd5, 0x35, 0xec, 0x0a, 0xe7, 0x56, 0xe9, 0xa8,
+	0xfd, 0xe7, 0x52, 0x3a, 0x71, 0x36, 0x6b, 0xec, 0x2f, 0x67, 0x1e, 0x6a, 0x7f, 0x99, 0xf8, 0xc3,
+	0x0e, 0xb4, 0x4e, 0x69, 0x22, 0x71, 0x3f, 0x62, 0x65, 0xfc, 0x90, 0x36, 0xa6, 0xb7, 0x00, 0xbc,
+	0x22, 0xcd, 0xf0, 0x02, 0x78, 0x55, 0x29, 0xd8, 0x42, 0x0e, 0xc2, 0x02, 0xb6, 0x7b, 0x8f, 0x35,
+	0x3f, 0xd8, 0x7b, 0xf6, 0x20, 0xf5, 0x52, 0xf2, 0x20, 0xf4, 0x16, 0xc9, 0x93, 0x28, 0xa5, 0xf3,
+	0xd1, 0xc2, 0x5b, 0xcd, 0x22, 0x6f, 0xca, 0x33, 0x5e, 0xbe, 0xa4, 0x13, 0x58, 0x52, 0x74, 0xd3,
+	0x7c, 0xc4, 0x2d, 0x00, 0xee, 0x6f, 0xb2, 0xb6, 0x55, 0x62, 0x76, 0xcc, 0x29, 0x2f, 0x42, 0xcb,
+	0xf3, 0x67, 0x45, 0x0e, 0xce, 0x16, 0x34, 0x81, 0xc6, 0x72, 0xa7, 0x5a, 0xac, 0xa5, 0xc4, 0x6b,
+	0x55, 0x67, 0x81, 0xa2, 0x6a, 0xb2, 0xe3, 0x78, 0x2d, 0x55, 0xa0, 0xee, 0x2f, 0x9b, 0xac, 0x0e,
+	0xca, 0xe2, 0x89, 0x87, 0x1a, 0xca, 0xa1, 0xd7, 0x61, 0x28, 0x45, 0x70, 0xd6, 0x02, 0xf5, 0xb0,
+	0x02, 0x45, 0x37, 0xa0, 0xf9, 0x94, 0x56, 0x66, 0x4b, 0xa7, 0xef, 0xfc, 0x24, 0x5a, 0x89, 0x31,
+	0xc3, 0x43, 0xdf, 0x83, 0x2e, 0x8f, 0x3b, 0xda, 0x40, 0x5b, 0x55, 0x67, 0xca, 0x69, 0xf2, 0xa8,
+	0x2d, 0xd0, 0xd1, 0x04, 0x86, 0x09, 0xbd, 0x7f, 0x32, 0xe6, 0x7d, 0x2f, 0x6f, 0x07, 0x5e, 0xd7,
+	0x33, 0xc8, 0x70, 0x98, 0xc4, 0x58, 0x21, 0x44, 0xef, 0x42, 0x27, 0x0b, 0x58, 0x3a, 0x94, 0x50,
+	0x1e, 0xdb, 0x7a, 0x1e, 0xbc, 0x88, 0xe4, 0xc8, 0xf4, 0xf6, 0x3c, 0x13, 0x25, 0x4e, 0xa7, 0xee,
+	0xf6, 0x79, 0x52, 0x29, 0xd0, 0xd1, 0x1e, 0xb4, 0x68, 0xac, 0x26, 0x4e, 0x77, 0xd7, 0x5a, 0xd3,
+	0x03, 0x66, 0x08, 0xe8, 0x13, 0x40, 0x95, 0x58, 0x4c, 0x9c, 0x1e, 0x23, 0xfb, 0x96, 0xfe, 0xb8,
+	0x4a, 0x40, 0x63, 0x0d, 0x0b, 0xf7, 0xf7, 0x06, 0x6c, 0x0a, 0x44, 0xac, 0xeb, 0xab, 0xf3, 0x01,
+	0x07, 0x3a, 0xb1, 0xd4, 0xd6, 0xe7, 0x4b, 0x3a, 0x98, 0xcc, 0xb5, 0xad, 0x9f, 0x0a, 0xd6, 0x74,
+	0x95, 0x4d, 0x5d, 0x57, 0xe9, 0xfe, 0xdb, 0x00, 0x24, 0xdc, 0x8d, 0x5b, 0xff, 0x7f, 0x3e, 0x17,
+	0x20, 0x57, 0x69, 0xfc, 0xe9, 0x6d, 0x9b, 0x72, 0x7b, 0x2f, 0x8c, 0x65, 0xc5, 0x23, 0x4e, 0x36,
+	0x01, 0xab, 0x60, 0xb4, 0x0b, 0xfd, 0xa7, 0x64, 0x55, 0xb4, 0x5d, 0x6d, 0x76, 0x90, 0x08, 0x72,
+	0xbf, 0x30, 0xe0, 0xb2, 0x20, 0xb7, 0xe0, 0xb4, 0xb5, 0xa6, 0x11, 0xc3, 0xc8, 0x3c, 0x5f, 0x18,
+	0xbd, 0x0d, 0x5b, 0x31, 0x2b, 0x18, 0xd3, 0x49, 0xd9, 0x04, 0x5b, 0xac, 0x09, 0xae, 0x6e, 0xb8,
+	0x7f, 0x35, 0x61, 0xab, 0x12, 0x10, 0xb5, 0x57, 0x93, 0x1e, 0xa7, 0x4c, 0xf5, 0x71, 0x4a, 0x7e,
+	0x58, 0xb2, 0xbe, 0xfe, 0xc3, 0xd2, 0xf9, 0xa7, 0x6f, 0xcd, 0xbb, 0x58, 0xeb, 0xcc, 0x77, 0xb1,
+	0xb6, 0xfc, 0x2e, 0xb6, 0x0d, 0xbd, 0x65, 0x42, 0xa6, 0x07, 0x54, 0x95, 0xac, 0x11, 0x1a, 0xe0,
+	0x12, 0xa0, 0x1d, 0x88, 0xbb, 0x6b, 0x06, 0xe2, 0x7f, 0xca, 0x0e, 0xcf, 0x13, 0x46, 0xad, 0x66,
+	0xc5, 0x60, 0x30, 0x6b, 0x3a, 0x70, 0x4b, 0x79, 0xb2, 0x41, 0xd0, 0x4c, 0xf3, 0x67, 0xbc, 0x16,
+	0x66, 0xdf, 0xac, 0xb4, 0x91, 0x70, 0x1a, 0x84, 0x8f, 0x99, 0x12, 0xba, 0x38, 0x5f, 0x6a, 0x1e,
+	0x0c, 0xda, 0xda, 0xe7, 0xa5, 0xeb, 0x30, 0xf4, 0x67, 0x51, 0x42, 0xa6, 0x39, 0x84, 0xe9, 0xa3,
+	0x87, 0x15, 0xa8, 0xfb, 0x27, 0x03, 0xb6, 0xeb, 0x52, 0x55, 0xad, 0xc8, 0x6f, 0xc2, 0x80, 0x25,
+	0xb2, 0x89, 0x2c, 0xb7, 0x0c, 0xa4, 0x7a, 0x0f, 0xc9, 0xb3, 0xfb, 0x12, 0x22, 0x7f, 0xc4, 0x51,
+	0xe1, 0xd5, 0x51, 0xaa, 0xa9, 0x1b, 0xa5, 0xee, 0x30, 0xaf, 0x3f, 0xce, 0x54, 0x92, 0x89, 0x92,
+	0xa0, 0x77, 0x68, 0x3e, 0x64, 0x9f, 0xbc, 0xbd, 0x50, 0x7a, 0x60, 0x09, 0x1d, 0xe7, 0xb8, 0xee,
+	0xdf, 0xb2, 0x79, 0x5d, 0xda, 0xad, 0xce, 0x0e, 0x86, 0xae, 0x75, 0x2d, 0x68, 0x6a, 0x67, 0x87,
+	0x6a, 0x73, 0x6d, 0x7e, 0x93, 0xe6, 0x5a, 0x37, 0x8a, 0x58, 0xdf, 0x74, 0x14, 0xb9, 0xa3, 0x8e,
+	0x22, 0xcd, 0x1a, 0x79, 0xeb, 0x27, 0x92, 0xb2, 0xfb, 0xfb, 0x5d, 0xd6, 0x67, 0xe9, 0xb4, 0xf4,
+	0xf2, 0x6f, 0x67, 0xff, 0x9d, 0xf7, 0xa5, 0x5f, 0xc0, 0x95, 0x35, 0xf2, 0x9c, 0xf9, 0xfc, 0xa0,
+	0x29, 0xae, 0xa6, 0xbe, 0xb8, 0x0a, 0x05, 0xda, 0x92, 0x0a, 0xf4, 0xfe, 0xc7, 0x30, 0x90, 0x32,
+	0x29, 0xda, 0x82, 0x01, 0xd7, 0xcf, 0xc3, 0x88, 0x4e, 0x29, 0x76, 0x83, 0x82, 0xc6, 0xe1, 0x2a,
+	0x0a, 0xc9, 0x81, 0x17, 0x32, 0x90, 0x81, 0x6c, 0xd8, 0x38, 0x5e, 0x9e, 0xcc, 0x02, 0x9f, 0x66,
+	0x29, 0x12, 0xdb, 0xe6, 0xfe, 0x0f, 0x00, 0x55, 0xc5, 0x47, 0x5d, 0x68, 0xfe, 0x30, 0x0a, 0x89,
+	0xdd, 0x40, 0x3d, 0x68, 0xb1, 0xa8, 0xb1, 0x0d, 0xfa, 0x39, 0x9e, 0xce, 0x83, 0xd0, 0x36, 0x11,
+	0x40, 0xfb, 0x93, 0x38, 0x48, 0x49, 0x6c, 0x5b, 0xf4, 0x9b, 0x73, 0x6b, 0xee, 0xff, 0xda, 0x84,
+	0x81, 0xf4, 0x70, 0x86, 0x10, 0x0c, 0xcb, 0x15, 0xe7, 0x29, 0xc1, 0x28, 0xad, 0x6d, 0xa0, 0x0b,
+	0xb0, 0x59, 0xc2, 0x18, 0x6f, 0xdb, 0x44, 0x97, 0x60, 0xab, 0x04, 0x1e, 0x44, 0xf3, 0x39, 0x09,
+	0x53, 0xdb, 0x42, 0x17, 0xc1, 0x2e, 0xc1, 0x99, 0x0e, 0xec, 0x26, 0xda, 0x06, 0xa7, 0x84, 0x66,
+	0x4e, 0xc2, 0x35, 0x92, 0xd8, 0x2d, 0x79, 0x37, 0x33, 0x14, 0xb7, 0x5a, 0x62, 0xb7, 0xd1, 0x6b,
+	0x70, 0x4d, 0x38, 0x88, 0x79, 0xb4, 0xa0, 0x0e, 0xbb, 0x83, 0xae, 0xc1, 0x15, 0x15, 0x81, 0x27,
+	0x7a, 0xbb, 0x8b, 0x5e, 0x81, 0x4b, 0xe5, 0xe6, 0x3d, 0x2f, 0xf4, 0x1e, 0x13, 0xda, 0xef, 0x25,
+	0x76, 0xef, 0xc3, 0x0f, 0xfe, 0xf2, 0x7c, 0xc7, 0xf8, 0xf2, 0xf9, 0x8e, 0xf1, 0xd5, 0xf3, 0x1d,
+	0xe3, 0xb7, 0x2f, 0x76, 0x1a, 0x5f, 0xbe, 0xd8, 0x69, 0xfc, 0xfd, 0xc5, 0x4e, 0xe3, 0xc7, 0x6f,
+	0xbd, 0xd4, 0x7f, 0xed, 0x4e, 0xda, 0xec, 0xcf, 0x77, 0xfe, 0x33, 0x00, 0x7d, 0xa2, 0xc0, 0x6f,
+	0xe5, 0x1b, 0x00, 0x00,
+}
+
+func (m *AclRoot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AclRoot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclRoot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.InviteRevoke != nil {
-		l = m.InviteRevoke.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.EncryptedOwnerMetadata) > 0 {
+		i -= len(m.EncryptedOwnerMetadata)
+		copy(dAtA[i:], m.EncryptedOwnerMetadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedOwnerMetadata)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	return n
-}
-func (m *AclContentValue_RequestJoin) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.EncryptedMetadataPrivKey) > 0 {
+		i -= len(m.EncryptedMetadataPrivKey)
+		copy(dAtA[i:], m.EncryptedMetadataPrivKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedMetadataPrivKey)))
+		i--
+		dAtA[i] = 0x42
 	}
-	var l int
-	_ = l
-	if m.RequestJoin != nil {
-		l = m.RequestJoin.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.MetadataPubKey) > 0 {
+		i -= len(m.MetadataPubKey)
+		copy(dAtA[i:], m.MetadataPubKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPubKey)))
+		i--
+		dAtA[i] = 0x3a
 	}
-	return n
-}
-func (m *AclContentValue_RequestAccept) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.IdentitySignature) > 0 {
+		i -= len(m.IdentitySignature)
+		copy(dAtA[i:], m.IdentitySignature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.IdentitySignature)))
+		i--
+		dAtA[i] = 0x32
 	}
-	var l int
-	_ = l
-	if m.RequestAccept != nil {
-		l = m.RequestAccept.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if m.Timestamp != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
-}
-func (m *AclContentValue_PermissionChange) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x22
 	}
-	var l int
-	_ = l
-	if m.PermissionChange != nil {
-		l = m.PermissionChange.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.SpaceId) > 0 {
+		i -= len(m.SpaceId)
+		copy(dAtA[i:], m.SpaceId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.SpaceId)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
-}
-func (m *AclContentValue_AccountRemove) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.MasterKey) > 0 {
+		i -= len(m.MasterKey)
+		copy(dAtA[i:], m.MasterKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MasterKey)))
+		i--
+		dAtA[i] = 0x12
 	}
-	var l int
-	_ = l
-	if m.AccountRemove != nil {
-		l = m.AccountRemove.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *AclContentValue_ReadKeyChange) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *AclAccountInvite) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountInvite) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountInvite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ReadKeyChange != nil {
-		l = m.ReadKeyChange.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x32
 	}
-	return n
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.InviteType != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.InviteType))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxUses != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.MaxUses))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ExpireTimestamp != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.ExpireTimestamp))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.InviteKey) > 0 {
+		i -= len(m.InviteKey)
+		copy(dAtA[i:], m.InviteKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteKey)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (m *AclContentValue_RequestDecline) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *AclAccountRequestJoin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountRequestJoin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountRequestJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.RequestDecline != nil {
-		l = m.RequestDecline.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.Metadata) > 0 {
+		i -= len(m.Metadata)
+		copy(dAtA[i:], m.Metadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Metadata)))
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
+	if len(m.InviteIdentitySignature) > 0 {
+		i -= len(m.InviteIdentitySignature)
+		copy(dAtA[i:], m.InviteIdentitySignature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteIdentitySignature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.InviteRecordId) > 0 {
+		i -= len(m.InviteRecordId)
+		copy(dAtA[i:], m.InviteRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.InviteIdentity) > 0 {
+		i -= len(m.InviteIdentity)
+		copy(dAtA[i:], m.InviteIdentity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteIdentity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (m *AclContentValue_AccountRequestRemove) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *AclAccountInviteJoin) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountInviteJoin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountInviteJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.AccountRequestRemove != nil {
-		l = m.AccountRequestRemove.Size()
-		n += 1 + l + sovAclrecord(uint64(l))
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x30
 	}
-	return n
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Metadata) > 0 {
+		i -= len(m.Metadata)
+		copy(dAtA[i:], m.Metadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Metadata)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.InviteIdentitySignature) > 0 {
+		i -= len(m.InviteIdentitySignature)
+		copy(dAtA[i:], m.InviteIdentitySignature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteIdentitySignature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.InviteRecordId) > 0 {
+		i -= len(m.InviteRecordId)
+		copy(dAtA[i:], m.InviteRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
-func (m *AclData) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *AclAccountRequestAccept) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountRequestAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountRequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.AclContent) > 0 {
-		for _, e := range m.AclContent {
-			l = e.Size()
-			n += 1 + l + sovAclrecord(uint64(l))
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RequestRecordId) > 0 {
+		i -= len(m.RequestRecordId)
+		copy(dAtA[i:], m.RequestRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestRecordId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountRequestDecline) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountRequestDecline) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountRequestDecline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RequestRecordId) > 0 {
+		i -= len(m.RequestRecordId)
+		copy(dAtA[i:], m.RequestRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestRecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountInviteRevoke) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountInviteRevoke) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountInviteRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.InviteRecordId) > 0 {
+		i -= len(m.InviteRecordId)
+		copy(dAtA[i:], m.InviteRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclEncryptedReadKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclEncryptedReadKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclEncryptedReadKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountPermissionChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountPermissionChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountPermissionChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclRole) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclRole) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclRole) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Capabilities) > 0 {
+		dAtA2 := make([]byte, len(m.Capabilities)*10)
+		var j1 int
+		for _, num := range m.Capabilities {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintAclrecord(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclRoleDefine) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclRoleDefine) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclRoleDefine) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Role != nil {
+		{
+			size, err := m.Role.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclRoleRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclRoleRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclRoleRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclReadKeyChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclReadKeyChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclReadKeyChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.InviteKeys) > 0 {
+		for iNdEx := len(m.InviteKeys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InviteKeys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.EncryptedOldReadKey) > 0 {
+		i -= len(m.EncryptedOldReadKey)
+		copy(dAtA[i:], m.EncryptedOldReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedOldReadKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.EncryptedMetadataPrivKey) > 0 {
+		i -= len(m.EncryptedMetadataPrivKey)
+		copy(dAtA[i:], m.EncryptedMetadataPrivKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedMetadataPrivKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.MetadataPubKey) > 0 {
+		i -= len(m.MetadataPubKey)
+		copy(dAtA[i:], m.MetadataPubKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPubKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AccountKeys) > 0 {
+		for iNdEx := len(m.AccountKeys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccountKeys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReadKeyChange != nil {
+		{
+			size, err := m.ReadKeyChange.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identities) > 0 {
+		for iNdEx := len(m.Identities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Identities[iNdEx])
+			copy(dAtA[i:], m.Identities[iNdEx])
+			i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identities[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountRequestRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountRequestRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountRequestRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AclOwnershipTransfer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclOwnershipTransfer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclOwnershipTransfer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.KeepOwnership {
+		i--
+		if m.KeepOwnership {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclOwnershipAccept) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclOwnershipAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclOwnershipAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TransferRecordId) > 0 {
+		i -= len(m.TransferRecordId)
+		copy(dAtA[i:], m.TransferRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.TransferRecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclAccountMetadataUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclAccountMetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclAccountMetadataUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		i -= len(m.Metadata)
+		copy(dAtA[i:], m.Metadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Metadata)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclContentValue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclContentValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclContentValue_Invite) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_Invite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Invite != nil {
+		{
+			size, err := m.Invite.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_InviteRevoke) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_InviteRevoke) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.InviteRevoke != nil {
+		{
+			size, err := m.InviteRevoke.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_RequestJoin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RequestJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestJoin != nil {
+		{
+			size, err := m.RequestJoin.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_RequestAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestAccept != nil {
+		{
+			size, err := m.RequestAccept.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_PermissionChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_PermissionChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PermissionChange != nil {
+		{
+			size, err := m.PermissionChange.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_AccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_AccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AccountRemove != nil {
+		{
+			size, err := m.AccountRemove.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_ReadKeyChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_ReadKeyChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ReadKeyChange != nil {
+		{
+			size, err := m.ReadKeyChange.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_RequestDecline) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RequestDecline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestDecline != nil {
+		{
+			size, err := m.RequestDecline.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_AccountRequestRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_AccountRequestRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AccountRequestRemove != nil {
+		{
+			size, err := m.AccountRequestRemove.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_InviteJoin) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_InviteJoin) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.InviteJoin != nil {
+		{
+			size, err := m.InviteJoin.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_RoleDefine) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RoleDefine) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RoleDefine != nil {
+		{
+			size, err := m.RoleDefine.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_RoleRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_RoleRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RoleRemove != nil {
+		{
+			size, err := m.RoleRemove.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_OwnershipTransfer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_OwnershipTransfer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.OwnershipTransfer != nil {
+		{
+			size, err := m.OwnershipTransfer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_OwnershipAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_OwnershipAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.OwnershipAccept != nil {
+		{
+			size, err := m.OwnershipAccept.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclContentValue_MetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclContentValue_MetadataUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MetadataUpdate != nil {
+		{
+			size, err := m.MetadataUpdate.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AclContent) > 0 {
+		for iNdEx := len(m.AclContent) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AclContent[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclRawStateSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclRawStateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclRawStateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclStateSnapshotPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclStateSnapshotPayload) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclStateSnapshotPayload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EncryptedState) > 0 {
+		i -= len(m.EncryptedState)
+		copy(dAtA[i:], m.EncryptedState)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedState)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AclId) > 0 {
+		i -= len(m.AclId)
+		copy(dAtA[i:], m.AclId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.AclId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclStateSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclStateSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclStateSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.OwnershipTransfers) > 0 {
+		for iNdEx := len(m.OwnershipTransfers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.OwnershipTransfers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Roles) > 0 {
+		for iNdEx := len(m.Roles) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Roles[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Invites) > 0 {
+		for iNdEx := len(m.Invites) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Invites[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.StatesAtRecord) > 0 {
+		for iNdEx := len(m.StatesAtRecord) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StatesAtRecord[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Accounts) > 0 {
+		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Keys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ReadKeyChanges) > 0 {
+		for iNdEx := len(m.ReadKeyChanges) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ReadKeyChanges[iNdEx])
+			copy(dAtA[i:], m.ReadKeyChanges[iNdEx])
+			i = encodeVarintAclrecord(dAtA, i, uint64(len(m.ReadKeyChanges[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotKeys) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotKeys) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotKeys) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MetadataPubKey) > 0 {
+		i -= len(m.MetadataPubKey)
+		copy(dAtA[i:], m.MetadataPubKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPubKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.MetadataPrivKey) > 0 {
+		i -= len(m.MetadataPrivKey)
+		copy(dAtA[i:], m.MetadataPrivKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPrivKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ReadKey) > 0 {
+		i -= len(m.ReadKey)
+		copy(dAtA[i:], m.ReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.ReadKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotAccount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotAccount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotAccount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyRecordId) > 0 {
+		i -= len(m.KeyRecordId)
+		copy(dAtA[i:], m.KeyRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.KeyRecordId)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.RequestMetadata) > 0 {
+		i -= len(m.RequestMetadata)
+		copy(dAtA[i:], m.RequestMetadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestMetadata)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Capabilities != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Capabilities))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotRecordState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotRecordState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotRecordState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RemovedIdentities) > 0 {
+		for iNdEx := len(m.RemovedIdentities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RemovedIdentities[iNdEx])
+			copy(dAtA[i:], m.RemovedIdentities[iNdEx])
+			i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RemovedIdentities[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Accounts) > 0 {
+		for iNdEx := len(m.Accounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Accounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotInvite) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotInvite) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotInvite) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EncryptedReadKey) > 0 {
+		i -= len(m.EncryptedReadKey)
+		copy(dAtA[i:], m.EncryptedReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.EncryptedReadKey)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.UsedCount != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.UsedCount))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MaxUses != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.MaxUses))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ExpireTimestamp != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.ExpireTimestamp))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.InviteType != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.InviteType))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.InviteKey) > 0 {
+		i -= len(m.InviteKey)
+		copy(dAtA[i:], m.InviteKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ClosedRecordId) > 0 {
+		i -= len(m.ClosedRecordId)
+		copy(dAtA[i:], m.ClosedRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.ClosedRecordId)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.InviteRecordId) > 0 {
+		i -= len(m.InviteRecordId)
+		copy(dAtA[i:], m.InviteRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.InviteRecordId)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Pending {
+		i--
+		if m.Pending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Type != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Metadata) > 0 {
+		i -= len(m.Metadata)
+		copy(dAtA[i:], m.Metadata)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Metadata)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclSnapshotOwnershipTransfer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclSnapshotOwnershipTransfer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclSnapshotOwnershipTransfer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.KeepOwnership {
+		i--
+		if m.KeepOwnership {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.NewOwnerIdentity) > 0 {
+		i -= len(m.NewOwnerIdentity)
+		copy(dAtA[i:], m.NewOwnerIdentity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.NewOwnerIdentity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.OwnerIdentity) > 0 {
+		i -= len(m.OwnerIdentity)
+		copy(dAtA[i:], m.OwnerIdentity)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.OwnerIdentity)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RecordId) > 0 {
+		i -= len(m.RecordId)
+		copy(dAtA[i:], m.RecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclPendingRecords) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclPendingRecords) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecords) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAclrecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclPendingRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclPendingRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclPendingRecord_RequestAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecord_RequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestAccept != nil {
+		{
+			size, err := m.RequestAccept.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclPendingRecord_RequestDecline) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecord_RequestDecline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestDecline != nil {
+		{
+			size, err := m.RequestDecline.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclPendingRecord_PermissionChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecord_PermissionChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PermissionChange != nil {
+		{
+			size, err := m.PermissionChange.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclPendingRecord_AccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRecord_AccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AccountRemove != nil {
+		{
+			size, err := m.AccountRemove.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAclrecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *AclPendingRequestAccept) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclPendingRequestAccept) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingRequestAccept) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Role) > 0 {
+		i -= len(m.Role)
+		copy(dAtA[i:], m.Role)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Role)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Permissions != 0 {
+		i = encodeVarintAclrecord(dAtA, i, uint64(m.Permissions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.RequestRecordId) > 0 {
+		i -= len(m.RequestRecordId)
+		copy(dAtA[i:], m.RequestRecordId)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.RequestRecordId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AclPendingAccountRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AclPendingAccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AclPendingAccountRemove) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ReadKey) > 0 {
+		i -= len(m.ReadKey)
+		copy(dAtA[i:], m.ReadKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.ReadKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.MetadataPrivKey) > 0 {
+		i -= len(m.MetadataPrivKey)
+		copy(dAtA[i:], m.MetadataPrivKey)
+		i = encodeVarintAclrecord(dAtA, i, uint64(len(m.MetadataPrivKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Identities) > 0 {
+		for iNdEx := len(m.Identities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Identities[iNdEx])
+			copy(dAtA[i:], m.Identities[iNdEx])
+			i = encodeVarintAclrecord(dAtA, i, uint64(len(m.Identities[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAclrecord(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAclrecord(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AclRoot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.MasterKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.SpaceId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovAclrecord(uint64(m.Timestamp))
+	}
+	l = len(m.IdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.MetadataPubKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedMetadataPrivKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedOwnerMetadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountInvite) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InviteKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.ExpireTimestamp != 0 {
+		n += 1 + sovAclrecord(uint64(m.ExpireTimestamp))
+	}
+	if m.MaxUses != 0 {
+		n += 1 + sovAclrecord(uint64(m.MaxUses))
+	}
+	if m.InviteType != 0 {
+		n += 1 + sovAclrecord(uint64(m.InviteType))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountRequestJoin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InviteIdentity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.InviteRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.InviteIdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountInviteJoin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.InviteRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.InviteIdentitySignature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	return n
+}
+
+func (m *AclAccountRequestAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.RequestRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountRequestDecline) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RequestRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountInviteRevoke) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.InviteRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclEncryptedReadKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountPermissionChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclRole) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		l = 0
+		for _, e := range m.Capabilities {
+			l += sovAclrecord(uint64(e))
+		}
+		n += 1 + sovAclrecord(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *AclRoleDefine) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Role != nil {
+		l = m.Role.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclRoleRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclReadKeyChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AccountKeys) > 0 {
+		for _, e := range m.AccountKeys {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	l = len(m.MetadataPubKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedMetadataPrivKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedOldReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if len(m.InviteKeys) > 0 {
+		for _, e := range m.InviteKeys {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AclAccountRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Identities) > 0 {
+		for _, b := range m.Identities {
+			l = len(b)
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if m.ReadKeyChange != nil {
+		l = m.ReadKeyChange.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountRequestRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AclOwnershipTransfer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.KeepOwnership {
+		n += 2
+	}
+	return n
+}
+
+func (m *AclOwnershipAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TransferRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclAccountMetadataUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclContentValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *AclContentValue_Invite) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Invite != nil {
+		l = m.Invite.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_InviteRevoke) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InviteRevoke != nil {
+		l = m.InviteRevoke.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_RequestJoin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestJoin != nil {
+		l = m.RequestJoin.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_RequestAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestAccept != nil {
+		l = m.RequestAccept.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_PermissionChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PermissionChange != nil {
+		l = m.PermissionChange.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_AccountRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccountRemove != nil {
+		l = m.AccountRemove.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_ReadKeyChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadKeyChange != nil {
+		l = m.ReadKeyChange.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_RequestDecline) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestDecline != nil {
+		l = m.RequestDecline.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_AccountRequestRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccountRequestRemove != nil {
+		l = m.AccountRequestRemove.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_InviteJoin) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InviteJoin != nil {
+		l = m.InviteJoin.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_RoleDefine) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RoleDefine != nil {
+		l = m.RoleDefine.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_RoleRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RoleRemove != nil {
+		l = m.RoleRemove.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_OwnershipTransfer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OwnershipTransfer != nil {
+		l = m.OwnershipTransfer.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_OwnershipAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OwnershipAccept != nil {
+		l = m.OwnershipAccept.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclContentValue_MetadataUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MetadataUpdate != nil {
+		l = m.MetadataUpdate.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AclContent) > 0 {
+		for _, e := range m.AclContent {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AclRawStateSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclStateSnapshotPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AclId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.EncryptedState)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclStateSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if len(m.ReadKeyChanges) > 0 {
+		for _, s := range m.ReadKeyChanges {
+			l = len(s)
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.StatesAtRecord) > 0 {
+		for _, e := range m.StatesAtRecord {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.Invites) > 0 {
+		for _, e := range m.Invites {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.Roles) > 0 {
+		for _, e := range m.Roles {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.OwnershipTransfers) > 0 {
+		for _, e := range m.OwnershipTransfers {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AclSnapshotKeys) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.ReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.MetadataPrivKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.MetadataPubKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclSnapshotAccount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Capabilities != 0 {
+		n += 1 + sovAclrecord(uint64(m.Capabilities))
+	}
+	l = len(m.RequestMetadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.KeyRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclSnapshotRecordState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if len(m.Accounts) > 0 {
+		for _, e := range m.Accounts {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	if len(m.RemovedIdentities) > 0 {
+		for _, b := range m.RemovedIdentities {
+			l = len(b)
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AclSnapshotInvite) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.InviteKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.InviteType != 0 {
+		n += 1 + sovAclrecord(uint64(m.InviteType))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	if m.ExpireTimestamp != 0 {
+		n += 1 + sovAclrecord(uint64(m.ExpireTimestamp))
+	}
+	if m.MaxUses != 0 {
+		n += 1 + sovAclrecord(uint64(m.MaxUses))
+	}
+	if m.UsedCount != 0 {
+		n += 1 + sovAclrecord(uint64(m.UsedCount))
+	}
+	l = len(m.EncryptedReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclSnapshotRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovAclrecord(uint64(m.Type))
+	}
+	if m.Pending {
+		n += 2
+	}
+	l = len(m.InviteRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.ClosedRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclSnapshotOwnershipTransfer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.OwnerIdentity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.NewOwnerIdentity)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.KeepOwnership {
+		n += 2
+	}
+	return n
+}
+
+func (m *AclPendingRecords) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AclPendingRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *AclPendingRecord_RequestAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestAccept != nil {
+		l = m.RequestAccept.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclPendingRecord_RequestDecline) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestDecline != nil {
+		l = m.RequestDecline.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclPendingRecord_PermissionChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PermissionChange != nil {
+		l = m.PermissionChange.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclPendingRecord_AccountRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AccountRemove != nil {
+		l = m.AccountRemove.Size()
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+func (m *AclPendingRequestAccept) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RequestRecordId)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	if m.Permissions != 0 {
+		n += 1 + sovAclrecord(uint64(m.Permissions))
+	}
+	l = len(m.Role)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func (m *AclPendingAccountRemove) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Identities) > 0 {
+		for _, b := range m.Identities {
+			l = len(b)
+			n += 1 + l + sovAclrecord(uint64(l))
+		}
+	}
+	l = len(m.MetadataPrivKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	l = len(m.ReadKey)
+	if l > 0 {
+		n += 1 + l + sovAclrecord(uint64(l))
+	}
+	return n
+}
+
+func sovAclrecord(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAclrecord(x uint64) (n int) {
+	return sovAclrecord(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AclRoot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRoot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRoot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MasterKey = append(m.MasterKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MasterKey == nil {
+				m.MasterKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpaceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpaceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdentitySignature = append(m.IdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.IdentitySignature == nil {
+				m.IdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetadataPubKey = append(m.MetadataPubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MetadataPubKey == nil {
+				m.MetadataPubKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedMetadataPrivKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedMetadataPrivKey = append(m.EncryptedMetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedMetadataPrivKey == nil {
+				m.EncryptedMetadataPrivKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedOwnerMetadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedOwnerMetadata = append(m.EncryptedOwnerMetadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedOwnerMetadata == nil {
+				m.EncryptedOwnerMetadata = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountInvite) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountInvite: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountInvite: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteKey = append(m.InviteKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.InviteKey == nil {
+				m.InviteKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTimestamp", wireType)
+			}
+			m.ExpireTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTimestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUses", wireType)
+			}
+			m.MaxUses = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUses |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteType", wireType)
+			}
+			m.InviteType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InviteType |= AclInviteType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			m.Permissions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountRequestJoin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountRequestJoin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteIdentity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteIdentity = append(m.InviteIdentity[:0], dAtA[iNdEx:postIndex]...)
+			if m.InviteIdentity == nil {
+				m.InviteIdentity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteIdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteIdentitySignature = append(m.InviteIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.InviteIdentitySignature == nil {
+				m.InviteIdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountInviteJoin) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountInviteJoin: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountInviteJoin: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteIdentitySignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteIdentitySignature = append(m.InviteIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.InviteIdentitySignature == nil {
+				m.InviteIdentitySignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			m.Permissions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountRequestAccept: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountRequestAccept: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			m.Permissions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountRequestDecline) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountRequestDecline: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountRequestDecline: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountInviteRevoke) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountInviteRevoke: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountInviteRevoke: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclEncryptedReadKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclEncryptedReadKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountPermissionChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountPermissionChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+			}
+			m.Permissions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Role = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclRole) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRole: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRole: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v AclCapability
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAclrecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= AclCapability(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Capabilities = append(m.Capabilities, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAclrecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAclrecord
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAclrecord
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Capabilities) == 0 {
+					m.Capabilities = make([]AclCapability, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v AclCapability
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAclrecord
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= AclCapability(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Capabilities = append(m.Capabilities, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclRoleDefine) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRoleDefine: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRoleDefine: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Role == nil {
+				m.Role = &AclRole{}
+			}
+			if err := m.Role.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclRoleRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRoleRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRoleRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclReadKeyChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclReadKeyChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccountKeys = append(m.AccountKeys, &AclEncryptedReadKey{})
+			if err := m.AccountKeys[len(m.AccountKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetadataPubKey = append(m.MetadataPubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MetadataPubKey == nil {
+				m.MetadataPubKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedMetadataPrivKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedMetadataPrivKey = append(m.EncryptedMetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedMetadataPrivKey == nil {
+				m.EncryptedMetadataPrivKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedOldReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedOldReadKey = append(m.EncryptedOldReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedOldReadKey == nil {
+				m.EncryptedOldReadKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InviteKeys = append(m.InviteKeys, &AclEncryptedReadKey{})
+			if err := m.InviteKeys[len(m.InviteKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identities", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identities = append(m.Identities, make([]byte, postIndex-iNdEx))
+			copy(m.Identities[len(m.Identities)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyChange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadKeyChange == nil {
+				m.ReadKeyChange = &AclReadKeyChange{}
+			}
+			if err := m.ReadKeyChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountRequestRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountRequestRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountRequestRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclOwnershipTransfer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclOwnershipTransfer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclOwnershipTransfer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepOwnership", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeepOwnership = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclOwnershipAccept) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclOwnershipAccept: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclOwnershipAccept: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransferRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclAccountMetadataUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclAccountMetadataUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclAccountMetadataUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclContentValue) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclContentValue: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclContentValue: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Invite", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountInvite{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_Invite{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteRevoke", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountInviteRevoke{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_InviteRevoke{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestJoin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountRequestJoin{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RequestJoin{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestAccept", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountRequestAccept{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RequestAccept{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PermissionChange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountPermissionChange{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_PermissionChange{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_AccountRemove{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyChange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclReadKeyChange{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_ReadKeyChange{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestDecline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountRequestDecline{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RequestDecline{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountRequestRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountRequestRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_AccountRequestRemove{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteJoin", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountInviteJoin{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_InviteJoin{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoleDefine", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclRoleDefine{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RoleDefine{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoleRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclRoleRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_RoleRemove{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnershipTransfer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclOwnershipTransfer{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_OwnershipTransfer{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnershipAccept", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclOwnershipAccept{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_OwnershipAccept{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &AclAccountMetadataUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &AclContentValue_MetadataUpdate{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AclContent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AclContent = append(m.AclContent, &AclContentValue{})
+			if err := m.AclContent[len(m.AclContent)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclRawStateSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclRawStateSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclRawStateSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func sovAclrecord(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozAclrecord(x uint64) (n int) {
-	return sovAclrecord(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *AclRoot) Unmarshal(dAtA []byte) error {
+func (m *AclStateSnapshotPayload) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2134,13 +9110,77 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclRoot: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclStateSnapshotPayload: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclRoot: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclStateSnapshotPayload: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AclId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AclId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
@@ -2174,9 +9214,9 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				m.Identity = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MasterKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedState", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2203,14 +9243,64 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MasterKey = append(m.MasterKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.MasterKey == nil {
-				m.MasterKey = []byte{}
+			m.EncryptedState = append(m.EncryptedState[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedState == nil {
+				m.EncryptedState = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclStateSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclStateSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclStateSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SpaceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2238,13 +9328,13 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SpaceId = string(dAtA[iNdEx:postIndex])
+			m.RecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyChanges", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2254,31 +9344,29 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedReadKey == nil {
-				m.EncryptedReadKey = []byte{}
-			}
+			m.ReadKeyChanges = append(m.ReadKeyChanges, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			m.Timestamp = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2288,16 +9376,31 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, &AclSnapshotKeys{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IdentitySignature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2307,31 +9410,31 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IdentitySignature = append(m.IdentitySignature[:0], dAtA[iNdEx:postIndex]...)
-			if m.IdentitySignature == nil {
-				m.IdentitySignature = []byte{}
+			m.Accounts = append(m.Accounts, &AclSnapshotAccount{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StatesAtRecord", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2341,31 +9444,31 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MetadataPubKey = append(m.MetadataPubKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.MetadataPubKey == nil {
-				m.MetadataPubKey = []byte{}
+			m.StatesAtRecord = append(m.StatesAtRecord, &AclSnapshotRecordState{})
+			if err := m.StatesAtRecord[len(m.StatesAtRecord)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedMetadataPrivKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Invites", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2375,31 +9478,31 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedMetadataPrivKey = append(m.EncryptedMetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedMetadataPrivKey == nil {
-				m.EncryptedMetadataPrivKey = []byte{}
+			m.Invites = append(m.Invites, &AclSnapshotInvite{})
+			if err := m.Invites[len(m.Invites)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedOwnerMetadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2409,81 +9512,65 @@ func (m *AclRoot) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedOwnerMetadata = append(m.EncryptedOwnerMetadata[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedOwnerMetadata == nil {
-				m.EncryptedOwnerMetadata = []byte{}
+			m.Requests = append(m.Requests, &AclSnapshotRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAclrecord(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAclrecord
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthAclrecord
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AclAccountInvite) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAclrecord
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Roles = append(m.Roles, &AclRole{})
+			if err := m.Roles[len(m.Roles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountInvite: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountInvite: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnershipTransfers", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2493,24 +9580,24 @@ func (m *AclAccountInvite) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InviteKey = append(m.InviteKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.InviteKey == nil {
-				m.InviteKey = []byte{}
+			m.OwnershipTransfers = append(m.OwnershipTransfers, &AclSnapshotOwnershipTransfer{})
+			if err := m.OwnershipTransfers[len(m.OwnershipTransfers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -2534,7 +9621,7 @@ func (m *AclAccountInvite) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotKeys) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2557,17 +9644,17 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountRequestJoin: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotKeys: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountRequestJoin: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotKeys: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteIdentity", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2577,31 +9664,29 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InviteIdentity = append(m.InviteIdentity[:0], dAtA[iNdEx:postIndex]...)
-			if m.InviteIdentity == nil {
-				m.InviteIdentity = []byte{}
-			}
+			m.RecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2611,27 +9696,29 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			m.ReadKey = append(m.ReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ReadKey == nil {
+				m.ReadKey = []byte{}
+			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteIdentitySignature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPrivKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2658,14 +9745,14 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InviteIdentitySignature = append(m.InviteIdentitySignature[:0], dAtA[iNdEx:postIndex]...)
-			if m.InviteIdentitySignature == nil {
-				m.InviteIdentitySignature = []byte{}
+			m.MetadataPrivKey = append(m.MetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MetadataPrivKey == nil {
+				m.MetadataPrivKey = []byte{}
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPubKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2692,9 +9779,9 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
-			if m.Metadata == nil {
-				m.Metadata = []byte{}
+			m.MetadataPubKey = append(m.MetadataPubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MetadataPubKey == nil {
+				m.MetadataPubKey = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -2718,7 +9805,7 @@ func (m *AclAccountRequestJoin) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotAccount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2741,10 +9828,10 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountRequestAccept: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotAccount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountRequestAccept: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotAccount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2782,10 +9869,10 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestRecordId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
 			}
-			var stringLen uint64
+			m.Permissions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2795,29 +9882,16 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.RequestRecordId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2827,31 +9901,29 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedReadKey == nil {
-				m.EncryptedReadKey = []byte{}
-			}
+			m.Role = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
 			}
-			m.Permissions = 0
+			m.Capabilities = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -2861,64 +9933,48 @@ func (m *AclAccountRequestAccept) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Permissions |= AclUserPermissions(b&0x7F) << shift
+				m.Capabilities |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAclrecord(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestMetadata", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AclAccountRequestDecline) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAclrecord
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.RequestMetadata = append(m.RequestMetadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.RequestMetadata == nil {
+				m.RequestMetadata = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountRequestDecline: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountRequestDecline: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestRecordId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRecordId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2946,7 +10002,7 @@ func (m *AclAccountRequestDecline) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RequestRecordId = string(dAtA[iNdEx:postIndex])
+			m.KeyRecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2969,7 +10025,7 @@ func (m *AclAccountRequestDecline) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclAccountInviteRevoke) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotRecordState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2992,15 +10048,15 @@ func (m *AclAccountInviteRevoke) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountInviteRevoke: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotRecordState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountInviteRevoke: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotRecordState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3028,63 +10084,13 @@ func (m *AclAccountInviteRevoke) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			m.RecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAclrecord(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAclrecord
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AclEncryptedReadKey: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclEncryptedReadKey: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Accounts", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3094,29 +10100,29 @@ func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
-			if m.Identity == nil {
-				m.Identity = []byte{}
+			m.Accounts = append(m.Accounts, &AclSnapshotAccount{})
+			if err := m.Accounts[len(m.Accounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedIdentities", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3143,10 +10149,8 @@ func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedReadKey == nil {
-				m.EncryptedReadKey = []byte{}
-			}
+			m.RemovedIdentities = append(m.RemovedIdentities, make([]byte, postIndex-iNdEx))
+			copy(m.RemovedIdentities[len(m.RemovedIdentities)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3169,7 +10173,7 @@ func (m *AclEncryptedReadKey) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotInvite) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3192,15 +10196,47 @@ func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountPermissionChange: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotInvite: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountPermissionChange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotInvite: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3227,12 +10263,31 @@ func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
-			if m.Identity == nil {
-				m.Identity = []byte{}
+			m.InviteKey = append(m.InviteKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.InviteKey == nil {
+				m.InviteKey = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteType", wireType)
+			}
+			m.InviteType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InviteType |= AclInviteType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
 			}
@@ -3251,6 +10306,97 @@ func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireTimestamp", wireType)
+			}
+			m.ExpireTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireTimestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUses", wireType)
+			}
+			m.MaxUses = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUses |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedCount", wireType)
+			}
+			m.UsedCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsedCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedReadKey = append(m.EncryptedReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedReadKey == nil {
+				m.EncryptedReadKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -3272,7 +10418,7 @@ func (m *AclAccountPermissionChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3295,17 +10441,17 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclReadKeyChange: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclReadKeyChange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3315,29 +10461,27 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AccountKeys = append(m.AccountKeys, &AclEncryptedReadKey{})
-			if err := m.AccountKeys[len(m.AccountKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.RecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3364,14 +10508,14 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MetadataPubKey = append(m.MetadataPubKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.MetadataPubKey == nil {
-				m.MetadataPubKey = []byte{}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedMetadataPrivKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3398,16 +10542,55 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedMetadataPrivKey = append(m.EncryptedMetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedMetadataPrivKey == nil {
-				m.EncryptedMetadataPrivKey = []byte{}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pending = bool(v != 0)
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedOldReadKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InviteRecordId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3417,25 +10600,55 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EncryptedOldReadKey = append(m.EncryptedOldReadKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EncryptedOldReadKey == nil {
-				m.EncryptedOldReadKey = []byte{}
+			m.InviteRecordId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClosedRecordId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.ClosedRecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3458,7 +10671,7 @@ func (m *AclReadKeyChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclAccountRemove) Unmarshal(dAtA []byte) error {
+func (m *AclSnapshotOwnershipTransfer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3481,17 +10694,17 @@ func (m *AclAccountRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclSnapshotOwnershipTransfer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclSnapshotOwnershipTransfer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identities", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3501,29 +10714,29 @@ func (m *AclAccountRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Identities = append(m.Identities, make([]byte, postIndex-iNdEx))
-			copy(m.Identities[len(m.Identities)-1], dAtA[iNdEx:postIndex])
+			m.RecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyChange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnerIdentity", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3533,78 +10746,80 @@ func (m *AclAccountRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ReadKeyChange == nil {
-				m.ReadKeyChange = &AclReadKeyChange{}
-			}
-			if err := m.ReadKeyChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.OwnerIdentity = append(m.OwnerIdentity[:0], dAtA[iNdEx:postIndex]...)
+			if m.OwnerIdentity == nil {
+				m.OwnerIdentity = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAclrecord(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewOwnerIdentity", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AclAccountRequestRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAclrecord
+			m.NewOwnerIdentity = append(m.NewOwnerIdentity[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewOwnerIdentity == nil {
+				m.NewOwnerIdentity = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeepOwnership", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AclAccountRequestRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclAccountRequestRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.KeepOwnership = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAclrecord(dAtA[iNdEx:])
@@ -3626,7 +10841,7 @@ func (m *AclAccountRequestRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclContentValue) Unmarshal(dAtA []byte) error {
+func (m *AclPendingRecords) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3649,50 +10864,15 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclContentValue: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclPendingRecords: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclContentValue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclPendingRecords: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Invite", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAclrecord
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &AclAccountInvite{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &AclContentValue_Invite{v}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InviteRevoke", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3719,15 +10899,64 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclAccountInviteRevoke{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Records = append(m.Records, &AclPendingRecord{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &AclContentValue_InviteRevoke{v}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclPendingRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclPendingRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclPendingRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestJoin", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestAccept", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3754,15 +10983,15 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclAccountRequestJoin{}
+			v := &AclPendingRequestAccept{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &AclContentValue_RequestJoin{v}
+			m.Value = &AclPendingRecord_RequestAccept{v}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestAccept", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestDecline", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3789,13 +11018,13 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclAccountRequestAccept{}
+			v := &AclAccountRequestDecline{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &AclContentValue_RequestAccept{v}
+			m.Value = &AclPendingRecord_RequestDecline{v}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field PermissionChange", wireType)
 			}
@@ -3828,9 +11057,9 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &AclContentValue_PermissionChange{v}
+			m.Value = &AclPendingRecord_PermissionChange{v}
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field AccountRemove", wireType)
 			}
@@ -3859,17 +11088,67 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclAccountRemove{}
+			v := &AclPendingAccountRemove{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &AclContentValue_AccountRemove{v}
+			m.Value = &AclPendingRecord_AccountRemove{v}
 			iNdEx = postIndex
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAclrecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AclPendingRequestAccept) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAclrecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AclPendingRequestAccept: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AclPendingRequestAccept: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyChange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestRecordId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3879,32 +11158,29 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclReadKeyChange{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &AclContentValue_ReadKeyChange{v}
+			m.RequestRecordId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestDecline", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permissions", wireType)
 			}
-			var msglen int
+			m.Permissions = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3914,32 +11190,16 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Permissions |= AclUserPermissions(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAclrecord
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &AclAccountRequestDecline{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &AclContentValue_RequestDecline{v}
-			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AccountRequestRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -3949,26 +11209,23 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &AclAccountRequestRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &AclContentValue_AccountRequestRemove{v}
+			m.Role = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3991,7 +11248,7 @@ func (m *AclContentValue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AclData) Unmarshal(dAtA []byte) error {
+func (m *AclPendingAccountRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4014,17 +11271,17 @@ func (m *AclData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AclData: wiretype end group for non-group")
+			return fmt.Errorf("proto: AclPendingAccountRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AclData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AclPendingAccountRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AclContent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Identities", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAclrecord
@@ -4034,24 +11291,90 @@ func (m *AclData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAclrecord
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAclrecord
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AclContent = append(m.AclContent, &AclContentValue{})
-			if err := m.AclContent[len(m.AclContent)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Identities = append(m.Identities, make([]byte, postIndex-iNdEx))
+			copy(m.Identities[len(m.Identities)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataPrivKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetadataPrivKey = append(m.MetadataPrivKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.MetadataPrivKey == nil {
+				m.MetadataPrivKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAclrecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAclrecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadKey = append(m.ReadKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.ReadKey == nil {
+				m.ReadKey = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -31,14 +31,43 @@ type ReadKeyChangePayload struct {
 	ReadKey     crypto.SymKey
 }
 
+type InvitePayload struct {
+	// ExpireTimestamp is the unix time after which the invite can't be used, zero means no expiration
+	ExpireTimestamp int64
+	// MaxUses is the number of join requests which can be made with the invite, zero means no limit
+	MaxUses uint32
+}
+
+type InviteAnyonePayload struct {
+	InvitePayload
+	// Permissions are given to anyone joining with the invite
+	Permissions AclPermissions
+}
+
+type InviteJoinPayload struct {
+	InviteRecordId string
+	InviteKey      crypto.PrivKey
+	Metadata       []byte
+}
+
 type RequestAcceptPayload struct {
 	RequestRecordId string
 	Permissions     AclPermissions
+	// Role is the name of the custom role, if it is set then Permissions are derived from the role
+	Role string
 }
 
 type PermissionChangePayload struct {
 	Identity    crypto.PubKey
 	Permissions AclPermissions
+	// Role is the name of the custom role, if it is set then Permissions are derived from the role
+	Role string
+}
+
+type OwnershipTransferPayload struct {
+	Identity crypto.PubKey
+	// KeepOwnership is true if the current owner should stay an owner, otherwise it becomes an admin
+	KeepOwnership bool
 }
 
 type AccountRemovePayload struct {
@@ -46,6 +75,19 @@ type AccountRemovePayload struct {
 	Change     ReadKeyChangePayload
 }
 
+// BatchRequestPayload describes several changes which are made atomically in one record,
+// the read key is changed at most once after all other changes
+type BatchRequestPayload struct {
+	InviteRevokes []string
+	Declines      []string
+	Approvals     []RequestAcceptPayload
+	Changes       []PermissionChangePayload
+	// Removals are the accounts to remove, ReadKeyChange is required if they are set
+	Removals []crypto.PubKey
+	// ReadKeyChange is the new read key, it is given to all accounts including the approved ones
+	ReadKeyChange *ReadKeyChangePayload
+}
+
 type InviteResult struct {
 	InviteRec *consensusproto.RawRecord
 	InviteKey crypto.PrivKey
@@ -57,14 +99,25 @@ type AclRecordBuilder interface {
 
 	BuildRoot(content RootContent) (rec *consensusproto.RawRecordWithId, err error)
 	BuildInvite() (res InviteResult, err error)
+	BuildLimitedInvite(payload InvitePayload) (res InviteResult, err error)
+	BuildInviteAnyone(payload InviteAnyonePayload) (res InviteResult, err error)
+	BuildPublicReaderInvite() (res InviteResult, err error)
 	BuildInviteRevoke(inviteRecordId string) (rawRecord *consensusproto.RawRecord, err error)
+	BuildReadKeyInviteRevoke(inviteRecordId string, change ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildRequestJoin(payload RequestJoinPayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildInviteJoin(payload InviteJoinPayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildRequestAccept(payload RequestAcceptPayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildRequestDecline(requestRecordId string) (rawRecord *consensusproto.RawRecord, err error)
 	BuildRequestRemove() (rawRecord *consensusproto.RawRecord, err error)
 	BuildPermissionChange(payload PermissionChangePayload) (rawRecord *consensusproto.RawRecord, err error)
 	BuildReadKeyChange(payload ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildOwnershipTransfer(payload OwnershipTransferPayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildOwnershipAccept(transferRecordId string) (rawRecord *consensusproto.RawRecord, err error)
+	BuildRoleDefine(role AclRole) (rawRecord *consensusproto.RawRecord, err error)
+	BuildRoleRemove(name string) (rawRecord *consensusproto.RawRecord, err error)
 	BuildAccountRemove(payload AccountRemovePayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildBatchRequest(payload BatchRequestPayload) (rawRecord *consensusproto.RawRecord, err error)
+	BuildMetadataUpdate(metadata []byte) (rawRecord *consensusproto.RawRecord, err error)
 }
 
 type aclRecordBuilder struct {
@@ -84,10 +137,8 @@ func NewAclRecordBuilder(id string, keyStorage crypto.KeyStorage, keys *accountd
 	}
 }
 
-func (a *aclRecordBuilder) buildRecord(aclContent *aclrecordproto.AclContentValue) (rawRec *consensusproto.RawRecord, err error) {
-	aclData := &aclrecordproto.AclData{AclContent: []*aclrecordproto.AclContentValue{
-		aclContent,
-	}}
+func (a *aclRecordBuilder) buildRecord(aclContents ...*aclrecordproto.AclContentValue) (rawRec *consensusproto.RawRecord, err error) {
+	aclData := &aclrecordproto.AclData{AclContent: aclContents}
 	marshalledData, err := aclData.Marshal()
 	if err != nil {
 		return
@@ -118,10 +169,73 @@ func (a *aclRecordBuilder) buildRecord(aclContent *aclrecordproto.AclContentValu
 }
 
 func (a *aclRecordBuilder) BuildInvite() (res InviteResult, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	return a.BuildLimitedInvite(InvitePayload{})
+}
+
+func (a *aclRecordBuilder) BuildLimitedInvite(payload InvitePayload) (res InviteResult, err error) {
+	return a.buildInvite(payload, func(inviteRec *aclrecordproto.AclAccountInvite, inviteKey crypto.PubKey) error {
+		return nil
+	})
+}
+
+func (a *aclRecordBuilder) BuildInviteAnyone(payload InviteAnyonePayload) (res InviteResult, err error) {
+	if payload.Permissions.NoPermissions() || payload.Permissions.IsOwner() {
+		err = ErrInsufficientPermissions
+		return
+	}
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	readKey, err := a.state.CurrentReadKey()
+	if err != nil {
+		err = ErrNoReadKey
+		return
+	}
+	protoReadKey, err := readKey.Marshall()
+	if err != nil {
+		return
+	}
+	return a.buildInvite(payload.InvitePayload, func(inviteRec *aclrecordproto.AclAccountInvite, inviteKey crypto.PubKey) (err error) {
+		inviteRec.InviteType = aclrecordproto.AclInviteType_AnyoneCanJoin
+		inviteRec.Permissions = aclrecordproto.AclUserPermissions(payload.Permissions)
+		inviteRec.EncryptedReadKey, err = inviteKey.Encrypt(protoReadKey)
+		return
+	})
+}
+
+// BuildPublicReaderInvite builds the invite giving the read keys to anyone holding the invite key,
+// to exclude the holders from the future content the invite is revoked with BuildReadKeyInviteRevoke
+func (a *aclRecordBuilder) BuildPublicReaderInvite() (res InviteResult, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	readKey, err := a.state.CurrentReadKey()
+	if err != nil {
+		err = ErrNoReadKey
+		return
+	}
+	protoReadKey, err := readKey.Marshall()
+	if err != nil {
+		return
+	}
+	return a.buildInvite(InvitePayload{}, func(inviteRec *aclrecordproto.AclAccountInvite, inviteKey crypto.PubKey) (err error) {
+		inviteRec.InviteType = aclrecordproto.AclInviteType_PublicReader
+		inviteRec.EncryptedReadKey, err = inviteKey.Encrypt(protoReadKey)
+		return
+	})
+}
+
+func (a *aclRecordBuilder) buildInvite(payload InvitePayload, fillInvite func(inviteRec *aclrecordproto.AclAccountInvite, inviteKey crypto.PubKey) error) (res InviteResult, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
+	if payload.ExpireTimestamp != 0 && payload.ExpireTimestamp <= time.Now().Unix() {
+		err = ErrInviteExpired
+		return
+	}
 	privKey, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
 	if err != nil {
 		return
@@ -130,7 +244,14 @@ func (a *aclRecordBuilder) BuildInvite() (res InviteResult, err error) {
 	if err != nil {
 		return
 	}
-	inviteRec := &aclrecordproto.AclAccountInvite{InviteKey: invitePubKey}
+	inviteRec := &aclrecordproto.AclAccountInvite{
+		InviteKey:       invitePubKey,
+		ExpireTimestamp: payload.ExpireTimestamp,
+		MaxUses:         payload.MaxUses,
+	}
+	if err = fillInvite(inviteRec, pubKey); err != nil {
+		return
+	}
 	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_Invite{Invite: inviteRec}}
 	rawRec, err := a.buildRecord(content)
 	if err != nil {
@@ -141,29 +262,74 @@ func (a *aclRecordBuilder) BuildInvite() (res InviteResult, err error) {
 	return
 }
 
+// BuildInviteRevoke builds the revoke of the invite, the invites giving the read key can't be revoked this way,
+// because the holders of the invite key would still read the new content
 func (a *aclRecordBuilder) BuildInviteRevoke(inviteRecordId string) (rawRecord *consensusproto.RawRecord, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if invite, exists := a.state.invites[inviteRecordId]; exists && invite.HasReadKey() {
+		err = ErrReadKeyInviteRevoke
+		return
+	}
+	content, err := a.buildInviteRevoke(inviteRecordId)
+	if err != nil {
+		return
+	}
+	return a.buildRecord(content)
+}
+
+// BuildReadKeyInviteRevoke builds the batch revoking the AnyoneCanJoin or PublicReader invite and changing the read key,
+// so the holders of the invite key can't read the content made after the record
+func (a *aclRecordBuilder) BuildReadKeyInviteRevoke(inviteRecordId string, change ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error) {
+	invite, exists := a.state.invites[inviteRecordId]
+	if !exists {
+		err = ErrNoSuchInvite
+		return
+	}
+	if !invite.HasReadKey() {
+		err = ErrIncorrectInviteType
+		return
+	}
+	return a.BuildBatchRequest(BatchRequestPayload{
+		InviteRevokes: []string{inviteRecordId},
+		ReadKeyChange: &change,
+	})
+}
+
+func (a *aclRecordBuilder) buildInviteRevoke(inviteRecordId string) (content *aclrecordproto.AclContentValue, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityInvite) {
 		err = ErrInsufficientPermissions
 		return
 	}
-	_, exists := a.state.inviteKeys[inviteRecordId]
+	_, exists := a.state.invites[inviteRecordId]
 	if !exists {
 		err = ErrNoSuchInvite
 		return
 	}
 	revokeRec := &aclrecordproto.AclAccountInviteRevoke{InviteRecordId: inviteRecordId}
-	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_InviteRevoke{InviteRevoke: revokeRec}}
-	return a.buildRecord(content)
+	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_InviteRevoke{InviteRevoke: revokeRec}}
+	return
 }
 
 func (a *aclRecordBuilder) BuildRequestJoin(payload RequestJoinPayload) (rawRecord *consensusproto.RawRecord, err error) {
-	key, exists := a.state.inviteKeys[payload.InviteRecordId]
+	invite, exists := a.state.invites[payload.InviteRecordId]
 	if !exists {
 		err = ErrNoSuchInvite
 		return
 	}
-	if !payload.InviteKey.GetPublic().Equals(key) {
+	if invite.Type == aclrecordproto.AclInviteType_PublicReader {
+		err = ErrIncorrectInviteType
+		return
+	}
+	if !payload.InviteKey.GetPublic().Equals(invite.Key) {
 		err = ErrIncorrectInviteKey
+		return
+	}
+	if invite.IsExpired(time.Now().Unix()) {
+		err = ErrInviteExpired
+		return
+	}
+	if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+		err = ErrInviteExhausted
+		return
 	}
 	mkKey, err := a.state.CurrentMetadataKey()
 	if err != nil {
@@ -198,16 +364,108 @@ func (a *aclRecordBuilder) BuildRequestJoin(payload RequestJoinPayload) (rawReco
 	return a.buildRecord(content)
 }
 
+func (a *aclRecordBuilder) BuildInviteJoin(payload InviteJoinPayload) (rawRecord *consensusproto.RawRecord, err error) {
+	invite, exists := a.state.invites[payload.InviteRecordId]
+	if !exists {
+		err = ErrNoSuchInvite
+		return
+	}
+	if invite.Type != aclrecordproto.AclInviteType_AnyoneCanJoin {
+		err = ErrIncorrectInviteType
+		return
+	}
+	if !payload.InviteKey.GetPublic().Equals(invite.Key) {
+		err = ErrIncorrectInviteKey
+		return
+	}
+	if invite.IsExpired(time.Now().Unix()) {
+		err = ErrInviteExpired
+		return
+	}
+	if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+		err = ErrInviteExhausted
+		return
+	}
+	if !a.state.Permissions(a.state.pubKey).NoPermissions() {
+		err = ErrAccountExists
+		return
+	}
+	protoReadKey, err := payload.InviteKey.Decrypt(invite.EncryptedReadKey)
+	if err != nil {
+		err = ErrFailedToDecrypt
+		return
+	}
+	encReadKey, err := a.accountKeys.SignKey.GetPublic().Encrypt(protoReadKey)
+	if err != nil {
+		return
+	}
+	mkKey, err := a.state.CurrentMetadataKey()
+	if err != nil {
+		return nil, err
+	}
+	encMeta, err := mkKey.Encrypt(payload.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(encMeta) > MaxMetadataLen {
+		return nil, ErrMetadataTooLarge
+	}
+	rawIdentity, err := a.accountKeys.SignKey.GetPublic().Raw()
+	if err != nil {
+		return
+	}
+	signature, err := payload.InviteKey.Sign(rawIdentity)
+	if err != nil {
+		return
+	}
+	protoIdentity, err := a.accountKeys.SignKey.GetPublic().Marshall()
+	if err != nil {
+		return
+	}
+	joinRec := &aclrecordproto.AclAccountInviteJoin{
+		Identity:                protoIdentity,
+		InviteRecordId:          payload.InviteRecordId,
+		InviteIdentitySignature: signature,
+		Metadata:                encMeta,
+		EncryptedReadKey:        encReadKey,
+		Permissions:             aclrecordproto.AclUserPermissions(invite.Permissions),
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_InviteJoin{InviteJoin: joinRec}}
+	return a.buildRecord(content)
+}
+
 func (a *aclRecordBuilder) BuildRequestAccept(payload RequestAcceptPayload) (rawRecord *consensusproto.RawRecord, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	content, err := a.buildRequestAccept(payload)
+	if err != nil {
+		return
+	}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) buildRequestAccept(payload RequestAcceptPayload) (content *aclrecordproto.AclContentValue, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
 		err = ErrInsufficientPermissions
 		return
 	}
-	request, exists := a.state.requestRecords[payload.RequestRecordId]
-	if !exists {
+	if !a.state.isPendingRequest(payload.RequestRecordId) {
 		err = ErrNoSuchRequest
 		return
 	}
+	request := a.state.requestRecords[payload.RequestRecordId]
+	if invite, exists := a.state.invites[request.InviteRecordId]; exists {
+		if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+			err = ErrInviteExhausted
+			return
+		}
+	}
+	if payload.Role != "" {
+		role, exists := a.state.roles[payload.Role]
+		if !exists {
+			err = ErrNoSuchRole
+			return
+		}
+		payload.Permissions = role.fallbackPermissions()
+	}
 	readKey, err := a.state.CurrentReadKey()
 	if err != nil {
 		return nil, ErrNoReadKey
@@ -229,29 +487,44 @@ func (a *aclRecordBuilder) BuildRequestAccept(payload RequestAcceptPayload) (raw
 		RequestRecordId:  payload.RequestRecordId,
 		EncryptedReadKey: enc,
 		Permissions:      aclrecordproto.AclUserPermissions(payload.Permissions),
+		Role:             payload.Role,
 	}
-	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestAccept{RequestAccept: acceptRec}}
-	return a.buildRecord(content)
+	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestAccept{RequestAccept: acceptRec}}
+	return
 }
 
 func (a *aclRecordBuilder) BuildRequestDecline(requestRecordId string) (rawRecord *consensusproto.RawRecord, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	content, err := a.buildRequestDecline(requestRecordId)
+	if err != nil {
+		return
+	}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) buildRequestDecline(requestRecordId string) (content *aclrecordproto.AclContentValue, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
 		err = ErrInsufficientPermissions
 		return
 	}
-	_, exists := a.state.requestRecords[requestRecordId]
-	if !exists {
+	if !a.state.isPendingRequest(requestRecordId) {
 		err = ErrNoSuchRequest
 		return
 	}
 	declineRec := &aclrecordproto.AclAccountRequestDecline{RequestRecordId: requestRecordId}
-	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestDecline{RequestDecline: declineRec}}
-	return a.buildRecord(content)
+	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RequestDecline{RequestDecline: declineRec}}
+	return
 }
 
 func (a *aclRecordBuilder) BuildPermissionChange(payload PermissionChangePayload) (rawRecord *consensusproto.RawRecord, err error) {
-	permissions := a.state.Permissions(a.state.pubKey)
-	if !permissions.CanManageAccounts() || payload.Identity.Equals(a.state.pubKey) {
+	content, err := a.buildPermissionChange(payload)
+	if err != nil {
+		return
+	}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) buildPermissionChange(payload PermissionChangePayload) (content *aclrecordproto.AclContentValue, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityChangePermissions) || payload.Identity.Equals(a.state.pubKey) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -259,6 +532,14 @@ func (a *aclRecordBuilder) BuildPermissionChange(payload PermissionChangePayload
 		err = ErrIsOwner
 		return
 	}
+	if payload.Role != "" {
+		role, exists := a.state.roles[payload.Role]
+		if !exists {
+			err = ErrNoSuchRole
+			return
+		}
+		payload.Permissions = role.fallbackPermissions()
+	}
 	protoIdentity, err := payload.Identity.Marshall()
 	if err != nil {
 		return
@@ -266,17 +547,18 @@ func (a *aclRecordBuilder) BuildPermissionChange(payload PermissionChangePayload
 	permissionRec := &aclrecordproto.AclAccountPermissionChange{
 		Identity:    protoIdentity,
 		Permissions: aclrecordproto.AclUserPermissions(payload.Permissions),
+		Role:        payload.Role,
 	}
-	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_PermissionChange{PermissionChange: permissionRec}}
-	return a.buildRecord(content)
+	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_PermissionChange{PermissionChange: permissionRec}}
+	return
 }
 
 func (a *aclRecordBuilder) BuildReadKeyChange(payload ReadKeyChangePayload) (rawRecord *consensusproto.RawRecord, err error) {
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityChangeReadKey) {
 		err = ErrInsufficientPermissions
 		return
 	}
-	rkChange, err := a.buildReadKeyChange(payload, nil)
+	rkChange, err := a.buildReadKeyChange(payload, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -284,24 +566,106 @@ func (a *aclRecordBuilder) BuildReadKeyChange(payload ReadKeyChangePayload) (raw
 	return a.buildRecord(content)
 }
 
-func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, removedIdentities map[string]struct{}) (*aclrecordproto.AclReadKeyChange, error) {
+func (a *aclRecordBuilder) BuildOwnershipTransfer(payload OwnershipTransferPayload) (rawRecord *consensusproto.RawRecord, err error) {
+	if !a.state.Permissions(a.state.pubKey).IsOwner() {
+		err = ErrInsufficientPermissions
+		return
+	}
+	permissions := a.state.Permissions(payload.Identity)
+	if permissions.NoPermissions() {
+		err = ErrNoSuchAccount
+		return
+	}
+	if permissions.IsOwner() {
+		err = ErrIsOwner
+		return
+	}
+	protoIdentity, err := payload.Identity.Marshall()
+	if err != nil {
+		return
+	}
+	transferRec := &aclrecordproto.AclOwnershipTransfer{
+		Identity:      protoIdentity,
+		KeepOwnership: payload.KeepOwnership,
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_OwnershipTransfer{OwnershipTransfer: transferRec}}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) BuildOwnershipAccept(transferRecordId string) (rawRecord *consensusproto.RawRecord, err error) {
+	transfer, exists := a.state.ownershipTransfers[transferRecordId]
+	if !exists {
+		err = ErrNoSuchOwnershipTransfer
+		return
+	}
+	if !transfer.NewOwnerIdentity.Equals(a.state.pubKey) {
+		err = ErrIncorrectIdentity
+		return
+	}
+	acceptRec := &aclrecordproto.AclOwnershipAccept{TransferRecordId: transferRecordId}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_OwnershipAccept{OwnershipAccept: acceptRec}}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) BuildRoleDefine(role AclRole) (rawRecord *consensusproto.RawRecord, err error) {
+	capabilities := a.state.Capabilities(a.state.pubKey)
+	if !capabilities.Has(aclrecordproto.AclCapability_CapabilityManageRoles) || !capabilities.Contains(role.Capabilities) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	if role.Name == "" || isBuiltinRoleName(role.Name) {
+		err = ErrIncorrectRole
+		return
+	}
+	defineRec := &aclrecordproto.AclRoleDefine{Role: &aclrecordproto.AclRole{
+		Name:         role.Name,
+		Capabilities: role.Capabilities.List(),
+	}}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RoleDefine{RoleDefine: defineRec}}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) BuildRoleRemove(name string) (rawRecord *consensusproto.RawRecord, err error) {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityManageRoles) {
+		err = ErrInsufficientPermissions
+		return
+	}
+	if _, exists := a.state.roles[name]; !exists {
+		err = ErrNoSuchRole
+		return
+	}
+	removeRec := &aclrecordproto.AclRoleRemove{Name: name}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RoleRemove{RoleRemove: removeRec}}
+	return a.buildRecord(content)
+}
+
+// buildReadKeyChange encrypts the new read key for all accounts except the removed ones,
+// the added accounts and the revoked invites are the changes made earlier in the same record
+func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, removedIdentities map[string]struct{}, batch *batchChanges) (*aclrecordproto.AclReadKeyChange, error) {
 	// encrypting new read key with all keys of users
 	protoKey, err := payload.ReadKey.Marshall()
 	if err != nil {
 		return nil, err
 	}
-	var aclReadKeys []*aclrecordproto.AclEncryptedReadKey
+	accounts := make([]crypto.PubKey, 0, len(a.state.accountStates))
 	for identity, st := range a.state.accountStates {
 		if removedIdentities != nil {
 			if _, exists := removedIdentities[identity]; exists {
 				continue
 			}
 		}
-		protoIdentity, err := st.PubKey.Marshall()
+		accounts = append(accounts, st.PubKey)
+	}
+	if batch != nil {
+		accounts = append(accounts, batch.addedIdentities...)
+	}
+	var aclReadKeys []*aclrecordproto.AclEncryptedReadKey
+	for _, pubKey := range accounts {
+		protoIdentity, err := pubKey.Marshall()
 		if err != nil {
 			return nil, err
 		}
-		enc, err := st.PubKey.Encrypt(protoKey)
+		enc, err := pubKey.Encrypt(protoKey)
 		if err != nil {
 			return nil, err
 		}
@@ -310,6 +674,30 @@ func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, remo
 			EncryptedReadKey: enc,
 		})
 	}
+	// encrypting new read key with the keys of invites which don't require approval and public reader invites
+	var inviteReadKeys []*aclrecordproto.AclEncryptedReadKey
+	for id, invite := range a.state.invites {
+		if !invite.HasReadKey() {
+			continue
+		}
+		if batch != nil {
+			if _, revoked := batch.revokedInvites[id]; revoked {
+				continue
+			}
+		}
+		protoInviteKey, err := invite.Key.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		enc, err := invite.Key.Encrypt(protoKey)
+		if err != nil {
+			return nil, err
+		}
+		inviteReadKeys = append(inviteReadKeys, &aclrecordproto.AclEncryptedReadKey{
+			Identity:         protoInviteKey,
+			EncryptedReadKey: enc,
+		})
+	}
 	// encrypting metadata key with new read key
 	mkPubKey, err := payload.MetadataKey.GetPublic().Marshall()
 	if err != nil {
@@ -341,11 +729,20 @@ func (a *aclRecordBuilder) buildReadKeyChange(payload ReadKeyChangePayload, remo
 		MetadataPubKey:           mkPubKey,
 		EncryptedMetadataPrivKey: encPrivKey,
 		EncryptedOldReadKey:      encOldKey,
+		InviteKeys:               inviteReadKeys,
 	}
 	return readRec, nil
 }
 
 func (a *aclRecordBuilder) BuildAccountRemove(payload AccountRemovePayload) (rawRecord *consensusproto.RawRecord, err error) {
+	content, err := a.buildAccountRemove(payload, nil)
+	if err != nil {
+		return
+	}
+	return a.buildRecord(content)
+}
+
+func (a *aclRecordBuilder) buildAccountRemove(payload AccountRemovePayload, batch *batchChanges) (content *aclrecordproto.AclContentValue, err error) {
 	deletedMap := map[string]struct{}{}
 	for _, key := range payload.Identities {
 		permissions := a.state.Permissions(key)
@@ -357,7 +754,7 @@ func (a *aclRecordBuilder) BuildAccountRemove(payload AccountRemovePayload) (raw
 		}
 		deletedMap[mapKeyFromPubKey(key)] = struct{}{}
 	}
-	if !a.state.Permissions(a.state.pubKey).CanManageAccounts() {
+	if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityRemoveAccounts) {
 		err = ErrInsufficientPermissions
 		return
 	}
@@ -369,13 +766,80 @@ func (a *aclRecordBuilder) BuildAccountRemove(payload AccountRemovePayload) (raw
 		}
 		marshalledIdentities = append(marshalledIdentities, protoIdentity)
 	}
-	rkChange, err := a.buildReadKeyChange(payload.Change, deletedMap)
+	rkChange, err := a.buildReadKeyChange(payload.Change, deletedMap, batch)
 	if err != nil {
 		return nil, err
 	}
 	removeRec := &aclrecordproto.AclAccountRemove{ReadKeyChange: rkChange, Identities: marshalledIdentities}
-	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_AccountRemove{AccountRemove: removeRec}}
-	return a.buildRecord(content)
+	content = &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_AccountRemove{AccountRemove: removeRec}}
+	return
+}
+
+// batchChanges are the changes made by the batch before the read key change
+type batchChanges struct {
+	addedIdentities []crypto.PubKey
+	revokedInvites  map[string]struct{}
+}
+
+func (a *aclRecordBuilder) BuildBatchRequest(payload BatchRequestPayload) (rawRecord *consensusproto.RawRecord, err error) {
+	var (
+		contents []*aclrecordproto.AclContentValue
+		batch    = &batchChanges{revokedInvites: map[string]struct{}{}}
+	)
+	addContent := func(content *aclrecordproto.AclContentValue, err error) error {
+		if err != nil {
+			return err
+		}
+		contents = append(contents, content)
+		return nil
+	}
+	for _, inviteRecordId := range payload.InviteRevokes {
+		if err = addContent(a.buildInviteRevoke(inviteRecordId)); err != nil {
+			return
+		}
+		batch.revokedInvites[inviteRecordId] = struct{}{}
+	}
+	for _, requestRecordId := range payload.Declines {
+		if err = addContent(a.buildRequestDecline(requestRecordId)); err != nil {
+			return
+		}
+	}
+	for _, approval := range payload.Approvals {
+		if err = addContent(a.buildRequestAccept(approval)); err != nil {
+			return
+		}
+		batch.addedIdentities = append(batch.addedIdentities, a.state.requestRecords[approval.RequestRecordId].RequestIdentity)
+	}
+	for _, change := range payload.Changes {
+		if err = addContent(a.buildPermissionChange(change)); err != nil {
+			return
+		}
+	}
+	switch {
+	case len(payload.Removals) != 0:
+		if payload.ReadKeyChange == nil {
+			return nil, ErrNoReadKey
+		}
+		err = addContent(a.buildAccountRemove(AccountRemovePayload{
+			Identities: payload.Removals,
+			Change:     *payload.ReadKeyChange,
+		}, batch))
+	case payload.ReadKeyChange != nil:
+		if !a.state.Capabilities(a.state.pubKey).Has(aclrecordproto.AclCapability_CapabilityChangeReadKey) {
+			return nil, ErrInsufficientPermissions
+		}
+		var rkChange *aclrecordproto.AclReadKeyChange
+		if rkChange, err = a.buildReadKeyChange(*payload.ReadKeyChange, nil, batch); err == nil {
+			contents = append(contents, &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_ReadKeyChange{ReadKeyChange: rkChange}})
+		}
+	}
+	if err != nil {
+		return
+	}
+	if len(contents) == 0 {
+		return nil, ErrIncorrectBatch
+	}
+	return a.buildRecord(contents...)
 }
 
 func (a *aclRecordBuilder) BuildRequestRemove() (rawRecord *consensusproto.RawRecord, err error) {
@@ -393,6 +857,34 @@ func (a *aclRecordBuilder) BuildRequestRemove() (rawRecord *consensusproto.RawRe
 	return a.buildRecord(content)
 }
 
+func (a *aclRecordBuilder) BuildMetadataUpdate(metadata []byte) (rawRecord *consensusproto.RawRecord, err error) {
+	if a.state.Permissions(a.state.pubKey).NoPermissions() {
+		err = ErrNoSuchAccount
+		return
+	}
+	mkKey, err := a.state.CurrentMetadataKey()
+	if err != nil {
+		return nil, err
+	}
+	encMeta, err := mkKey.Encrypt(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(encMeta) > MaxMetadataLen {
+		return nil, ErrMetadataTooLarge
+	}
+	protoIdentity, err := a.state.pubKey.Marshall()
+	if err != nil {
+		return
+	}
+	updateRec := &aclrecordproto.AclAccountMetadataUpdate{
+		Identity: protoIdentity,
+		Metadata: encMeta,
+	}
+	content := &aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_MetadataUpdate{MetadataUpdate: updateRec}}
+	return a.buildRecord(content)
+}
+
 func (a *aclRecordBuilder) Unmarshall(rawRecord *consensusproto.RawRecord) (rec *AclRecord, err error) {
 	aclRecord := &consensusproto.Record{}
 	err = proto.Unmarshal(rawRecord.Payload, aclRecord)
@@ -474,13 +966,14 @@ func (a *aclRecordBuilder) UnmarshallWithId(rawIdRecord *consensusproto.RawRecor
 			return
 		}
 		rec = &AclRecord{
-			Id:        rawIdRecord.Id,
-			PrevId:    aclRecord.PrevId,
-			Timestamp: aclRecord.Timestamp,
-			Data:      aclRecord.Data,
-			Signature: rawRec.Signature,
-			Identity:  pubKey,
-			Model:     aclData,
+			Id:                rawIdRecord.Id,
+			PrevId:            aclRecord.PrevId,
+			Timestamp:         aclRecord.Timestamp,
+			AcceptorTimestamp: rawRec.AcceptorTimestamp,
+			Data:              aclRecord.Data,
+			Signature:         rawRec.Signature,
+			Identity:          pubKey,
+			Model:             aclData,
 		}
 	}
 
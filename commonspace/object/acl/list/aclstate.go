@@ -5,6 +5,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/anyproto/any-sync/app/logger"
 	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
@@ -34,6 +36,16 @@ var (
 	ErrIncorrectRoot             = errors.New("incorrect root")
 	ErrIncorrectRecordSequence   = errors.New("incorrect prev id of a record")
 	ErrMetadataTooLarge          = errors.New("metadata size too large")
+	ErrInviteExpired             = errors.New("invite expired")
+	ErrInviteExhausted           = errors.New("invite has no uses left")
+	ErrIncorrectInviteType       = errors.New("incorrect invite type")
+	ErrAccountExists             = errors.New("account already exists")
+	ErrNoSuchRole                = errors.New("no such role")
+	ErrIncorrectRole             = errors.New("incorrect role")
+	ErrRoleInUse                 = errors.New("role is given to some accounts")
+	ErrNoSuchOwnershipTransfer   = errors.New("no such ownership transfer")
+	ErrIncorrectBatch            = errors.New("incorrect batch of acl contents")
+	ErrReadKeyInviteRevoke       = errors.New("invite giving the read key can be revoked only together with the read key change")
 )
 
 const MaxMetadataLen = 1024
@@ -60,12 +72,18 @@ type AclState struct {
 	//  probably this can grow rather large at some point, so we can maybe optimise later to have:
 	//  - map pubKey -> []recordIds (where recordIds is an array where such identity permissions were changed)
 	statesAtRecord map[string][]AclAccountState
-	// inviteKeys is a map recordId -> invite
-	inviteKeys map[string]crypto.PubKey
+	// invites is a map recordId -> invite
+	invites map[string]AclInvite
 	// requestRecords is a map recordId -> RequestRecord
 	requestRecords map[string]RequestRecord
 	// pendingRequests is a map pubKey -> recordId
 	pendingRequests map[string]string
+	// closedRequests is a map requestRecordId -> id of the record which accepted, declined or removed the request
+	closedRequests map[string]string
+	// roles is a map name -> custom role defined in the acl
+	roles map[string]AclRole
+	// ownershipTransfers is a map recordId -> pending ownership transfer
+	ownershipTransfers map[string]OwnershipTransferRecord
 	// readKeyChanges is a list of records containing read key changes
 	readKeyChanges []string
 	key            crypto.PrivKey
@@ -81,16 +99,19 @@ func newAclStateWithKeys(
 	rootRecord *AclRecord,
 	key crypto.PrivKey) (st *AclState, err error) {
 	st = &AclState{
-		id:              rootRecord.Id,
-		key:             key,
-		pubKey:          key.GetPublic(),
-		keys:            make(map[string]AclKeys),
-		accountStates:   make(map[string]AclAccountState),
-		statesAtRecord:  make(map[string][]AclAccountState),
-		inviteKeys:      make(map[string]crypto.PubKey),
-		requestRecords:  make(map[string]RequestRecord),
-		pendingRequests: make(map[string]string),
-		keyStore:        crypto.NewKeyStorage(),
+		id:                 rootRecord.Id,
+		key:                key,
+		pubKey:             key.GetPublic(),
+		keys:               make(map[string]AclKeys),
+		accountStates:      make(map[string]AclAccountState),
+		statesAtRecord:     make(map[string][]AclAccountState),
+		invites:            make(map[string]AclInvite),
+		requestRecords:     make(map[string]RequestRecord),
+		pendingRequests:    make(map[string]string),
+		closedRequests:     make(map[string]string),
+		roles:              make(map[string]AclRole),
+		ownershipTransfers: make(map[string]OwnershipTransferRecord),
+		keyStore:           crypto.NewKeyStorage(),
 	}
 	st.contentValidator = &contentValidator{
 		keyStore: st.keyStore,
@@ -108,14 +129,17 @@ func newAclStateWithKeys(
 
 func newAclState(rootRecord *AclRecord) (st *AclState, err error) {
 	st = &AclState{
-		id:              rootRecord.Id,
-		keys:            make(map[string]AclKeys),
-		accountStates:   make(map[string]AclAccountState),
-		statesAtRecord:  make(map[string][]AclAccountState),
-		inviteKeys:      make(map[string]crypto.PubKey),
-		requestRecords:  make(map[string]RequestRecord),
-		pendingRequests: make(map[string]string),
-		keyStore:        crypto.NewKeyStorage(),
+		id:                 rootRecord.Id,
+		keys:               make(map[string]AclKeys),
+		accountStates:      make(map[string]AclAccountState),
+		statesAtRecord:     make(map[string][]AclAccountState),
+		invites:            make(map[string]AclInvite),
+		requestRecords:     make(map[string]RequestRecord),
+		pendingRequests:    make(map[string]string),
+		closedRequests:     make(map[string]string),
+		roles:              make(map[string]AclRole),
+		ownershipTransfers: make(map[string]OwnershipTransferRecord),
+		keyStore:           crypto.NewKeyStorage(),
 	}
 	st.contentValidator = &contentValidator{
 		keyStore: st.keyStore,
@@ -232,6 +256,7 @@ func (st *AclState) applyRoot(record *AclRecord) (err error) {
 	accountState := AclAccountState{
 		PubKey:          record.Identity,
 		Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Owner),
+		Capabilities:    BuiltinRole(AclPermissions(aclrecordproto.AclUserPermissions_Owner)).Capabilities,
 		KeyRecordId:     record.Id,
 		RequestMetadata: root.EncryptedOwnerMetadata,
 	}
@@ -267,6 +292,21 @@ func (st *AclState) saveKeysFromRoot(id string, root *aclrecordproto.AclRoot) (e
 
 func (st *AclState) applyChangeData(record *AclRecord) (err error) {
 	model := record.Model.(*aclrecordproto.AclData)
+	if err = st.checkReadKeyInviteRevokes(model.GetAclContent()); err != nil {
+		return err
+	}
+	if len(model.GetAclContent()) > 1 {
+		// batches are applied to the copy of the state, so that the record either applies fully or not at all
+		batchState, err := st.applyBatch(record)
+		if err != nil {
+			log.Info("error while applying batch; ignore", zap.Error(err))
+			return err
+		}
+		validator := st.contentValidator
+		*st = *batchState
+		st.contentValidator = validator
+		return nil
+	}
 	for _, ch := range model.GetAclContent() {
 		if err = st.applyChangeContent(ch, record); err != nil {
 			log.Info("error while applying changes; ignore", zap.Error(err))
@@ -276,6 +316,114 @@ func (st *AclState) applyChangeData(record *AclRecord) (err error) {
 	return nil
 }
 
+// applyBatch applies the contents of the record one by one to the copy of the state,
+// so each content is validated against the state changed by the previous contents
+func (st *AclState) applyBatch(record *AclRecord) (batchState *AclState, err error) {
+	contents := record.Model.(*aclrecordproto.AclData).GetAclContent()
+	// only one read key change is possible in a record and it should be the last one,
+	// so that the new read key is given to all accounts added by the record
+	for idx, ch := range contents {
+		if isReadKeyChangeContent(ch) && idx != len(contents)-1 {
+			return nil, ErrIncorrectBatch
+		}
+	}
+	var touched stateMaps
+	for _, ch := range contents {
+		touched |= touchedStateMaps(ch)
+	}
+	batchState = st.copy(touched)
+	for _, ch := range contents {
+		if err = batchState.applyChangeContent(ch, record); err != nil {
+			return nil, err
+		}
+	}
+	return batchState, nil
+}
+
+// stateMaps is a set of the maps of the state which are changed by the record contents
+type stateMaps uint8
+
+const (
+	stateKeys stateMaps = 1 << iota
+	stateAccounts
+	stateInvites
+	stateRequests
+	statePendingRequests
+	stateRoles
+	stateOwnershipTransfers
+	stateReadKeyChanges
+)
+
+// touchedStateMaps returns the maps changed by applying the content, it should be updated with the apply functions
+func touchedStateMaps(ch *aclrecordproto.AclContentValue) stateMaps {
+	switch {
+	case ch.GetPermissionChange() != nil, ch.GetMetadataUpdate() != nil:
+		return stateAccounts
+	case ch.GetInvite() != nil:
+		return stateInvites | stateKeys
+	case ch.GetInviteRevoke() != nil:
+		return stateInvites
+	case ch.GetRequestJoin() != nil, ch.GetAccountRequestRemove() != nil:
+		return stateRequests | statePendingRequests
+	case ch.GetRequestDecline() != nil:
+		return statePendingRequests
+	case ch.GetRequestAccept() != nil:
+		return stateAccounts | statePendingRequests | stateInvites | stateKeys
+	case ch.GetInviteJoin() != nil:
+		return stateAccounts | stateInvites | stateKeys
+	case ch.GetAccountRemove() != nil:
+		return stateAccounts | statePendingRequests | stateOwnershipTransfers | stateReadKeyChanges | stateKeys | stateInvites
+	case ch.GetReadKeyChange() != nil:
+		return stateReadKeyChanges | stateKeys | stateInvites
+	case ch.GetRoleDefine() != nil:
+		return stateRoles | stateAccounts
+	case ch.GetRoleRemove() != nil:
+		return stateRoles
+	case ch.GetOwnershipTransfer() != nil:
+		return stateOwnershipTransfers
+	case ch.GetOwnershipAccept() != nil:
+		return stateAccounts | stateOwnershipTransfers
+	default:
+		return 0
+	}
+}
+
+// copy returns the copy of the state where the touched maps can be changed without affecting the original state,
+// the other maps are shared, statesAtRecord is always shared because it is changed only after all contents are applied
+func (st *AclState) copy(touched stateMaps) *AclState {
+	cp := *st
+	if touched&stateKeys != 0 {
+		cp.keys = maps.Clone(st.keys)
+	}
+	if touched&stateAccounts != 0 {
+		cp.accountStates = maps.Clone(st.accountStates)
+	}
+	if touched&stateInvites != 0 {
+		cp.invites = maps.Clone(st.invites)
+	}
+	if touched&stateRequests != 0 {
+		cp.requestRecords = maps.Clone(st.requestRecords)
+	}
+	if touched&statePendingRequests != 0 {
+		cp.pendingRequests = maps.Clone(st.pendingRequests)
+		cp.closedRequests = maps.Clone(st.closedRequests)
+	}
+	if touched&stateRoles != 0 {
+		cp.roles = maps.Clone(st.roles)
+	}
+	if touched&stateOwnershipTransfers != 0 {
+		cp.ownershipTransfers = maps.Clone(st.ownershipTransfers)
+	}
+	if touched&stateReadKeyChanges != 0 {
+		cp.readKeyChanges = slices.Clone(st.readKeyChanges)
+	}
+	cp.contentValidator = &contentValidator{
+		keyStore: cp.keyStore,
+		aclState: &cp,
+	}
+	return &cp
+}
+
 func (st *AclState) applyChangeContent(ch *aclrecordproto.AclContentValue, record *AclRecord) error {
 	switch {
 	case ch.GetPermissionChange() != nil:
@@ -296,6 +444,18 @@ func (st *AclState) applyChangeContent(ch *aclrecordproto.AclContentValue, recor
 		return st.applyReadKeyChange(ch.GetReadKeyChange(), record, true)
 	case ch.GetAccountRequestRemove() != nil:
 		return st.applyRequestRemove(ch.GetAccountRequestRemove(), record)
+	case ch.GetInviteJoin() != nil:
+		return st.applyInviteJoin(ch.GetInviteJoin(), record)
+	case ch.GetRoleDefine() != nil:
+		return st.applyRoleDefine(ch.GetRoleDefine(), record)
+	case ch.GetRoleRemove() != nil:
+		return st.applyRoleRemove(ch.GetRoleRemove(), record)
+	case ch.GetOwnershipTransfer() != nil:
+		return st.applyOwnershipTransfer(ch.GetOwnershipTransfer(), record)
+	case ch.GetOwnershipAccept() != nil:
+		return st.applyOwnershipAccept(ch.GetOwnershipAccept(), record)
+	case ch.GetMetadataUpdate() != nil:
+		return st.applyMetadataUpdate(ch.GetMetadataUpdate(), record)
 	default:
 		return ErrUnexpectedContentType
 	}
@@ -313,10 +473,94 @@ func (st *AclState) applyPermissionChange(ch *aclrecordproto.AclAccountPermissio
 	stringKey := mapKeyFromPubKey(chIdentity)
 	state, _ := st.accountStates[stringKey]
 	state.Permissions = AclPermissions(ch.Permissions)
+	state.Role = ch.Role
+	state.Capabilities = st.resolveCapabilities(state.Permissions, state.Role)
 	st.accountStates[stringKey] = state
 	return nil
 }
 
+func (st *AclState) applyRoleDefine(ch *aclrecordproto.AclRoleDefine, record *AclRecord) error {
+	err := st.contentValidator.ValidateRoleDefine(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	role := AclRole{
+		Name:         ch.Role.Name,
+		Capabilities: NewAclCapabilities(ch.Role.Capabilities...),
+	}
+	st.roles[role.Name] = role
+	// updating the accounts which already have this role
+	for idKey, state := range st.accountStates {
+		if state.Role == role.Name {
+			state.Capabilities = role.Capabilities
+			st.accountStates[idKey] = state
+		}
+	}
+	return nil
+}
+
+func (st *AclState) applyRoleRemove(ch *aclrecordproto.AclRoleRemove, record *AclRecord) error {
+	err := st.contentValidator.ValidateRoleRemove(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	delete(st.roles, ch.Name)
+	return nil
+}
+
+func (st *AclState) applyOwnershipTransfer(ch *aclrecordproto.AclOwnershipTransfer, record *AclRecord) error {
+	err := st.contentValidator.ValidateOwnershipTransfer(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	newOwner, err := st.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return err
+	}
+	// each owner can have only one pending transfer, so the new one replaces the previous
+	for id, transfer := range st.ownershipTransfers {
+		if transfer.OwnerIdentity.Equals(record.Identity) {
+			delete(st.ownershipTransfers, id)
+		}
+	}
+	st.ownershipTransfers[record.Id] = OwnershipTransferRecord{
+		RecordId:         record.Id,
+		OwnerIdentity:    record.Identity,
+		NewOwnerIdentity: newOwner,
+		KeepOwnership:    ch.KeepOwnership,
+	}
+	return nil
+}
+
+func (st *AclState) applyOwnershipAccept(ch *aclrecordproto.AclOwnershipAccept, record *AclRecord) error {
+	err := st.contentValidator.ValidateOwnershipAccept(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	transfer := st.ownershipTransfers[ch.TransferRecordId]
+	ownerPermissions := AclPermissions(aclrecordproto.AclUserPermissions_Owner)
+	newOwnerKey := mapKeyFromPubKey(transfer.NewOwnerIdentity)
+	newOwnerState := st.accountStates[newOwnerKey]
+	newOwnerState.Permissions = ownerPermissions
+	newOwnerState.Role = ""
+	newOwnerState.Capabilities = st.resolveCapabilities(ownerPermissions, "")
+	st.accountStates[newOwnerKey] = newOwnerState
+	if !transfer.KeepOwnership {
+		adminPermissions := AclPermissions(aclrecordproto.AclUserPermissions_Admin)
+		ownerKey := mapKeyFromPubKey(transfer.OwnerIdentity)
+		ownerState := st.accountStates[ownerKey]
+		ownerState.Permissions = adminPermissions
+		ownerState.Capabilities = st.resolveCapabilities(adminPermissions, "")
+		st.accountStates[ownerKey] = ownerState
+	}
+	for id, other := range st.ownershipTransfers {
+		if other.NewOwnerIdentity.Equals(transfer.NewOwnerIdentity) || other.OwnerIdentity.Equals(transfer.OwnerIdentity) {
+			delete(st.ownershipTransfers, id)
+		}
+	}
+	return nil
+}
+
 func (st *AclState) applyInvite(ch *aclrecordproto.AclAccountInvite, record *AclRecord) error {
 	inviteKey, err := st.keyStore.PubKeyFromProto(ch.InviteKey)
 	if err != nil {
@@ -326,7 +570,19 @@ func (st *AclState) applyInvite(ch *aclrecordproto.AclAccountInvite, record *Acl
 	if err != nil {
 		return err
 	}
-	st.inviteKeys[record.Id] = inviteKey
+	st.invites[record.Id] = AclInvite{
+		Id:               record.Id,
+		Key:              inviteKey,
+		Type:             ch.InviteType,
+		Permissions:      AclPermissions(ch.Permissions),
+		ExpireTimestamp:  ch.ExpireTimestamp,
+		MaxUses:          ch.MaxUses,
+		EncryptedReadKey: ch.EncryptedReadKey,
+	}
+	// the acl is read by the holder of the public reader invite key
+	if ch.InviteType == aclrecordproto.AclInviteType_PublicReader && inviteKey.Equals(st.pubKey) {
+		return st.unpackAllKeys(ch.EncryptedReadKey)
+	}
 	return nil
 }
 
@@ -335,12 +591,26 @@ func (st *AclState) applyInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke,
 	if err != nil {
 		return err
 	}
-	delete(st.inviteKeys, ch.InviteRecordId)
+	delete(st.invites, ch.InviteRecordId)
+	return nil
+}
+
+func (st *AclState) applyMetadataUpdate(ch *aclrecordproto.AclAccountMetadataUpdate, record *AclRecord) error {
+	err := st.contentValidator.ValidateMetadataUpdate(ch, record.Identity)
+	if err != nil {
+		return err
+	}
+	idKey := mapKeyFromPubKey(record.Identity)
+	state := st.accountStates[idKey]
+	state.RequestMetadata = ch.Metadata
+	// the metadata is encrypted with the current metadata key
+	state.KeyRecordId = st.CurrentReadKeyId()
+	st.accountStates[idKey] = state
 	return nil
 }
 
 func (st *AclState) applyRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, record *AclRecord) error {
-	err := st.contentValidator.ValidateRequestJoin(ch, record.Identity)
+	err := st.contentValidator.ValidateRequestJoin(ch, record.Identity, record.AcceptorTimestamp)
 	if err != nil {
 		return err
 	}
@@ -349,10 +619,40 @@ func (st *AclState) applyRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, r
 		RequestIdentity: record.Identity,
 		RequestMetadata: ch.Metadata,
 		Type:            RequestTypeJoin,
+		InviteRecordId:  ch.InviteRecordId,
 	}
 	return nil
 }
 
+func (st *AclState) applyInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, record *AclRecord) error {
+	err := st.contentValidator.ValidateInviteJoin(ch, record.Identity, record.AcceptorTimestamp)
+	if err != nil {
+		return err
+	}
+	st.useInvite(ch.InviteRecordId)
+	st.accountStates[mapKeyFromPubKey(record.Identity)] = AclAccountState{
+		PubKey:          record.Identity,
+		Permissions:     AclPermissions(ch.Permissions),
+		Capabilities:    st.resolveCapabilities(AclPermissions(ch.Permissions), ""),
+		RequestMetadata: ch.Metadata,
+		KeyRecordId:     st.CurrentReadKeyId(),
+	}
+	if !st.pubKey.Equals(record.Identity) {
+		return nil
+	}
+	return st.unpackAllKeys(ch.EncryptedReadKey)
+}
+
+// useInvite counts the join made with the invite, the exhausted invites are kept in the state until they are revoked
+func (st *AclState) useInvite(inviteRecordId string) {
+	invite, exists := st.invites[inviteRecordId]
+	if !exists {
+		return
+	}
+	invite.UsedCount++
+	st.invites[inviteRecordId] = invite
+}
+
 func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccept, record *AclRecord) error {
 	err := st.contentValidator.ValidateRequestAccept(ch, record.Identity)
 	if err != nil {
@@ -363,17 +663,26 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 		return err
 	}
 	requestRecord, _ := st.requestRecords[ch.RequestRecordId]
+	st.useInvite(requestRecord.InviteRecordId)
 	st.accountStates[mapKeyFromPubKey(acceptIdentity)] = AclAccountState{
 		PubKey:          acceptIdentity,
 		Permissions:     AclPermissions(ch.Permissions),
+		Role:            ch.Role,
+		Capabilities:    st.resolveCapabilities(AclPermissions(ch.Permissions), ch.Role),
 		RequestMetadata: requestRecord.RequestMetadata,
 		KeyRecordId:     st.CurrentReadKeyId(),
 	}
-	delete(st.pendingRequests, mapKeyFromPubKey(st.requestRecords[ch.RequestRecordId].RequestIdentity))
+	st.closePendingRequest(mapKeyFromPubKey(requestRecord.RequestIdentity), record.Id)
 	if !st.pubKey.Equals(acceptIdentity) {
 		return nil
 	}
-	iterReadKey, err := st.unmarshallDecryptReadKey(ch.EncryptedReadKey, st.key.Decrypt)
+	return st.unpackAllKeys(ch.EncryptedReadKey)
+}
+
+// unpackAllKeys decrypts the current read key and then goes back through all read key changes
+// decrypting each previous read key with the next one
+func (st *AclState) unpackAllKeys(encryptedReadKey []byte) error {
+	iterReadKey, err := st.unmarshallDecryptReadKey(encryptedReadKey, st.key.Decrypt)
 	if err != nil {
 		return err
 	}
@@ -396,17 +705,20 @@ func (st *AclState) applyRequestAccept(ch *aclrecordproto.AclAccountRequestAccep
 			st.keys[recId] = aclKeys
 			break
 		}
-		model := rec.Model.(*aclrecordproto.AclData)
-		if len(model.GetAclContent()) != 1 {
+		// the read key change is always the last content of the record
+		contents := rec.Model.(*aclrecordproto.AclData).GetAclContent()
+		if len(contents) == 0 {
 			return ErrIncorrectReadKey
 		}
-		ch := model.GetAclContent()[0]
+		ch := contents[len(contents)-1]
 		var readKeyChange *aclrecordproto.AclReadKeyChange
 		switch {
 		case ch.GetReadKeyChange() != nil:
 			readKeyChange = ch.GetReadKeyChange()
 		case ch.GetAccountRemove() != nil:
 			readKeyChange = ch.GetAccountRemove().GetReadKeyChange()
+		default:
+			return ErrIncorrectReadKey
 		}
 		oldReadKey, err := st.unmarshallDecryptReadKey(readKeyChange.EncryptedOldReadKey, iterReadKey.Decrypt)
 		if err != nil {
@@ -430,11 +742,26 @@ func (st *AclState) applyRequestDecline(ch *aclrecordproto.AclAccountRequestDecl
 	if err != nil {
 		return err
 	}
-	delete(st.pendingRequests, mapKeyFromPubKey(st.requestRecords[ch.RequestRecordId].RequestIdentity))
-	delete(st.requestRecords, ch.RequestRecordId)
+	st.closePendingRequest(mapKeyFromPubKey(st.requestRecords[ch.RequestRecordId].RequestIdentity), record.Id)
 	return nil
 }
 
+// closePendingRequest closes the pending request of the identity by the record, the request itself is kept in the state
+func (st *AclState) closePendingRequest(idKey, recordId string) {
+	requestRecordId, exists := st.pendingRequests[idKey]
+	if !exists {
+		return
+	}
+	st.closedRequests[requestRecordId] = recordId
+	delete(st.pendingRequests, idKey)
+}
+
+// isPendingRequest checks that the request was not accepted or declined before
+func (st *AclState) isPendingRequest(requestRecordId string) bool {
+	request, exists := st.requestRecords[requestRecordId]
+	return exists && st.pendingRequests[mapKeyFromPubKey(request.RequestIdentity)] == requestRecordId
+}
+
 func (st *AclState) applyRequestRemove(ch *aclrecordproto.AclAccountRequestRemove, record *AclRecord) error {
 	err := st.contentValidator.ValidateRequestRemove(ch, record.Identity)
 	if err != nil {
@@ -460,7 +787,12 @@ func (st *AclState) applyAccountRemove(ch *aclrecordproto.AclAccountRemove, reco
 		}
 		idKey := mapKeyFromPubKey(identity)
 		delete(st.accountStates, idKey)
-		delete(st.pendingRequests, idKey)
+		st.closePendingRequest(idKey, record.Id)
+		for id, transfer := range st.ownershipTransfers {
+			if transfer.NewOwnerIdentity.Equals(identity) {
+				delete(st.ownershipTransfers, id)
+			}
+		}
 	}
 	return st.applyReadKeyChange(ch.ReadKeyChange, record, false)
 }
@@ -480,6 +812,25 @@ func (st *AclState) applyReadKeyChange(ch *aclrecordproto.AclReadKeyChange, reco
 	aclKeys := AclKeys{
 		MetadataPubKey: mkPubKey,
 	}
+	for _, inviteKey := range ch.InviteKeys {
+		key, err := st.keyStore.PubKeyFromProto(inviteKey.Identity)
+		if err != nil {
+			return err
+		}
+		for id, invite := range st.invites {
+			if invite.Key.Equals(key) {
+				invite.EncryptedReadKey = inviteKey.EncryptedReadKey
+				st.invites[id] = invite
+			}
+		}
+		if st.pubKey.Equals(key) {
+			res, err := st.unmarshallDecryptReadKey(inviteKey.EncryptedReadKey, st.key.Decrypt)
+			if err != nil {
+				return err
+			}
+			aclKeys.ReadKey = res
+		}
+	}
 	for _, accKey := range ch.AccountKeys {
 		identity, _ := st.keyStore.PubKeyFromProto(accKey.Identity)
 		if st.pubKey.Equals(identity) {
@@ -548,6 +899,83 @@ func (st *AclState) Permissions(identity crypto.PubKey) AclPermissions {
 	return state.Permissions
 }
 
+// Invites returns all invites which were not revoked or used up,
+// expired invites are also returned, because they can only be removed by revoking them
+func (st *AclState) Invites() (invites []AclInvite) {
+	for _, invite := range st.invites {
+		invites = append(invites, invite)
+	}
+	return
+}
+
+func (st *AclState) Invite(inviteRecordId string) (AclInvite, error) {
+	invite, exists := st.invites[inviteRecordId]
+	if !exists {
+		return AclInvite{}, ErrNoSuchInvite
+	}
+	return invite, nil
+}
+
+// IsPublicReader returns true if the account key is the key of the public reader invite which was not revoked
+func (st *AclState) IsPublicReader() bool {
+	for _, invite := range st.invites {
+		if invite.Type == aclrecordproto.AclInviteType_PublicReader && invite.Key.Equals(st.pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities returns the capabilities of the account's role
+func (st *AclState) Capabilities(identity crypto.PubKey) AclCapabilities {
+	return st.accountStates[mapKeyFromPubKey(identity)].Capabilities
+}
+
+// Role returns the custom role of the account or the built-in role corresponding to its permissions
+func (st *AclState) Role(identity crypto.PubKey) AclRole {
+	state, exists := st.accountStates[mapKeyFromPubKey(identity)]
+	if !exists {
+		return BuiltinRole(AclPermissions(aclrecordproto.AclUserPermissions_None))
+	}
+	if state.Role != "" {
+		return st.roles[state.Role]
+	}
+	return BuiltinRole(state.Permissions)
+}
+
+// Owners returns all accounts with owner permissions
+func (st *AclState) Owners() (owners []crypto.PubKey) {
+	for _, state := range st.accountStates {
+		if state.Permissions.IsOwner() {
+			owners = append(owners, state.PubKey)
+		}
+	}
+	return
+}
+
+// OwnershipTransfers returns the transfers which were offered by owners and not accepted yet
+func (st *AclState) OwnershipTransfers() (transfers []OwnershipTransferRecord) {
+	for _, transfer := range st.ownershipTransfers {
+		transfers = append(transfers, transfer)
+	}
+	return
+}
+
+// Roles returns all custom roles defined in the acl
+func (st *AclState) Roles() (roles []AclRole) {
+	for _, role := range st.roles {
+		roles = append(roles, role)
+	}
+	return
+}
+
+func (st *AclState) resolveCapabilities(permissions AclPermissions, role string) AclCapabilities {
+	if role == "" {
+		return BuiltinRole(permissions).Capabilities
+	}
+	return st.roles[role].Capabilities
+}
+
 func (st *AclState) JoinRecords() (records []RequestRecord) {
 	for _, recId := range st.pendingRequests {
 		rec := st.requestRecords[recId]
@@ -580,6 +1008,27 @@ func (st *AclState) deriveKey() (crypto.SymKey, error) {
 	return crypto.DeriveSymmetricKey(keyBytes, crypto.AnysyncSpacePath)
 }
 
+// checkReadKeyInviteRevokes checks that the invites giving the read key are revoked in the record changing the read key,
+// otherwise the holders of the invite key could read the content made after the revoke
+func (st *AclState) checkReadKeyInviteRevokes(contents []*aclrecordproto.AclContentValue) error {
+	changesReadKey := len(contents) > 0 && isReadKeyChangeContent(contents[len(contents)-1])
+	for _, ch := range contents {
+		revoke := ch.GetInviteRevoke()
+		if revoke == nil || changesReadKey {
+			continue
+		}
+		if invite, exists := st.invites[revoke.InviteRecordId]; exists && invite.HasReadKey() {
+			return ErrReadKeyInviteRevoke
+		}
+	}
+	return nil
+}
+
+// isReadKeyChangeContent returns true if the content changes the read key of the space
+func isReadKeyChangeContent(ch *aclrecordproto.AclContentValue) bool {
+	return ch.GetReadKeyChange() != nil || ch.GetAccountRemove() != nil
+}
+
 func mapKeyFromPubKey(pubKey crypto.PubKey) string {
 	return string(pubKey.Storage())
 }
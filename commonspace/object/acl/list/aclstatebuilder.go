@@ -1,7 +1,12 @@
 package list
 
 import (
+	"context"
+
+	"go.uber.org/zap"
+
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
 	"github.com/anyproto/any-sync/util/crypto"
 )
 
@@ -24,10 +29,18 @@ func (sb *aclStateBuilder) Init(id string) {
 	sb.id = id
 }
 
-func (sb *aclStateBuilder) Build(records []*AclRecord) (state *AclState, err error) {
+func (sb *aclStateBuilder) Build(records []*AclRecord, list *aclList) (state *AclState, err error) {
 	if len(records) == 0 {
 		return nil, ErrIncorrectRecordSequence
 	}
+	snapshotStorage, ok := list.storage.(liststorage.SnapshotStorage)
+	if sb.privKey != nil && ok {
+		return sb.buildWithSnapshot(records, list, snapshotStorage)
+	}
+	return sb.build(records, list)
+}
+
+func (sb *aclStateBuilder) build(records []*AclRecord, list *aclList) (state *AclState, err error) {
 	if sb.privKey != nil {
 		state, err = newAclStateWithKeys(records[0], sb.privKey)
 		if err != nil {
@@ -39,6 +52,7 @@ func (sb *aclStateBuilder) Build(records []*AclRecord) (state *AclState, err err
 			return
 		}
 	}
+	state.list = list
 	for _, rec := range records[1:] {
 		err = state.applyRecord(rec)
 		if err != nil {
@@ -49,6 +63,62 @@ func (sb *aclStateBuilder) Build(records []*AclRecord) (state *AclState, err err
 	return state, err
 }
 
+// buildWithSnapshot restores the state from the local snapshot and replays only the records after it,
+// if the snapshot is missing or doesn't match the records, then all records are replayed
+func (sb *aclStateBuilder) buildWithSnapshot(records []*AclRecord, list *aclList, snapshotStorage liststorage.SnapshotStorage) (state *AclState, err error) {
+	state, tail := sb.restoreSnapshot(records, list, snapshotStorage)
+	if state != nil {
+		for _, rec := range tail {
+			err = state.applyRecord(rec)
+			if err != nil {
+				log.Info("failed to apply records after acl snapshot", zap.String("aclId", sb.id), zap.Error(err))
+				state = nil
+				break
+			}
+		}
+	}
+	if state == nil {
+		state, err = sb.build(records, list)
+		if err != nil {
+			return
+		}
+		tail = records[1:]
+	}
+	// the snapshot is saved by the list in the background when enough records are replayed or added
+	list.snapshotStorage = snapshotStorage
+	list.recordsSinceSnapshot = len(tail)
+	return
+}
+
+func (sb *aclStateBuilder) restoreSnapshot(records []*AclRecord, list *aclList, snapshotStorage liststorage.SnapshotStorage) (state *AclState, tail []*AclRecord) {
+	ctx := context.Background()
+	data, err := snapshotStorage.StateSnapshot(ctx)
+	if err != nil {
+		if err != liststorage.ErrUnknownSnapshot {
+			log.Warn("failed to load acl snapshot", zap.String("aclId", sb.id), zap.Error(err))
+		}
+		return nil, nil
+	}
+	snapshot, err := decodeSnapshot(sb.id, data, sb.privKey)
+	if err == nil {
+		// the record ids depend on previous ids, so if the record is in the list, then all records before it are the same
+		if idx, ok := list.indexes[snapshot.RecordId]; ok {
+			state, err = newAclStateFromSnapshot(sb.id, snapshot, sb.privKey)
+			if err == nil {
+				state.list = list
+				return state, records[idx+1:]
+			}
+		} else {
+			err = ErrIncorrectSnapshot
+		}
+	}
+	log.Info("invalidating acl snapshot", zap.String("aclId", sb.id), zap.Error(err))
+	if err = snapshotStorage.SetStateSnapshot(ctx, nil); err != nil {
+		log.Warn("failed to invalidate acl snapshot", zap.String("aclId", sb.id), zap.Error(err))
+	}
+	return nil, nil
+}
+
 func (sb *aclStateBuilder) Append(state *AclState, records []*AclRecord) (err error) {
 	for _, rec := range records {
 		err = state.applyRecord(rec)
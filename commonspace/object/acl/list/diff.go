@@ -0,0 +1,168 @@
+package list
+
+import (
+	"errors"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ErrIncorrectRecordOrder = errors.New("first record is after the second one")
+
+// AclPermissionChange is the change of permissions or role of the account which stayed in the acl
+type AclPermissionChange struct {
+	Identity       crypto.PubKey
+	OldPermissions AclPermissions
+	NewPermissions AclPermissions
+	OldRole        string
+	NewRole        string
+}
+
+// AclPendingRequest is the join or remove request waiting for the decision
+type AclPendingRequest struct {
+	RecordId string
+	RequestRecord
+}
+
+// AclStateDiff describes what changed in the acl between two records
+type AclStateDiff struct {
+	AddedAccounts     []AclAccountState
+	RemovedAccounts   []AclAccountState
+	PermissionChanges []AclPermissionChange
+	// ReadKeyIds are the ids of the records which changed the read key, in the order of the records
+	ReadKeyIds []string
+	// OpenedRequests are pending at the second record and were not pending at the first one
+	OpenedRequests []AclPendingRequest
+	// ClosedRequests were pending at the first record and are not pending at the second one
+	ClosedRequests []AclPendingRequest
+}
+
+// IsEmpty returns true if nothing changed
+func (d AclStateDiff) IsEmpty() bool {
+	return len(d.AddedAccounts) == 0 &&
+		len(d.RemovedAccounts) == 0 &&
+		len(d.PermissionChanges) == 0 &&
+		len(d.ReadKeyIds) == 0 &&
+		len(d.OpenedRequests) == 0 &&
+		len(d.ClosedRequests) == 0
+}
+
+// Diff returns the changes made by the records after fromId up to toId including it,
+// it should be called while holding the read lock of the list
+func Diff(acl AclList, fromId, toId string) (diff AclStateDiff, err error) {
+	if !acl.HasHead(fromId) || !acl.HasHead(toId) {
+		err = ErrNoSuchRecord
+		return
+	}
+	isAfter, err := acl.IsAfter(toId, fromId)
+	if err != nil {
+		return
+	}
+	if !isAfter {
+		err = ErrIncorrectRecordOrder
+		return
+	}
+	state := acl.AclState()
+	fromAccounts, ok := state.statesAtRecord[fromId]
+	if !ok {
+		err = ErrNoSuchRecord
+		return
+	}
+	toAccounts, ok := state.statesAtRecord[toId]
+	if !ok {
+		err = ErrNoSuchRecord
+		return
+	}
+	diff.AddedAccounts, diff.RemovedAccounts, diff.PermissionChanges = diffAccounts(fromAccounts, toAccounts)
+
+	var (
+		// indexes contain the positions of all records, so the requests closed after toId are not pending at it
+		indexes   = map[string]int{}
+		idx       int
+		afterFrom bool
+		afterTo   bool
+	)
+	acl.Iterate(func(record *AclRecord) (IsContinue bool) {
+		indexes[record.Id] = idx
+		idx++
+		if afterFrom && !afterTo && isReadKeyChangeRecord(record) {
+			diff.ReadKeyIds = append(diff.ReadKeyIds, record.Id)
+		}
+		afterFrom = afterFrom || record.Id == fromId
+		afterTo = afterTo || record.Id == toId
+		return true
+	})
+	fromIdx, toIdx := indexes[fromId], indexes[toId]
+	isPendingAt := func(requestRecordId string, recordIdx int) bool {
+		if indexes[requestRecordId] > recordIdx {
+			return false
+		}
+		closedRecordId, isClosed := state.closedRequests[requestRecordId]
+		return !isClosed || indexes[closedRecordId] > recordIdx
+	}
+	// iterating over the records to keep the order of the requests stable
+	acl.Iterate(func(record *AclRecord) (IsContinue bool) {
+		request, exists := state.requestRecords[record.Id]
+		if !exists {
+			return record.Id != toId
+		}
+		var (
+			pendingRequest = AclPendingRequest{RecordId: record.Id, RequestRecord: request}
+			wasPending     = isPendingAt(record.Id, fromIdx)
+			isPending      = isPendingAt(record.Id, toIdx)
+		)
+		switch {
+		case isPending && !wasPending:
+			diff.OpenedRequests = append(diff.OpenedRequests, pendingRequest)
+		case wasPending && !isPending:
+			diff.ClosedRequests = append(diff.ClosedRequests, pendingRequest)
+		}
+		return record.Id != toId
+	})
+	return
+}
+
+func diffAccounts(from, to []AclAccountState) (added, removed []AclAccountState, changes []AclPermissionChange) {
+	fromMap := make(map[string]AclAccountState, len(from))
+	for _, state := range from {
+		fromMap[mapKeyFromPubKey(state.PubKey)] = state
+	}
+	toMap := make(map[string]AclAccountState, len(to))
+	for _, state := range to {
+		idKey := mapKeyFromPubKey(state.PubKey)
+		toMap[idKey] = state
+		prevState, exists := fromMap[idKey]
+		if !exists {
+			added = append(added, state)
+			continue
+		}
+		if prevState.Permissions != state.Permissions || prevState.Role != state.Role {
+			changes = append(changes, AclPermissionChange{
+				Identity:       state.PubKey,
+				OldPermissions: prevState.Permissions,
+				NewPermissions: state.Permissions,
+				OldRole:        prevState.Role,
+				NewRole:        state.Role,
+			})
+		}
+	}
+	for _, state := range from {
+		if _, exists := toMap[mapKeyFromPubKey(state.PubKey)]; !exists {
+			removed = append(removed, state)
+		}
+	}
+	return
+}
+
+func isReadKeyChangeRecord(record *AclRecord) bool {
+	aclData, ok := record.Model.(*aclrecordproto.AclData)
+	if !ok {
+		return false
+	}
+	for _, ch := range aclData.GetAclContent() {
+		if isReadKeyChangeContent(ch) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package list
+
+import (
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+type AclEventType int
+
+const (
+	AclEventTypeCreated AclEventType = iota
+	AclEventTypeInviteCreated
+	AclEventTypeInviteRevoked
+	AclEventTypeJoinRequested
+	AclEventTypeJoinAccepted
+	AclEventTypeJoinDeclined
+	AclEventTypeInviteJoined
+	AclEventTypePermissionChanged
+	AclEventTypeRemoveRequested
+	AclEventTypeMemberRemoved
+	AclEventTypeReadKeyChanged
+	AclEventTypeRoleDefined
+	AclEventTypeRoleRemoved
+	AclEventTypeOwnershipOffered
+	AclEventTypeOwnershipAccepted
+	AclEventTypeMetadataUpdated
+)
+
+var aclEventTypeNames = map[AclEventType]string{
+	AclEventTypeCreated:           "created",
+	AclEventTypeInviteCreated:     "inviteCreated",
+	AclEventTypeInviteRevoked:     "inviteRevoked",
+	AclEventTypeJoinRequested:     "joinRequested",
+	AclEventTypeJoinAccepted:      "joinAccepted",
+	AclEventTypeJoinDeclined:      "joinDeclined",
+	AclEventTypeInviteJoined:      "inviteJoined",
+	AclEventTypePermissionChanged: "permissionChanged",
+	AclEventTypeRemoveRequested:   "removeRequested",
+	AclEventTypeMemberRemoved:     "memberRemoved",
+	AclEventTypeReadKeyChanged:    "readKeyChanged",
+	AclEventTypeRoleDefined:       "roleDefined",
+	AclEventTypeRoleRemoved:       "roleRemoved",
+	AclEventTypeOwnershipOffered:  "ownershipOffered",
+	AclEventTypeOwnershipAccepted: "ownershipAccepted",
+	AclEventTypeMetadataUpdated:   "metadataUpdated",
+}
+
+func (t AclEventType) String() string {
+	if name, ok := aclEventTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// AclEvent is a single change in the acl made by some record
+type AclEvent struct {
+	Type     AclEventType
+	RecordId string
+	// Actor is the identity which made the record
+	Actor crypto.PubKey
+	// Target is the identity affected by the change, it is nil for the events not related to a specific account
+	Target            crypto.PubKey
+	Timestamp         int64
+	AcceptorTimestamp int64
+	// InviteRecordId is set for invite and join events
+	InviteRecordId string
+	// RequestRecordId is set for accepted and declined requests
+	RequestRecordId string
+	Permissions     AclPermissions
+	Role            string
+	// Metadata is the decrypted account metadata, it is nil if the account doesn't have the metadata key
+	Metadata []byte
+	// EncryptedMetadata is the account metadata as it is stored in the record
+	EncryptedMetadata []byte
+}
+
+// AclEventFilter selects the events, zero values of the fields mean no filtering
+type AclEventFilter struct {
+	// Identity selects the events where the identity is either the actor or the target
+	Identity crypto.PubKey
+	// From and To are the unix timestamps limiting the RecordTimestamp of the event (inclusive)
+	From int64
+	To   int64
+}
+
+func (f AclEventFilter) match(ev AclEvent) bool {
+	if f.Identity != nil {
+		isActor := ev.Actor != nil && ev.Actor.Equals(f.Identity)
+		isTarget := ev.Target != nil && ev.Target.Equals(f.Identity)
+		if !isActor && !isTarget {
+			return false
+		}
+	}
+	if f.From != 0 && ev.RecordTimestamp() < f.From {
+		return false
+	}
+	if f.To != 0 && ev.RecordTimestamp() > f.To {
+		return false
+	}
+	return true
+}
+
+// RecordTimestamp returns the time when the record was accepted by the consensus node,
+// if the record is not accepted yet, then the time set by its author is used
+func (ev AclEvent) RecordTimestamp() int64 {
+	if ev.AcceptorTimestamp != 0 {
+		return ev.AcceptorTimestamp
+	}
+	return ev.Timestamp
+}
+
+// History converts the records of the acl list into typed events,
+// it should be called while holding the read lock of the list
+func History(acl AclList, filter AclEventFilter) (events []AclEvent, err error) {
+	h := &historyBuilder{
+		state:        acl.AclState(),
+		keyStore:     crypto.NewKeyStorage(),
+		requests:     map[string]crypto.PubKey{},
+		currentKeyId: acl.Id(),
+		filter:       filter,
+	}
+	acl.Iterate(func(record *AclRecord) (IsContinue bool) {
+		err = h.addRecord(record)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.events, nil
+}
+
+type historyBuilder struct {
+	state    *AclState
+	keyStore crypto.KeyStorage
+	// requests is a map requestRecordId -> identity of the requester
+	requests map[string]crypto.PubKey
+	// currentKeyId is the id of the record with the read key which was current at the processed record
+	currentKeyId string
+	filter       AclEventFilter
+	events       []AclEvent
+}
+
+func (h *historyBuilder) addRecord(record *AclRecord) (err error) {
+	newEvent := func(tp AclEventType) AclEvent {
+		return AclEvent{
+			Type:              tp,
+			RecordId:          record.Id,
+			Actor:             record.Identity,
+			Timestamp:         record.Timestamp,
+			AcceptorTimestamp: record.AcceptorTimestamp,
+		}
+	}
+	if root, ok := record.Model.(*aclrecordproto.AclRoot); ok {
+		ev := newEvent(AclEventTypeCreated)
+		ev.Target = record.Identity
+		ev.Permissions = AclPermissions(aclrecordproto.AclUserPermissions_Owner)
+		h.setMetadata(&ev, root.EncryptedOwnerMetadata)
+		h.add(ev)
+		return
+	}
+	aclData, ok := record.Model.(*aclrecordproto.AclData)
+	if !ok {
+		return ErrUnexpectedContentType
+	}
+	for _, ch := range aclData.GetAclContent() {
+		switch {
+		case ch.GetInvite() != nil:
+			ev := newEvent(AclEventTypeInviteCreated)
+			ev.InviteRecordId = record.Id
+			ev.Permissions = AclPermissions(ch.GetInvite().Permissions)
+			h.add(ev)
+		case ch.GetInviteRevoke() != nil:
+			ev := newEvent(AclEventTypeInviteRevoked)
+			ev.InviteRecordId = ch.GetInviteRevoke().InviteRecordId
+			h.add(ev)
+		case ch.GetRequestJoin() != nil:
+			join := ch.GetRequestJoin()
+			ev := newEvent(AclEventTypeJoinRequested)
+			ev.Target = record.Identity
+			ev.InviteRecordId = join.InviteRecordId
+			h.setMetadata(&ev, join.Metadata)
+			h.requests[record.Id] = record.Identity
+			h.add(ev)
+		case ch.GetInviteJoin() != nil:
+			join := ch.GetInviteJoin()
+			ev := newEvent(AclEventTypeInviteJoined)
+			ev.Target = record.Identity
+			ev.InviteRecordId = join.InviteRecordId
+			ev.Permissions = AclPermissions(join.Permissions)
+			h.setMetadata(&ev, join.Metadata)
+			h.add(ev)
+		case ch.GetRequestAccept() != nil:
+			accept := ch.GetRequestAccept()
+			ev := newEvent(AclEventTypeJoinAccepted)
+			if ev.Target, err = h.keyStore.PubKeyFromProto(accept.Identity); err != nil {
+				return
+			}
+			ev.RequestRecordId = accept.RequestRecordId
+			ev.Permissions = AclPermissions(accept.Permissions)
+			ev.Role = accept.Role
+			h.add(ev)
+		case ch.GetRequestDecline() != nil:
+			decline := ch.GetRequestDecline()
+			ev := newEvent(AclEventTypeJoinDeclined)
+			ev.Target = h.requests[decline.RequestRecordId]
+			ev.RequestRecordId = decline.RequestRecordId
+			h.add(ev)
+		case ch.GetAccountRequestRemove() != nil:
+			ev := newEvent(AclEventTypeRemoveRequested)
+			ev.Target = record.Identity
+			h.requests[record.Id] = record.Identity
+			h.add(ev)
+		case ch.GetPermissionChange() != nil:
+			change := ch.GetPermissionChange()
+			ev := newEvent(AclEventTypePermissionChanged)
+			if ev.Target, err = h.keyStore.PubKeyFromProto(change.Identity); err != nil {
+				return
+			}
+			ev.Permissions = AclPermissions(change.Permissions)
+			ev.Role = change.Role
+			h.add(ev)
+		case ch.GetAccountRemove() != nil:
+			for _, rawIdentity := range ch.GetAccountRemove().Identities {
+				ev := newEvent(AclEventTypeMemberRemoved)
+				if ev.Target, err = h.keyStore.PubKeyFromProto(rawIdentity); err != nil {
+					return
+				}
+				h.add(ev)
+			}
+			h.currentKeyId = record.Id
+			h.add(newEvent(AclEventTypeReadKeyChanged))
+		case ch.GetReadKeyChange() != nil:
+			h.currentKeyId = record.Id
+			h.add(newEvent(AclEventTypeReadKeyChanged))
+		case ch.GetRoleDefine() != nil:
+			ev := newEvent(AclEventTypeRoleDefined)
+			ev.Role = ch.GetRoleDefine().GetRole().GetName()
+			h.add(ev)
+		case ch.GetRoleRemove() != nil:
+			ev := newEvent(AclEventTypeRoleRemoved)
+			ev.Role = ch.GetRoleRemove().Name
+			h.add(ev)
+		case ch.GetOwnershipTransfer() != nil:
+			ev := newEvent(AclEventTypeOwnershipOffered)
+			if ev.Target, err = h.keyStore.PubKeyFromProto(ch.GetOwnershipTransfer().Identity); err != nil {
+				return
+			}
+			ev.Permissions = AclPermissions(aclrecordproto.AclUserPermissions_Owner)
+			h.add(ev)
+		case ch.GetOwnershipAccept() != nil:
+			ev := newEvent(AclEventTypeOwnershipAccepted)
+			ev.Target = record.Identity
+			ev.RequestRecordId = ch.GetOwnershipAccept().TransferRecordId
+			ev.Permissions = AclPermissions(aclrecordproto.AclUserPermissions_Owner)
+			h.add(ev)
+		case ch.GetMetadataUpdate() != nil:
+			ev := newEvent(AclEventTypeMetadataUpdated)
+			ev.Target = record.Identity
+			h.setMetadata(&ev, ch.GetMetadataUpdate().Metadata)
+			h.add(ev)
+		default:
+			return ErrUnexpectedContentType
+		}
+	}
+	return
+}
+
+// setMetadata decrypts the metadata with the metadata key which was current at the time of the record
+func (h *historyBuilder) setMetadata(ev *AclEvent, encrypted []byte) {
+	ev.EncryptedMetadata = encrypted
+	if len(encrypted) == 0 {
+		return
+	}
+	metadataKey := h.state.keys[h.currentKeyId].MetadataPrivKey
+	if metadataKey == nil {
+		return
+	}
+	decrypted, err := metadataKey.Decrypt(encrypted)
+	if err != nil {
+		return
+	}
+	ev.Metadata = decrypted
+}
+
+func (h *historyBuilder) add(ev AclEvent) {
+	if h.filter.match(ev) {
+		h.events = append(h.events, ev)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
@@ -77,6 +78,12 @@ type aclList struct {
 	aclState      *AclState
 	storage       liststorage.ListStorage
 
+	// snapshotStorage is set if the state snapshots are saved for this list
+	snapshotStorage      liststorage.SnapshotStorage
+	recordsSinceSnapshot int
+	snapshotSaving       atomic.Bool
+	snapshotWg           sync.WaitGroup
+
 	sync.RWMutex
 }
 
@@ -159,31 +166,30 @@ func build(deps internalDeps) (list AclList, err error) {
 		indexes[records[len(records)/2].Id] = len(records) / 2
 	}
 
-	stateBuilder.Init(id)
-	state, err := stateBuilder.Build(records)
-	if err != nil {
-		return
-	}
-
 	// TODO: check if this is correct (raw model instead of unmarshalled)
 	rootWithId, err := storage.Root()
 	if err != nil {
 		return
 	}
 
-	recBuilder.(*aclRecordBuilder).state = state
-	list = &aclList{
+	acl := &aclList{
 		root:          rootWithId,
 		records:       records,
 		indexes:       indexes,
 		stateBuilder:  stateBuilder,
 		recordBuilder: recBuilder,
-		aclState:      state,
 		storage:       storage,
 		id:            id,
 	}
-	state.list = list
-	return
+	stateBuilder.Init(id)
+	state, err := stateBuilder.Build(records, acl)
+	if err != nil {
+		return
+	}
+	recBuilder.(*aclRecordBuilder).state = state
+	acl.aclState = state
+	acl.saveSnapshotIfNeeded()
+	return acl, nil
 }
 
 func (a *aclList) RecordBuilder() AclRecordBuilder {
@@ -231,6 +237,8 @@ func (a *aclList) AddRawRecord(rawRec *consensusproto.RawRecordWithId) (err erro
 	if err = a.storage.SetHead(rawRec.Id); err != nil {
 		return
 	}
+	a.recordsSinceSnapshot++
+	a.saveSnapshotIfNeeded()
 	return
 }
 
@@ -338,6 +346,7 @@ func (a *aclList) IterateFrom(startId string, iterFunc IterFunc) {
 }
 
 func (a *aclList) Close(ctx context.Context) (err error) {
+	a.snapshotWg.Wait()
 	return nil
 }
 
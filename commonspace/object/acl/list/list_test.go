@@ -1,13 +1,16 @@
 package list
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
 	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
 	"github.com/anyproto/any-sync/consensus/consensusproto"
 	"github.com/anyproto/any-sync/util/crypto"
 )
@@ -140,8 +143,8 @@ func TestAclList_InviteRevoke(t *testing.T) {
 	// checking acl state
 	require.True(t, ownerState.Permissions(ownerState.pubKey).IsOwner())
 	require.True(t, ownerState.Permissions(accountState.pubKey).NoPermissions())
-	require.Empty(t, ownerState.inviteKeys)
-	require.Empty(t, accountState.inviteKeys)
+	require.Empty(t, ownerState.invites)
+	require.Empty(t, accountState.invites)
 }
 
 func TestAclList_RequestDecline(t *testing.T) {
@@ -180,6 +183,70 @@ func TestAclList_RequestDecline(t *testing.T) {
 	require.Empty(t, accountState.pendingRequests)
 }
 
+func TestAclList_ClosedRequest(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerAcl     = fx.ownerAcl
+		ownerState   = fx.ownerAcl.aclState
+		accountAcl   = fx.accountAcl
+		accountState = fx.accountAcl.aclState
+	)
+	inv, err := ownerAcl.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+	requestJoin := func(acl AclList) string {
+		rec, err := acl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+			InviteRecordId: inviteRec.Id,
+			InviteKey:      inv.InviteKey,
+		})
+		require.NoError(t, err)
+		recWithId := WrapAclRecord(rec)
+		fx.addRec(t, recWithId)
+		return recWithId.Id
+	}
+	identity, err := accountState.pubKey.Marshall()
+	require.NoError(t, err)
+	requireClosed := func(requestId string) {
+		validator := ownerState.Validator()
+		err := validator.ValidateRequestAccept(&aclrecordproto.AclAccountRequestAccept{
+			Identity:        identity,
+			RequestRecordId: requestId,
+			Permissions:     aclrecordproto.AclUserPermissions_Writer,
+		}, ownerState.pubKey)
+		require.ErrorIs(t, err, ErrNoSuchRequest)
+		err = validator.ValidateRequestDecline(&aclrecordproto.AclAccountRequestDecline{
+			RequestRecordId: requestId,
+		}, ownerState.pubKey)
+		require.ErrorIs(t, err, ErrNoSuchRequest)
+		_, err = ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+			RequestRecordId: requestId,
+			Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+		})
+		require.ErrorIs(t, err, ErrNoSuchRequest)
+		_, err = ownerAcl.RecordBuilder().BuildRequestDecline(requestId)
+		require.ErrorIs(t, err, ErrNoSuchRequest)
+	}
+
+	// the declined request can't be accepted or declined again
+	declinedId := requestJoin(accountAcl)
+	requestDecline, err := ownerAcl.RecordBuilder().BuildRequestDecline(declinedId)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(requestDecline))
+	requireClosed(declinedId)
+
+	// the accepted request can't be accepted or declined again
+	acceptedId := requestJoin(accountAcl)
+	requestAccept, err := ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+		RequestRecordId: acceptedId,
+		Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(requestAccept))
+	requireClosed(acceptedId)
+	require.True(t, ownerState.Permissions(accountState.pubKey).CanWrite())
+}
+
 func TestAclList_Remove(t *testing.T) {
 	fx := newFixture(t)
 	var (
@@ -350,3 +417,938 @@ func TestAclList_RequestRemove(t *testing.T) {
 	require.Nil(t, accountState.keys[removeRec.Id].ReadKey)
 	require.NotEmpty(t, accountState.keys[fx.ownerAcl.Id()])
 }
+
+func TestAclList_LimitedInvite(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+	)
+	expireTimestamp := time.Now().Add(time.Hour).Unix()
+	inv, err := fx.ownerAcl.RecordBuilder().BuildLimitedInvite(InvitePayload{
+		ExpireTimestamp: expireTimestamp,
+		MaxUses:         1,
+	})
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	for _, st := range []*AclState{ownerState, accountState} {
+		invite, err := st.Invite(inviteRec.Id)
+		require.NoError(t, err)
+		require.Equal(t, expireTimestamp, invite.ExpireTimestamp)
+		remaining, limited := invite.RemainingUses()
+		require.True(t, limited)
+		require.Equal(t, uint32(1), remaining)
+	}
+
+	// the declined request doesn't use the invite
+	requestJoin, err := fx.accountAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+		Metadata:       mockMetadata,
+	})
+	require.NoError(t, err)
+	requestJoinRec := WrapAclRecord(requestJoin)
+	fx.addRec(t, requestJoinRec)
+	requestDecline, err := fx.ownerAcl.RecordBuilder().BuildRequestDecline(requestJoinRec.Id)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(requestDecline))
+	for _, st := range []*AclState{ownerState, accountState} {
+		invite, err := st.Invite(inviteRec.Id)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), invite.UsedCount)
+	}
+
+	// two requests are made with the invite which can be used once
+	otherKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	otherAcl, err := NewTestAclWithRoot(otherKeys, fx.ownerAcl.Root())
+	require.NoError(t, err)
+	requestJoin, err = fx.accountAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.NoError(t, err)
+	requestJoinRec = WrapAclRecord(requestJoin)
+	fx.addRec(t, requestJoinRec)
+	records, err := fx.ownerAcl.RecordsAfter(context.Background(), fx.ownerAcl.Id())
+	require.NoError(t, err)
+	require.NoError(t, otherAcl.AddRawRecords(records))
+	otherJoin, err := otherAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.NoError(t, err)
+	otherJoinRec := WrapAclRecord(otherJoin)
+	fx.addRec(t, otherJoinRec)
+	require.NoError(t, otherAcl.AddRawRecord(otherJoinRec))
+
+	requestAccept, err := fx.ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+		RequestRecordId: requestJoinRec.Id,
+		Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	otherAccept, err := fx.ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+		RequestRecordId: otherJoinRec.Id,
+		Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	requestAcceptRec := WrapAclRecord(requestAccept)
+	fx.addRec(t, requestAcceptRec)
+	require.NoError(t, otherAcl.AddRawRecord(requestAcceptRec))
+
+	// the used up invite is kept in the state
+	for _, st := range []*AclState{ownerState, accountState} {
+		invite, err := st.Invite(inviteRec.Id)
+		require.NoError(t, err)
+		remaining, limited := invite.RemainingUses()
+		require.True(t, limited)
+		require.Equal(t, uint32(0), remaining)
+	}
+	otherAcceptRec, err := fx.ownerAcl.recordBuilder.Unmarshall(otherAccept)
+	require.NoError(t, err)
+	acceptContent := otherAcceptRec.Model.(*aclrecordproto.AclData).AclContent[0].GetRequestAccept()
+	err = ownerState.Validator().ValidateRequestAccept(acceptContent, ownerState.pubKey)
+	require.Equal(t, ErrInviteExhausted, err)
+	_, err = fx.ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+		RequestRecordId: otherJoinRec.Id,
+		Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.Equal(t, ErrInviteExhausted, err)
+	_, err = otherAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.Equal(t, ErrInviteExhausted, err)
+}
+
+func TestAclList_ExpiredInvite(t *testing.T) {
+	fx := newFixture(t)
+	expireTimestamp := time.Now().Add(time.Hour).Unix()
+	inv, err := fx.ownerAcl.RecordBuilder().BuildLimitedInvite(InvitePayload{
+		ExpireTimestamp: expireTimestamp,
+	})
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	requestJoin, err := fx.accountAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.NoError(t, err)
+	// consensus node accepted the record after the invite expired
+	requestJoin.AcceptorTimestamp = expireTimestamp + 1
+	err = fx.ownerAcl.AddRawRecord(WrapAclRecord(requestJoin))
+	require.Equal(t, ErrInviteExpired, err)
+
+	invite, err := fx.ownerAcl.AclState().Invite(inviteRec.Id)
+	require.NoError(t, err)
+	require.True(t, invite.IsExpired(expireTimestamp+1))
+	_, limited := invite.RemainingUses()
+	require.False(t, limited)
+
+	_, err = fx.ownerAcl.RecordBuilder().BuildLimitedInvite(InvitePayload{
+		ExpireTimestamp: time.Now().Add(-time.Hour).Unix(),
+	})
+	require.Equal(t, ErrInviteExpired, err)
+}
+
+func TestAclList_InviteAnyone(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+	)
+	inv, err := fx.ownerAcl.RecordBuilder().BuildInviteAnyone(InviteAnyonePayload{
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	// changing read key after the invite was created
+	newReadKey := crypto.NewAES()
+	privKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	readKeyChange, err := fx.ownerAcl.RecordBuilder().BuildReadKeyChange(ReadKeyChangePayload{
+		MetadataKey: privKey,
+		ReadKey:     newReadKey,
+	})
+	require.NoError(t, err)
+	readKeyRec := WrapAclRecord(readKeyChange)
+	fx.addRec(t, readKeyRec)
+
+	inviteJoin, err := fx.accountAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+		Metadata:       mockMetadata,
+	})
+	require.NoError(t, err)
+	err = fx.ownerAcl.ValidateRawRecord(inviteJoin)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(inviteJoin))
+
+	// checking acl state
+	for _, st := range []*AclState{ownerState, accountState} {
+		require.True(t, st.Permissions(ownerState.pubKey).IsOwner())
+		require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer), st.Permissions(accountState.pubKey))
+		require.Empty(t, st.pendingRequests)
+	}
+	readKey, err := accountState.CurrentReadKey()
+	require.NoError(t, err)
+	require.True(t, newReadKey.Equals(readKey))
+	require.True(t, accountState.keys[fx.ownerAcl.Id()].ReadKey.Equals(ownerState.keys[fx.ownerAcl.Id()].ReadKey))
+	meta, err := ownerState.GetMetadata(accountState.pubKey, true)
+	require.NoError(t, err)
+	require.Equal(t, mockMetadata, meta)
+
+	// joining twice is not allowed
+	_, err = fx.accountAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.Equal(t, ErrAccountExists, err)
+}
+
+func TestAclList_InviteAnyoneRevoke(t *testing.T) {
+	fx := newFixture(t)
+	ownerState := fx.ownerAcl.aclState
+	inv, err := fx.ownerAcl.RecordBuilder().BuildInviteAnyone(InviteAnyonePayload{
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	// the invite can't be revoked without the read key change
+	_, err = fx.ownerAcl.RecordBuilder().BuildInviteRevoke(inviteRec.Id)
+	require.Equal(t, ErrReadKeyInviteRevoke, err)
+	builder := fx.ownerAcl.RecordBuilder().(*aclRecordBuilder)
+	plainRevokeContent, err := builder.buildInviteRevoke(inviteRec.Id)
+	require.NoError(t, err)
+	plainRevoke, err := builder.buildRecord(plainRevokeContent)
+	require.NoError(t, err)
+	require.Equal(t, ErrReadKeyInviteRevoke, fx.ownerAcl.ValidateRawRecord(plainRevoke))
+
+	// the new read key is not encrypted with the key of the revoked invite
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	revoke, err := fx.ownerAcl.RecordBuilder().BuildReadKeyInviteRevoke(inviteRec.Id, ReadKeyChangePayload{
+		MetadataKey: metadataKey,
+		ReadKey:     crypto.NewAES(),
+	})
+	require.NoError(t, err)
+	revokeRec := WrapAclRecord(revoke)
+	fx.addRec(t, revokeRec)
+	require.NotContains(t, ownerState.invites, inviteRec.Id)
+	rec, err := fx.ownerAcl.RecordBuilder().UnmarshallWithId(revokeRec)
+	require.NoError(t, err)
+	contents := rec.Model.(*aclrecordproto.AclData).AclContent
+	readKeyChange := contents[len(contents)-1].GetReadKeyChange()
+	require.NotNil(t, readKeyChange)
+	require.Empty(t, readKeyChange.InviteKeys)
+
+	// the link can't be used to join anymore
+	_, err = fx.accountAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.Equal(t, ErrNoSuchInvite, err)
+}
+
+func TestAclList_InviteAnyoneIncorrectType(t *testing.T) {
+	fx := newFixture(t)
+	inv, err := fx.ownerAcl.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	_, err = fx.accountAcl.RecordBuilder().BuildInviteJoin(InviteJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.Equal(t, ErrIncorrectInviteType, err)
+
+	_, err = fx.ownerAcl.RecordBuilder().BuildInviteAnyone(InviteAnyonePayload{
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Owner),
+	})
+	require.Equal(t, ErrInsufficientPermissions, err)
+}
+
+func TestAclList_PublicReader(t *testing.T) {
+	fx := newFixture(t)
+	ownerState := fx.ownerAcl.aclState
+	inv, err := fx.ownerAcl.RecordBuilder().BuildPublicReaderInvite()
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+
+	// the link holder reads the acl with the invite key
+	peerKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	readerAcl, err := NewTestAclWithRoot(accountdata.New(peerKey, inv.InviteKey), fx.ownerAcl.Root())
+	require.NoError(t, err)
+	addRecToReader := func(rec *consensusproto.RawRecordWithId) {
+		fx.addRec(t, rec)
+		require.NoError(t, readerAcl.AddRawRecord(rec))
+	}
+	require.NoError(t, readerAcl.AddRawRecord(inviteRec))
+	readerState := readerAcl.AclState()
+	require.True(t, readerState.IsPublicReader())
+	require.True(t, readerState.Permissions(inv.InviteKey.GetPublic()).NoPermissions())
+	readKey, err := readerState.CurrentReadKey()
+	require.NoError(t, err)
+	ownerReadKey, err := ownerState.CurrentReadKey()
+	require.NoError(t, err)
+	require.True(t, ownerReadKey.Equals(readKey))
+
+	// the invite can't be used to join
+	_, err = fx.accountAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.Equal(t, ErrIncorrectInviteType, err)
+
+	// the link holder gets the new read key
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	newReadKey := crypto.NewAES()
+	readKeyChange, err := fx.ownerAcl.RecordBuilder().BuildReadKeyChange(ReadKeyChangePayload{
+		MetadataKey: metadataKey,
+		ReadKey:     newReadKey,
+	})
+	require.NoError(t, err)
+	readKeyChangeRec := WrapAclRecord(readKeyChange)
+	addRecToReader(readKeyChangeRec)
+	readKey, err = readerState.CurrentReadKey()
+	require.NoError(t, err)
+	require.True(t, newReadKey.Equals(readKey))
+
+	// the invite can't be revoked without the read key change
+	_, err = fx.ownerAcl.RecordBuilder().BuildInviteRevoke(inviteRec.Id)
+	require.Equal(t, ErrReadKeyInviteRevoke, err)
+	builder := fx.ownerAcl.RecordBuilder().(*aclRecordBuilder)
+	plainRevokeContent, err := builder.buildInviteRevoke(inviteRec.Id)
+	require.NoError(t, err)
+	plainRevoke, err := builder.buildRecord(plainRevokeContent)
+	require.NoError(t, err)
+	require.Equal(t, ErrReadKeyInviteRevoke, fx.ownerAcl.ValidateRawRecord(plainRevoke))
+
+	// revoking the invite together with the read key change
+	metadataKey, _, err = crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	revoke, err := fx.ownerAcl.RecordBuilder().BuildReadKeyInviteRevoke(inviteRec.Id, ReadKeyChangePayload{
+		MetadataKey: metadataKey,
+		ReadKey:     crypto.NewAES(),
+	})
+	require.NoError(t, err)
+	addRecToReader(WrapAclRecord(revoke))
+	require.False(t, readerState.IsPublicReader())
+	readKey, err = readerState.CurrentReadKey()
+	require.NoError(t, err)
+	require.Nil(t, readKey)
+	// the previous keys are still known
+	require.True(t, newReadKey.Equals(readerState.Keys()[readKeyChangeRec.Id].ReadKey))
+}
+
+func TestAclList_CustomRoles(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+		commenter    = AclRole{
+			Name: "commenter",
+			Capabilities: NewAclCapabilities(
+				aclrecordproto.AclCapability_CapabilityRead,
+				aclrecordproto.AclCapability_CapabilityComment),
+		}
+	)
+	_, err := fx.ownerAcl.RecordBuilder().BuildRoleDefine(AclRole{Name: "writer"})
+	require.Equal(t, ErrIncorrectRole, err)
+	roleDefine, err := fx.ownerAcl.RecordBuilder().BuildRoleDefine(commenter)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(roleDefine))
+	require.Equal(t, []AclRole{commenter}, accountState.Roles())
+
+	// accepting the account with the custom role
+	inv, err := fx.ownerAcl.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	fx.addRec(t, inviteRec)
+	requestJoin, err := fx.accountAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.NoError(t, err)
+	requestJoinRec := WrapAclRecord(requestJoin)
+	fx.addRec(t, requestJoinRec)
+	requestAccept, err := fx.ownerAcl.RecordBuilder().BuildRequestAccept(RequestAcceptPayload{
+		RequestRecordId: requestJoinRec.Id,
+		Role:            commenter.Name,
+	})
+	require.NoError(t, err)
+	requestAcceptRec := WrapAclRecord(requestAccept)
+	fx.addRec(t, requestAcceptRec)
+
+	for _, st := range []*AclState{ownerState, accountState} {
+		require.Equal(t, commenter, st.Role(accountState.pubKey))
+		require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Reader), st.Permissions(accountState.pubKey))
+		caps := st.Capabilities(accountState.pubKey)
+		require.True(t, caps.Has(aclrecordproto.AclCapability_CapabilityComment))
+		require.False(t, caps.Has(aclrecordproto.AclCapability_CapabilityWrite))
+		require.Equal(t, "owner", st.Role(ownerState.pubKey).Name)
+	}
+	stateAtRec, err := ownerState.StateAtRecord(requestAcceptRec.Id, accountState.pubKey)
+	require.NoError(t, err)
+	require.Equal(t, commenter.Name, stateAtRec.Role)
+	require.Equal(t, commenter.Capabilities, stateAtRec.Capabilities)
+
+	// the commenter can't manage accounts
+	_, err = fx.accountAcl.RecordBuilder().BuildInvite()
+	require.Equal(t, ErrInsufficientPermissions, err)
+
+	// redefining the role changes capabilities of its accounts
+	editor := AclRole{Name: commenter.Name, Capabilities: commenter.Capabilities | NewAclCapabilities(aclrecordproto.AclCapability_CapabilityWrite)}
+	roleDefine, err = fx.ownerAcl.RecordBuilder().BuildRoleDefine(editor)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(roleDefine))
+	require.True(t, accountState.Capabilities(accountState.pubKey).Has(aclrecordproto.AclCapability_CapabilityWrite))
+
+	// the role which is in use can't be removed
+	roleRemove, err := fx.ownerAcl.RecordBuilder().BuildRoleRemove(commenter.Name)
+	require.NoError(t, err)
+	require.Equal(t, ErrRoleInUse, fx.ownerAcl.ValidateRawRecord(roleRemove))
+
+	permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    accountState.pubKey,
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(permissionChange))
+	require.Equal(t, "reader", accountState.Role(accountState.pubKey).Name)
+
+	roleRemove, err = fx.ownerAcl.RecordBuilder().BuildRoleRemove(commenter.Name)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(roleRemove))
+	require.Empty(t, ownerState.Roles())
+
+	// the roles which can manage the space fall back to admin
+	moderator := AclRole{Name: "moderator", Capabilities: readerCapabilities | NewAclCapabilities(aclrecordproto.AclCapability_CapabilityAcceptRequests)}
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Admin), moderator.fallbackPermissions())
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer), editor.fallbackPermissions())
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Reader), commenter.fallbackPermissions())
+}
+
+func TestAclList_OwnershipTransfer(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+	)
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer))
+
+	_, err := fx.accountAcl.RecordBuilder().BuildOwnershipTransfer(OwnershipTransferPayload{
+		Identity: ownerState.pubKey,
+	})
+	require.Equal(t, ErrInsufficientPermissions, err)
+	transfer, err := fx.ownerAcl.RecordBuilder().BuildOwnershipTransfer(OwnershipTransferPayload{
+		Identity: accountState.pubKey,
+	})
+	require.NoError(t, err)
+	transferRec := WrapAclRecord(transfer)
+	fx.addRec(t, transferRec)
+	require.Len(t, accountState.OwnershipTransfers(), 1)
+
+	// only the new owner can accept the transfer
+	_, err = fx.ownerAcl.RecordBuilder().BuildOwnershipAccept(transferRec.Id)
+	require.Equal(t, ErrIncorrectIdentity, err)
+	accept, err := fx.accountAcl.RecordBuilder().BuildOwnershipAccept(transferRec.Id)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(accept))
+
+	for _, st := range []*AclState{ownerState, accountState} {
+		require.True(t, st.Permissions(accountState.pubKey).IsOwner())
+		require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Admin), st.Permissions(ownerState.pubKey))
+		require.Len(t, st.Owners(), 1)
+		require.Empty(t, st.OwnershipTransfers())
+	}
+
+	// the previous owner can't change permissions of the new owner
+	permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    accountState.pubKey,
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ErrIsOwner, fx.ownerAcl.ValidateRawRecord(permissionChange))
+}
+
+func TestAclList_MultipleOwners(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+	)
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Admin))
+
+	transfer, err := fx.ownerAcl.RecordBuilder().BuildOwnershipTransfer(OwnershipTransferPayload{
+		Identity:      accountState.pubKey,
+		KeepOwnership: true,
+	})
+	require.NoError(t, err)
+	transferRec := WrapAclRecord(transfer)
+	fx.addRec(t, transferRec)
+	accept, err := fx.accountAcl.RecordBuilder().BuildOwnershipAccept(transferRec.Id)
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(accept))
+
+	for _, st := range []*AclState{ownerState, accountState} {
+		require.True(t, st.Permissions(accountState.pubKey).IsOwner())
+		require.True(t, st.Permissions(ownerState.pubKey).IsOwner())
+		require.Len(t, st.Owners(), 2)
+	}
+	// owners can't remove each other
+	_, err = fx.accountAcl.RecordBuilder().BuildAccountRemove(AccountRemovePayload{
+		Identities: []crypto.PubKey{ownerState.pubKey},
+	})
+	require.Equal(t, ErrInsufficientPermissions, err)
+}
+
+func TestAclList_StateSnapshot(t *testing.T) {
+	fx := newFixture(t)
+	prevThreshold := snapshotThreshold
+	snapshotThreshold = 3
+	defer func() {
+		snapshotThreshold = prevThreshold
+	}()
+	// only the rebuilt lists save the snapshots to the storage
+	fx.accountAcl.snapshotStorage = nil
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Admin))
+	readKeyChange, err := fx.ownerAcl.RecordBuilder().BuildReadKeyChange(ReadKeyChangePayload{
+		MetadataKey: mustNewPrivKey(t),
+		ReadKey:     crypto.NewAES(),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(readKeyChange))
+
+	snapshotStorage := fx.accountAcl.storage.(liststorage.SnapshotStorage)
+	_, err = snapshotStorage.StateSnapshot(context.Background())
+	require.Equal(t, liststorage.ErrUnknownSnapshot, err)
+
+	// the first build replays all records and saves the snapshot
+	rebuilt, err := BuildAclListWithIdentity(fx.accountKeys, fx.accountAcl.storage, NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+	requireEqualStates(t, fx.accountAcl.aclState, rebuilt.AclState())
+	// the snapshot is saved in the background
+	require.NoError(t, rebuilt.Close(context.Background()))
+	data, err := snapshotStorage.StateSnapshot(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+	snapshot, err := decodeSnapshot(fx.accountAcl.Id(), data, fx.accountKeys.SignKey)
+	require.NoError(t, err)
+	require.Equal(t, fx.accountAcl.Head().Id, snapshot.RecordId)
+
+	// the snapshot of another account can't be used
+	_, err = decodeSnapshot(fx.accountAcl.Id(), data, fx.ownerKeys.SignKey)
+	require.Equal(t, ErrInvalidSignature, err)
+
+	// adding a record after the snapshot and building from the snapshot
+	permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    fx.accountKeys.SignKey.GetPublic(),
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(permissionChange))
+	rebuilt, err = BuildAclListWithIdentity(fx.accountKeys, fx.accountAcl.storage, NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+	requireEqualStates(t, fx.accountAcl.aclState, rebuilt.AclState())
+	require.NoError(t, rebuilt.Close(context.Background()))
+	require.Equal(t, snapshot.RecordId, mustDecodeSnapshot(t, fx).RecordId)
+
+	// only the changed accounts are stored for the record
+	recordStates := snapshot.StatesAtRecord
+	require.Equal(t, snapshot.RecordId, recordStates[len(recordStates)-1].RecordId)
+	require.Empty(t, recordStates[len(recordStates)-1].Accounts)
+	require.Empty(t, recordStates[len(recordStates)-1].RemovedIdentities)
+
+	// the snapshot is saved when enough records are added to the list
+	rebuilt, err = BuildAclListWithIdentity(fx.accountKeys, fx.accountAcl.storage, NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+	for _, permissions := range []aclrecordproto.AclUserPermissions{aclrecordproto.AclUserPermissions_Reader, aclrecordproto.AclUserPermissions_Writer} {
+		permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+			Identity:    fx.accountKeys.SignKey.GetPublic(),
+			Permissions: AclPermissions(permissions),
+		})
+		require.NoError(t, err)
+		permissionChangeRec := WrapAclRecord(permissionChange)
+		fx.addRec(t, permissionChangeRec)
+		require.NoError(t, rebuilt.AddRawRecord(permissionChangeRec))
+	}
+	require.NoError(t, rebuilt.Close(context.Background()))
+	require.Equal(t, fx.accountAcl.Head().Id, mustDecodeSnapshot(t, fx).RecordId)
+	snapshot = mustDecodeSnapshot(t, fx)
+
+	// the snapshot at the record which is not in the list is invalidated
+	snapshot.RecordId = "otherId"
+	data, err = encodeSnapshot(fx.accountAcl.Id(), snapshot, fx.accountKeys.SignKey)
+	require.NoError(t, err)
+	require.NoError(t, snapshotStorage.SetStateSnapshot(context.Background(), data))
+	rebuilt, err = BuildAclListWithIdentity(fx.accountKeys, fx.accountAcl.storage, NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+	requireEqualStates(t, fx.accountAcl.aclState, rebuilt.AclState())
+	require.NoError(t, rebuilt.Close(context.Background()))
+	// all records were replayed, so the new snapshot is saved at the head
+	require.Equal(t, fx.accountAcl.Head().Id, mustDecodeSnapshot(t, fx).RecordId)
+}
+
+func TestAclList_BatchRequest(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+	)
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer))
+
+	// another account is requesting to join
+	joinerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	joinerAcl, err := NewTestAclWithRoot(joinerKeys, fx.ownerAcl.Root())
+	require.NoError(t, err)
+	records, err := fx.ownerAcl.RecordsAfter(context.Background(), fx.ownerAcl.Id())
+	require.NoError(t, err)
+	require.NoError(t, joinerAcl.AddRawRecords(records))
+	addRec := func(rec *consensusproto.RawRecordWithId) {
+		fx.addRec(t, rec)
+		require.NoError(t, joinerAcl.AddRawRecord(rec))
+	}
+	inv, err := fx.ownerAcl.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	inviteRec := WrapAclRecord(inv.InviteRec)
+	addRec(inviteRec)
+	requestJoin, err := joinerAcl.RecordBuilder().BuildRequestJoin(RequestJoinPayload{
+		InviteRecordId: inviteRec.Id,
+		InviteKey:      inv.InviteKey,
+		Metadata:       mockMetadata,
+	})
+	require.NoError(t, err)
+	requestJoinRec := WrapAclRecord(requestJoin)
+	addRec(requestJoinRec)
+
+	// the batch fails as a whole if any of the changes is incorrect
+	unknownKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	incorrectBatch, err := fx.ownerAcl.RecordBuilder().BuildBatchRequest(BatchRequestPayload{
+		Approvals: []RequestAcceptPayload{{
+			RequestRecordId: requestJoinRec.Id,
+			Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+		}},
+		Changes: []PermissionChangePayload{{
+			Identity:    unknownKeys.SignKey.GetPublic(),
+			Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ErrNoSuchAccount, fx.ownerAcl.ValidateRawRecord(incorrectBatch))
+	require.Equal(t, ErrNoSuchAccount, fx.ownerAcl.AddRawRecord(WrapAclRecord(incorrectBatch)))
+	require.Equal(t, requestJoinRec.Id, ownerState.lastRecordId)
+	require.True(t, ownerState.Permissions(joinerKeys.SignKey.GetPublic()).NoPermissions())
+	require.Len(t, ownerState.pendingRequests, 1)
+
+	// accepting the request and removing the account with one read key change
+	newReadKey := crypto.NewAES()
+	privKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	batch, err := fx.ownerAcl.RecordBuilder().BuildBatchRequest(BatchRequestPayload{
+		Approvals: []RequestAcceptPayload{{
+			RequestRecordId: requestJoinRec.Id,
+			Permissions:     AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+		}},
+		Removals: []crypto.PubKey{fx.accountKeys.SignKey.GetPublic()},
+		ReadKeyChange: &ReadKeyChangePayload{
+			MetadataKey: privKey,
+			ReadKey:     newReadKey,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, fx.ownerAcl.ValidateRawRecord(batch))
+	batchRec := WrapAclRecord(batch)
+	addRec(batchRec)
+
+	joinerState := joinerAcl.AclState()
+	for _, st := range []*AclState{ownerState, joinerState} {
+		require.Equal(t, batchRec.Id, st.CurrentReadKeyId())
+		require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Reader), st.Permissions(joinerState.pubKey))
+		require.True(t, st.Permissions(accountState.pubKey).NoPermissions())
+		require.Len(t, st.pendingRequests, 0)
+		require.True(t, st.keys[batchRec.Id].ReadKey.Equals(newReadKey))
+		require.True(t, st.keys[batchRec.Id].MetadataPrivKey.Equals(privKey))
+		// the previous keys are available to the joined account as well
+		require.NotNil(t, st.keys[fx.ownerAcl.Id()].ReadKey)
+	}
+	require.Nil(t, accountState.keys[batchRec.Id].ReadKey)
+	rebuiltAcl, err := BuildAclListWithIdentity(joinerKeys, joinerAcl.(*aclList).storage, NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+	require.True(t, rebuiltAcl.AclState().keys[batchRec.Id].ReadKey.Equals(newReadKey))
+	require.NotNil(t, rebuiltAcl.AclState().keys[fx.ownerAcl.Id()].ReadKey)
+
+	// the read key change should be the last change of the batch
+	rkChange, err := fx.ownerAcl.recordBuilder.(*aclRecordBuilder).buildReadKeyChange(ReadKeyChangePayload{
+		MetadataKey: privKey,
+		ReadKey:     crypto.NewAES(),
+	}, nil, nil)
+	require.NoError(t, err)
+	incorrectOrder, err := fx.ownerAcl.recordBuilder.(*aclRecordBuilder).buildRecord(
+		&aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_ReadKeyChange{ReadKeyChange: rkChange}},
+		&aclrecordproto.AclContentValue{Value: &aclrecordproto.AclContentValue_RoleRemove{RoleRemove: &aclrecordproto.AclRoleRemove{Name: "role"}}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, ErrIncorrectBatch, fx.ownerAcl.ValidateRawRecord(incorrectOrder))
+}
+
+func TestAclList_MetadataUpdate(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		ownerState   = fx.ownerAcl.aclState
+		accountState = fx.accountAcl.aclState
+		newMetadata  = []byte("new metadata")
+	)
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer))
+	prevRecordId := ownerState.lastRecordId
+
+	update, err := fx.accountAcl.RecordBuilder().BuildMetadataUpdate(newMetadata)
+	require.NoError(t, err)
+	updateRec := WrapAclRecord(update)
+	fx.addRec(t, updateRec)
+
+	for _, st := range []*AclState{ownerState, accountState} {
+		meta, err := st.GetMetadata(accountState.pubKey, true)
+		require.NoError(t, err)
+		require.Equal(t, newMetadata, meta)
+		// the previous metadata stays in the state at the previous record
+		prevState, err := st.StateAtRecord(prevRecordId, accountState.pubKey)
+		require.NoError(t, err)
+		meta, err = st.keys[prevState.KeyRecordId].MetadataPrivKey.Decrypt(prevState.RequestMetadata)
+		require.NoError(t, err)
+		require.Equal(t, mockMetadata, meta)
+	}
+
+	// the owner can't change the metadata of another account
+	protoIdentity, err := accountState.pubKey.Marshall()
+	require.NoError(t, err)
+	ownerUpdate, err := fx.ownerAcl.recordBuilder.(*aclRecordBuilder).buildRecord(&aclrecordproto.AclContentValue{
+		Value: &aclrecordproto.AclContentValue_MetadataUpdate{MetadataUpdate: &aclrecordproto.AclAccountMetadataUpdate{
+			Identity: protoIdentity,
+			Metadata: newMetadata,
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ErrIncorrectIdentity, fx.ownerAcl.ValidateRawRecord(ownerUpdate))
+
+	// the history contains all values of the metadata
+	events, err := History(fx.ownerAcl, AclEventFilter{Identity: accountState.pubKey})
+	require.NoError(t, err)
+	var metadataHistory [][]byte
+	for _, ev := range events {
+		if ev.Metadata != nil {
+			metadataHistory = append(metadataHistory, ev.Metadata)
+		}
+	}
+	require.Equal(t, [][]byte{mockMetadata, newMetadata}, metadataHistory)
+	require.Equal(t, AclEventTypeMetadataUpdated, events[len(events)-1].Type)
+}
+
+func TestAclList_Diff(t *testing.T) {
+	fx := newFixture(t)
+	var (
+		rootId     = fx.ownerAcl.Id()
+		accountKey = fx.accountKeys.SignKey.GetPublic()
+	)
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer))
+	requestJoinId := fx.ownerAcl.records[2].Id
+	acceptId := fx.ownerAcl.Head().Id
+
+	diff, err := Diff(fx.ownerAcl, rootId, requestJoinId)
+	require.NoError(t, err)
+	require.Empty(t, diff.AddedAccounts)
+	require.Len(t, diff.OpenedRequests, 1)
+	require.Equal(t, requestJoinId, diff.OpenedRequests[0].RecordId)
+	require.True(t, diff.OpenedRequests[0].RequestIdentity.Equals(accountKey))
+
+	diff, err = Diff(fx.ownerAcl, requestJoinId, acceptId)
+	require.NoError(t, err)
+	require.Len(t, diff.AddedAccounts, 1)
+	require.True(t, diff.AddedAccounts[0].PubKey.Equals(accountKey))
+	require.Empty(t, diff.OpenedRequests)
+	require.Len(t, diff.ClosedRequests, 1)
+	require.Equal(t, requestJoinId, diff.ClosedRequests[0].RecordId)
+
+	// changing permissions and requesting to leave
+	permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    accountKey,
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(permissionChange))
+	requestRemove, err := fx.accountAcl.RecordBuilder().BuildRequestRemove()
+	require.NoError(t, err)
+	requestRemoveRec := WrapAclRecord(requestRemove)
+	fx.addRec(t, requestRemoveRec)
+
+	diff, err = Diff(fx.ownerAcl, acceptId, requestRemoveRec.Id)
+	require.NoError(t, err)
+	require.Len(t, diff.PermissionChanges, 1)
+	require.True(t, diff.PermissionChanges[0].Identity.Equals(accountKey))
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer), diff.PermissionChanges[0].OldPermissions)
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Reader), diff.PermissionChanges[0].NewPermissions)
+	require.Len(t, diff.OpenedRequests, 1)
+	require.Equal(t, RequestTypeRemove, diff.OpenedRequests[0].Type)
+
+	// removing the account closes its request and changes the read key
+	privKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	remove, err := fx.ownerAcl.RecordBuilder().BuildAccountRemove(AccountRemovePayload{
+		Identities: []crypto.PubKey{accountKey},
+		Change: ReadKeyChangePayload{
+			MetadataKey: privKey,
+			ReadKey:     crypto.NewAES(),
+		},
+	})
+	require.NoError(t, err)
+	removeRec := WrapAclRecord(remove)
+	fx.addRec(t, removeRec)
+
+	diff, err = Diff(fx.ownerAcl, requestRemoveRec.Id, removeRec.Id)
+	require.NoError(t, err)
+	require.Len(t, diff.RemovedAccounts, 1)
+	require.True(t, diff.RemovedAccounts[0].PubKey.Equals(accountKey))
+	require.Equal(t, []string{removeRec.Id}, diff.ReadKeyIds)
+	require.Len(t, diff.ClosedRequests, 1)
+	require.Equal(t, requestRemoveRec.Id, diff.ClosedRequests[0].RecordId)
+
+	// the whole range
+	diff, err = Diff(fx.ownerAcl, rootId, removeRec.Id)
+	require.NoError(t, err)
+	require.Empty(t, diff.AddedAccounts)
+	require.Empty(t, diff.RemovedAccounts)
+	require.Empty(t, diff.OpenedRequests)
+	require.Empty(t, diff.ClosedRequests)
+	require.Equal(t, []string{removeRec.Id}, diff.ReadKeyIds)
+
+	diff, err = Diff(fx.ownerAcl, removeRec.Id, removeRec.Id)
+	require.NoError(t, err)
+	require.True(t, diff.IsEmpty())
+	_, err = Diff(fx.ownerAcl, removeRec.Id, rootId)
+	require.Equal(t, ErrIncorrectRecordOrder, err)
+	_, err = Diff(fx.ownerAcl, rootId, "unknown")
+	require.Equal(t, ErrNoSuchRecord, err)
+}
+
+func TestAclList_History(t *testing.T) {
+	fx := newFixture(t)
+	accountKey := fx.accountKeys.SignKey.GetPublic()
+	fx.inviteAccount(t, AclPermissions(aclrecordproto.AclUserPermissions_Writer))
+
+	permissionChange, err := fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    accountKey,
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Reader),
+	})
+	require.NoError(t, err)
+	fx.addRec(t, WrapAclRecord(permissionChange))
+
+	eventTypes := func(events []AclEvent) (types []AclEventType) {
+		for _, ev := range events {
+			types = append(types, ev.Type)
+		}
+		return
+	}
+
+	events, err := History(fx.ownerAcl, AclEventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []AclEventType{
+		AclEventTypeCreated,
+		AclEventTypeInviteCreated,
+		AclEventTypeJoinRequested,
+		AclEventTypeJoinAccepted,
+		AclEventTypePermissionChanged,
+	}, eventTypes(events))
+	require.Equal(t, []byte("metadata"), events[0].Metadata)
+	require.Equal(t, events[1].RecordId, events[2].InviteRecordId)
+	require.Equal(t, mockMetadata, events[2].Metadata)
+	require.Equal(t, events[2].RecordId, events[3].RequestRecordId)
+	require.True(t, events[3].Target.Equals(accountKey))
+	require.Equal(t, AclPermissions(aclrecordproto.AclUserPermissions_Reader), events[4].Permissions)
+
+	// filtering by identity
+	events, err = History(fx.accountAcl, AclEventFilter{Identity: accountKey})
+	require.NoError(t, err)
+	require.Equal(t, []AclEventType{
+		AclEventTypeJoinRequested,
+		AclEventTypeJoinAccepted,
+		AclEventTypePermissionChanged,
+	}, eventTypes(events))
+
+	// filtering by time
+	events, err = History(fx.ownerAcl, AclEventFilter{From: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	require.Empty(t, events)
+	events, err = History(fx.ownerAcl, AclEventFilter{To: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+
+	// the time of the consensus node is used if the record is accepted
+	permissionChange, err = fx.ownerAcl.RecordBuilder().BuildPermissionChange(PermissionChangePayload{
+		Identity:    accountKey,
+		Permissions: AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+	})
+	require.NoError(t, err)
+	permissionChange.AcceptorTimestamp = time.Now().Add(2 * time.Hour).Unix()
+	fx.addRec(t, WrapAclRecord(permissionChange))
+	events, err = History(fx.ownerAcl, AclEventFilter{From: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	require.Equal(t, []AclEventType{AclEventTypePermissionChanged}, eventTypes(events))
+	events, err = History(fx.ownerAcl, AclEventFilter{To: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+}
+
+func mustNewPrivKey(t *testing.T) crypto.PrivKey {
+	privKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	return privKey
+}
+
+func mustDecodeSnapshot(t *testing.T, fx *aclFixture) *aclrecordproto.AclStateSnapshot {
+	data, err := fx.accountAcl.storage.(liststorage.SnapshotStorage).StateSnapshot(context.Background())
+	require.NoError(t, err)
+	snapshot, err := decodeSnapshot(fx.accountAcl.Id(), data, fx.accountKeys.SignKey)
+	require.NoError(t, err)
+	return snapshot
+}
+
+func requireEqualStates(t *testing.T, expected, actual *AclState) {
+	require.Equal(t, expected.lastRecordId, actual.lastRecordId)
+	require.Equal(t, expected.readKeyChanges, actual.readKeyChanges)
+	require.Equal(t, len(expected.keys), len(actual.keys))
+	for id, keys := range expected.keys {
+		if keys.ReadKey != nil {
+			require.True(t, keys.ReadKey.Equals(actual.keys[id].ReadKey))
+		}
+	}
+	require.Equal(t, len(expected.accountStates), len(actual.accountStates))
+	for id, state := range expected.accountStates {
+		require.Equal(t, state.Permissions, actual.accountStates[id].Permissions)
+		require.Equal(t, state.Capabilities, actual.accountStates[id].Capabilities)
+	}
+	require.Equal(t, len(expected.statesAtRecord), len(actual.statesAtRecord))
+	for recId, states := range expected.statesAtRecord {
+		require.Len(t, actual.statesAtRecord[recId], len(states))
+		for _, state := range states {
+			actualState, err := actual.StateAtRecord(recId, state.PubKey)
+			require.NoError(t, err)
+			require.Equal(t, state.Permissions, actualState.Permissions)
+		}
+	}
+	require.Equal(t, len(expected.requestRecords), len(actual.requestRecords))
+	require.Equal(t, len(expected.pendingRequests), len(actual.pendingRequests))
+}
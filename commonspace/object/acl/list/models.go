@@ -1,6 +1,8 @@
 package list
 
 import (
+	"sort"
+
 	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
 	"github.com/anyproto/any-sync/util/crypto"
 )
@@ -20,11 +22,58 @@ type RequestRecord struct {
 	RequestIdentity crypto.PubKey
 	RequestMetadata []byte
 	Type            RequestType
+	// InviteRecordId is the invite used by the join request, a use of the invite is counted when the request is accepted
+	InviteRecordId string
 }
 
-type AclAccountState struct {
-	PubKey          crypto.PubKey
+type AclInvite struct {
+	Id              string
+	Key             crypto.PubKey
+	Type            aclrecordproto.AclInviteType
 	Permissions     AclPermissions
+	ExpireTimestamp int64
+	MaxUses         uint32
+	UsedCount       uint32
+	// EncryptedReadKey is the current read key encrypted with the invite key, it is set only for AnyoneCanJoin and PublicReader invites
+	EncryptedReadKey []byte
+}
+
+// HasReadKey returns true if the holder of the invite key is given the read keys of the space
+func (i AclInvite) HasReadKey() bool {
+	return i.Type == aclrecordproto.AclInviteType_AnyoneCanJoin || i.Type == aclrecordproto.AclInviteType_PublicReader
+}
+
+// IsExpired returns true if the invite can't be used at the given unix timestamp
+func (i AclInvite) IsExpired(timestamp int64) bool {
+	return i.ExpireTimestamp != 0 && timestamp > i.ExpireTimestamp
+}
+
+// RemainingUses returns the number of joins which can be made with the invite,
+// limited is false if the invite can be used any number of times
+func (i AclInvite) RemainingUses() (remaining uint32, limited bool) {
+	if i.MaxUses == 0 {
+		return 0, false
+	}
+	if i.UsedCount >= i.MaxUses {
+		return 0, true
+	}
+	return i.MaxUses - i.UsedCount, true
+}
+
+type OwnershipTransferRecord struct {
+	RecordId         string
+	OwnerIdentity    crypto.PubKey
+	NewOwnerIdentity crypto.PubKey
+	KeepOwnership    bool
+}
+
+type AclAccountState struct {
+	PubKey      crypto.PubKey
+	Permissions AclPermissions
+	// Role is the name of the custom role of the account, empty if the account has a built-in role defined by Permissions
+	Role string
+	// Capabilities are the resolved capabilities of the account's role
+	Capabilities    AclCapabilities
 	RequestMetadata []byte
 	KeyRecordId     string
 }
@@ -69,3 +118,89 @@ func (p AclPermissions) CanManageAccounts() bool {
 		return false
 	}
 }
+
+// AclCapabilities is a set of capabilities stored as a bit mask
+type AclCapabilities uint64
+
+func NewAclCapabilities(capabilities ...aclrecordproto.AclCapability) (res AclCapabilities) {
+	for _, capability := range capabilities {
+		res |= 1 << uint64(capability)
+	}
+	return
+}
+
+func (c AclCapabilities) Has(capability aclrecordproto.AclCapability) bool {
+	return c&(1<<uint64(capability)) != 0
+}
+
+// Contains returns true if all capabilities from other are also in c
+func (c AclCapabilities) Contains(other AclCapabilities) bool {
+	return c&other == other
+}
+
+func (c AclCapabilities) List() (capabilities []aclrecordproto.AclCapability) {
+	for capability := range aclrecordproto.AclCapability_name {
+		if capability != int32(aclrecordproto.AclCapability_CapabilityNone) && c.Has(aclrecordproto.AclCapability(capability)) {
+			capabilities = append(capabilities, aclrecordproto.AclCapability(capability))
+		}
+	}
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i] < capabilities[j]
+	})
+	return
+}
+
+type AclRole struct {
+	Name         string
+	Capabilities AclCapabilities
+}
+
+var (
+	readerCapabilities = NewAclCapabilities(
+		aclrecordproto.AclCapability_CapabilityRead)
+	writerCapabilities = readerCapabilities | NewAclCapabilities(
+		aclrecordproto.AclCapability_CapabilityWrite,
+		aclrecordproto.AclCapability_CapabilityComment)
+	adminCapabilities = writerCapabilities | NewAclCapabilities(
+		aclrecordproto.AclCapability_CapabilityInvite,
+		aclrecordproto.AclCapability_CapabilityAcceptRequests,
+		aclrecordproto.AclCapability_CapabilityRemoveAccounts,
+		aclrecordproto.AclCapability_CapabilityChangePermissions,
+		aclrecordproto.AclCapability_CapabilityChangeReadKey,
+		aclrecordproto.AclCapability_CapabilityManageRoles)
+)
+
+// builtinRoles map the permissions of the accounts without custom roles to capabilities
+var builtinRoles = map[AclPermissions]AclRole{
+	AclPermissions(aclrecordproto.AclUserPermissions_None):   {Name: "none"},
+	AclPermissions(aclrecordproto.AclUserPermissions_Reader): {Name: "reader", Capabilities: readerCapabilities},
+	AclPermissions(aclrecordproto.AclUserPermissions_Writer): {Name: "writer", Capabilities: writerCapabilities},
+	AclPermissions(aclrecordproto.AclUserPermissions_Admin):  {Name: "admin", Capabilities: adminCapabilities},
+	AclPermissions(aclrecordproto.AclUserPermissions_Owner):  {Name: "owner", Capabilities: adminCapabilities},
+}
+
+// BuiltinRole returns the role corresponding to the permissions
+func BuiltinRole(permissions AclPermissions) AclRole {
+	return builtinRoles[permissions]
+}
+
+func isBuiltinRoleName(name string) bool {
+	for _, role := range builtinRoles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fallbackPermissions returns the built-in permissions which are used for the role by clients not supporting custom roles,
+// the role with any of the capabilities to manage the space is mapped to admin, so such clients don't reject its records
+func (r AclRole) fallbackPermissions() AclPermissions {
+	if r.Capabilities&(adminCapabilities&^writerCapabilities) != 0 {
+		return AclPermissions(aclrecordproto.AclUserPermissions_Admin)
+	}
+	if r.Capabilities.Has(aclrecordproto.AclCapability_CapabilityWrite) {
+		return AclPermissions(aclrecordproto.AclUserPermissions_Writer)
+	}
+	return AclPermissions(aclrecordproto.AclUserPermissions_Reader)
+}
@@ -0,0 +1,444 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	ErrIncorrectSnapshot = errors.New("incorrect snapshot")
+)
+
+// snapshotThreshold is the number of records applied after the last snapshot after which a new snapshot is saved
+var snapshotThreshold = 100
+
+// saveSnapshotIfNeeded saves the snapshot of the state in the background after snapshotThreshold records were applied,
+// the state is copied to the snapshot under the lock of the list, so only encrypting and writing is done in the background
+func (a *aclList) saveSnapshotIfNeeded() {
+	if a.snapshotStorage == nil || a.recordsSinceSnapshot < snapshotThreshold || a.snapshotSaving.Load() {
+		return
+	}
+	snapshot, err := a.aclState.marshallSnapshot()
+	if err != nil {
+		log.Warn("failed to make acl snapshot", zap.String("aclId", a.id), zap.Error(err))
+		return
+	}
+	a.recordsSinceSnapshot = 0
+	a.snapshotSaving.Store(true)
+	a.snapshotWg.Add(1)
+	go func() {
+		defer a.snapshotWg.Done()
+		defer a.snapshotSaving.Store(false)
+		data, err := encodeSnapshot(a.id, snapshot, a.stateBuilder.privKey)
+		if err == nil {
+			err = a.snapshotStorage.SetStateSnapshot(context.Background(), data)
+		}
+		if err != nil {
+			log.Warn("failed to save acl snapshot", zap.String("aclId", a.id), zap.Error(err))
+		}
+	}()
+}
+
+func (st *AclState) marshallSnapshot() (snapshot *aclrecordproto.AclStateSnapshot, err error) {
+	snapshot = &aclrecordproto.AclStateSnapshot{
+		RecordId:       st.lastRecordId,
+		ReadKeyChanges: append([]string(nil), st.readKeyChanges...),
+	}
+	for recId, keys := range st.keys {
+		protoKeys := &aclrecordproto.AclSnapshotKeys{RecordId: recId}
+		if keys.ReadKey != nil {
+			if protoKeys.ReadKey, err = keys.ReadKey.Marshall(); err != nil {
+				return
+			}
+		}
+		if keys.MetadataPrivKey != nil {
+			if protoKeys.MetadataPrivKey, err = keys.MetadataPrivKey.Marshall(); err != nil {
+				return
+			}
+		}
+		if keys.MetadataPubKey != nil {
+			if protoKeys.MetadataPubKey, err = keys.MetadataPubKey.Marshall(); err != nil {
+				return
+			}
+		}
+		snapshot.Keys = append(snapshot.Keys, protoKeys)
+	}
+	for _, state := range st.accountStates {
+		protoState, err := marshallAccountState(state)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Accounts = append(snapshot.Accounts, protoState)
+	}
+	recordStates, err := st.marshallStatesAtRecord()
+	if err != nil {
+		return nil, err
+	}
+	snapshot.StatesAtRecord = recordStates
+	for recId, invite := range st.invites {
+		inviteKey, err := invite.Key.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Invites = append(snapshot.Invites, &aclrecordproto.AclSnapshotInvite{
+			RecordId:         recId,
+			InviteKey:        inviteKey,
+			InviteType:       invite.Type,
+			Permissions:      aclrecordproto.AclUserPermissions(invite.Permissions),
+			ExpireTimestamp:  invite.ExpireTimestamp,
+			MaxUses:          invite.MaxUses,
+			UsedCount:        invite.UsedCount,
+			EncryptedReadKey: invite.EncryptedReadKey,
+		})
+	}
+	pending := make(map[string]struct{}, len(st.pendingRequests))
+	for _, recId := range st.pendingRequests {
+		pending[recId] = struct{}{}
+	}
+	for recId, request := range st.requestRecords {
+		identity, err := request.RequestIdentity.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		_, isPending := pending[recId]
+		snapshot.Requests = append(snapshot.Requests, &aclrecordproto.AclSnapshotRequest{
+			RecordId:       recId,
+			Identity:       identity,
+			Metadata:       request.RequestMetadata,
+			Type:           int32(request.Type),
+			Pending:        isPending,
+			InviteRecordId: request.InviteRecordId,
+			ClosedRecordId: st.closedRequests[recId],
+		})
+	}
+	for _, role := range st.roles {
+		snapshot.Roles = append(snapshot.Roles, &aclrecordproto.AclRole{
+			Name:         role.Name,
+			Capabilities: role.Capabilities.List(),
+		})
+	}
+	for recId, transfer := range st.ownershipTransfers {
+		owner, err := transfer.OwnerIdentity.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		newOwner, err := transfer.NewOwnerIdentity.Marshall()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.OwnershipTransfers = append(snapshot.OwnershipTransfers, &aclrecordproto.AclSnapshotOwnershipTransfer{
+			RecordId:         recId,
+			OwnerIdentity:    owner,
+			NewOwnerIdentity: newOwner,
+			KeepOwnership:    transfer.KeepOwnership,
+		})
+	}
+	return
+}
+
+// marshallStatesAtRecord keeps only the accounts changed by each record, because most records change few accounts
+func (st *AclState) marshallStatesAtRecord() (recordStates []*aclrecordproto.AclSnapshotRecordState, err error) {
+	prevStates := map[string]AclAccountState{}
+	for _, rec := range st.list.Records() {
+		states, ok := st.statesAtRecord[rec.Id]
+		if !ok {
+			return nil, ErrIncorrectSnapshot
+		}
+		recordState := &aclrecordproto.AclSnapshotRecordState{RecordId: rec.Id}
+		curStates := make(map[string]AclAccountState, len(states))
+		for _, state := range states {
+			idKey := mapKeyFromPubKey(state.PubKey)
+			curStates[idKey] = state
+			if prevState, exists := prevStates[idKey]; exists && isSameAccountState(prevState, state) {
+				continue
+			}
+			protoState, err := marshallAccountState(state)
+			if err != nil {
+				return nil, err
+			}
+			recordState.Accounts = append(recordState.Accounts, protoState)
+		}
+		for idKey, prevState := range prevStates {
+			if _, exists := curStates[idKey]; exists {
+				continue
+			}
+			identity, err := prevState.PubKey.Marshall()
+			if err != nil {
+				return nil, err
+			}
+			recordState.RemovedIdentities = append(recordState.RemovedIdentities, identity)
+		}
+		recordStates = append(recordStates, recordState)
+		prevStates = curStates
+		if rec.Id == st.lastRecordId {
+			return
+		}
+	}
+	return nil, ErrIncorrectSnapshot
+}
+
+func (st *AclState) unmarshallStatesAtRecord(recordStates []*aclrecordproto.AclSnapshotRecordState) error {
+	curStates := map[string]AclAccountState{}
+	for _, recordState := range recordStates {
+		for _, protoState := range recordState.Accounts {
+			state, err := st.unmarshallAccountState(protoState)
+			if err != nil {
+				return err
+			}
+			curStates[mapKeyFromPubKey(state.PubKey)] = state
+		}
+		for _, protoIdentity := range recordState.RemovedIdentities {
+			identity, err := st.keyStore.PubKeyFromProto(protoIdentity)
+			if err != nil {
+				return err
+			}
+			delete(curStates, mapKeyFromPubKey(identity))
+		}
+		states := make([]AclAccountState, 0, len(curStates))
+		for _, state := range curStates {
+			states = append(states, state)
+		}
+		st.statesAtRecord[recordState.RecordId] = states
+	}
+	return nil
+}
+
+// isSameAccountState compares the states of the same account
+func isSameAccountState(a, b AclAccountState) bool {
+	return a.Permissions == b.Permissions &&
+		a.Role == b.Role &&
+		a.Capabilities == b.Capabilities &&
+		a.KeyRecordId == b.KeyRecordId &&
+		bytes.Equal(a.RequestMetadata, b.RequestMetadata)
+}
+
+func marshallAccountState(state AclAccountState) (*aclrecordproto.AclSnapshotAccount, error) {
+	identity, err := state.PubKey.Marshall()
+	if err != nil {
+		return nil, err
+	}
+	return &aclrecordproto.AclSnapshotAccount{
+		Identity:        identity,
+		Permissions:     aclrecordproto.AclUserPermissions(state.Permissions),
+		Role:            state.Role,
+		Capabilities:    uint64(state.Capabilities),
+		RequestMetadata: state.RequestMetadata,
+		KeyRecordId:     state.KeyRecordId,
+	}, nil
+}
+
+func newAclStateFromSnapshot(id string, snapshot *aclrecordproto.AclStateSnapshot, key crypto.PrivKey) (st *AclState, err error) {
+	st = &AclState{
+		id:                 id,
+		key:                key,
+		pubKey:             key.GetPublic(),
+		keys:               make(map[string]AclKeys),
+		accountStates:      make(map[string]AclAccountState),
+		statesAtRecord:     make(map[string][]AclAccountState),
+		invites:            make(map[string]AclInvite),
+		requestRecords:     make(map[string]RequestRecord),
+		pendingRequests:    make(map[string]string),
+		closedRequests:     make(map[string]string),
+		roles:              make(map[string]AclRole),
+		ownershipTransfers: make(map[string]OwnershipTransferRecord),
+		readKeyChanges:     snapshot.ReadKeyChanges,
+		lastRecordId:       snapshot.RecordId,
+		keyStore:           crypto.NewKeyStorage(),
+	}
+	st.contentValidator = &contentValidator{
+		keyStore: st.keyStore,
+		aclState: st,
+	}
+	if len(st.readKeyChanges) == 0 {
+		return nil, ErrIncorrectSnapshot
+	}
+	for _, protoKeys := range snapshot.Keys {
+		var keys AclKeys
+		if protoKeys.ReadKey != nil {
+			if keys.ReadKey, err = crypto.UnmarshallAESKeyProto(protoKeys.ReadKey); err != nil {
+				return
+			}
+		}
+		if protoKeys.MetadataPrivKey != nil {
+			if keys.MetadataPrivKey, err = crypto.UnmarshalEd25519PrivateKeyProto(protoKeys.MetadataPrivKey); err != nil {
+				return
+			}
+		}
+		if protoKeys.MetadataPubKey != nil {
+			if keys.MetadataPubKey, err = st.keyStore.PubKeyFromProto(protoKeys.MetadataPubKey); err != nil {
+				return
+			}
+		}
+		st.keys[protoKeys.RecordId] = keys
+	}
+	for _, protoState := range snapshot.Accounts {
+		state, err := st.unmarshallAccountState(protoState)
+		if err != nil {
+			return nil, err
+		}
+		st.accountStates[mapKeyFromPubKey(state.PubKey)] = state
+	}
+	if err = st.unmarshallStatesAtRecord(snapshot.StatesAtRecord); err != nil {
+		return nil, err
+	}
+	for _, protoInvite := range snapshot.Invites {
+		inviteKey, err := st.keyStore.PubKeyFromProto(protoInvite.InviteKey)
+		if err != nil {
+			return nil, err
+		}
+		st.invites[protoInvite.RecordId] = AclInvite{
+			Id:               protoInvite.RecordId,
+			Key:              inviteKey,
+			Type:             protoInvite.InviteType,
+			Permissions:      AclPermissions(protoInvite.Permissions),
+			ExpireTimestamp:  protoInvite.ExpireTimestamp,
+			MaxUses:          protoInvite.MaxUses,
+			UsedCount:        protoInvite.UsedCount,
+			EncryptedReadKey: protoInvite.EncryptedReadKey,
+		}
+	}
+	for _, protoRequest := range snapshot.Requests {
+		identity, err := st.keyStore.PubKeyFromProto(protoRequest.Identity)
+		if err != nil {
+			return nil, err
+		}
+		st.requestRecords[protoRequest.RecordId] = RequestRecord{
+			RequestIdentity: identity,
+			RequestMetadata: protoRequest.Metadata,
+			Type:            RequestType(protoRequest.Type),
+			InviteRecordId:  protoRequest.InviteRecordId,
+		}
+		if protoRequest.Pending {
+			st.pendingRequests[mapKeyFromPubKey(identity)] = protoRequest.RecordId
+		}
+		if protoRequest.ClosedRecordId != "" {
+			st.closedRequests[protoRequest.RecordId] = protoRequest.ClosedRecordId
+		}
+	}
+	for _, protoRole := range snapshot.Roles {
+		st.roles[protoRole.Name] = AclRole{
+			Name:         protoRole.Name,
+			Capabilities: NewAclCapabilities(protoRole.Capabilities...),
+		}
+	}
+	for _, protoTransfer := range snapshot.OwnershipTransfers {
+		owner, err := st.keyStore.PubKeyFromProto(protoTransfer.OwnerIdentity)
+		if err != nil {
+			return nil, err
+		}
+		newOwner, err := st.keyStore.PubKeyFromProto(protoTransfer.NewOwnerIdentity)
+		if err != nil {
+			return nil, err
+		}
+		st.ownershipTransfers[protoTransfer.RecordId] = OwnershipTransferRecord{
+			RecordId:         protoTransfer.RecordId,
+			OwnerIdentity:    owner,
+			NewOwnerIdentity: newOwner,
+			KeepOwnership:    protoTransfer.KeepOwnership,
+		}
+	}
+	return st, nil
+}
+
+func (st *AclState) unmarshallAccountState(protoState *aclrecordproto.AclSnapshotAccount) (state AclAccountState, err error) {
+	identity, err := st.keyStore.PubKeyFromProto(protoState.Identity)
+	if err != nil {
+		return
+	}
+	return AclAccountState{
+		PubKey:          identity,
+		Permissions:     AclPermissions(protoState.Permissions),
+		Role:            protoState.Role,
+		Capabilities:    AclCapabilities(protoState.Capabilities),
+		RequestMetadata: protoState.RequestMetadata,
+		KeyRecordId:     protoState.KeyRecordId,
+	}, nil
+}
+
+// encodeSnapshot encrypts the snapshot with the key derived from account key and signs it
+func encodeSnapshot(aclId string, snapshot *aclrecordproto.AclStateSnapshot, key crypto.PrivKey) ([]byte, error) {
+	marshalledSnapshot, err := snapshot.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	symKey, err := deriveSnapshotKey(key)
+	if err != nil {
+		return nil, err
+	}
+	encryptedState, err := symKey.Encrypt(marshalledSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := key.GetPublic().Marshall()
+	if err != nil {
+		return nil, err
+	}
+	payload := &aclrecordproto.AclStateSnapshotPayload{
+		AclId:          aclId,
+		RecordId:       snapshot.RecordId,
+		Identity:       identity,
+		EncryptedState: encryptedState,
+	}
+	marshalledPayload, err := payload.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := key.Sign(marshalledPayload)
+	if err != nil {
+		return nil, err
+	}
+	raw := &aclrecordproto.AclRawStateSnapshot{
+		Payload:   marshalledPayload,
+		Signature: signature,
+	}
+	return raw.Marshal()
+}
+
+// decodeSnapshot checks that the snapshot was made by the account for this acl and decrypts it
+func decodeSnapshot(aclId string, data []byte, key crypto.PrivKey) (*aclrecordproto.AclStateSnapshot, error) {
+	raw := &aclrecordproto.AclRawStateSnapshot{}
+	if err := raw.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	ok, err := key.GetPublic().Verify(raw.Payload, raw.Signature)
+	if err != nil || !ok {
+		return nil, ErrInvalidSignature
+	}
+	payload := &aclrecordproto.AclStateSnapshotPayload{}
+	if err = payload.Unmarshal(raw.Payload); err != nil {
+		return nil, err
+	}
+	if payload.AclId != aclId {
+		return nil, ErrIncorrectSnapshot
+	}
+	symKey, err := deriveSnapshotKey(key)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := symKey.Decrypt(payload.EncryptedState)
+	if err != nil {
+		return nil, ErrFailedToDecrypt
+	}
+	snapshot := &aclrecordproto.AclStateSnapshot{}
+	if err = snapshot.Unmarshal(decrypted); err != nil {
+		return nil, err
+	}
+	if snapshot.RecordId != payload.RecordId {
+		return nil, ErrIncorrectSnapshot
+	}
+	return snapshot, nil
+}
+
+func deriveSnapshotKey(key crypto.PrivKey) (crypto.SymKey, error) {
+	keyBytes, err := key.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DeriveSymmetricKey(keyBytes, crypto.AnysyncAclSnapshotPath)
+}
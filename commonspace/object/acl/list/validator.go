@@ -10,12 +10,18 @@ type ContentValidator interface {
 	ValidatePermissionChange(ch *aclrecordproto.AclAccountPermissionChange, authorIdentity crypto.PubKey) (err error)
 	ValidateInvite(ch *aclrecordproto.AclAccountInvite, authorIdentity crypto.PubKey) (err error)
 	ValidateInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke, authorIdentity crypto.PubKey) (err error)
-	ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey) (err error)
+	ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey, acceptorTimestamp int64) (err error)
+	ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey, acceptorTimestamp int64) (err error)
 	ValidateRequestAccept(ch *aclrecordproto.AclAccountRequestAccept, authorIdentity crypto.PubKey) (err error)
 	ValidateRequestDecline(ch *aclrecordproto.AclAccountRequestDecline, authorIdentity crypto.PubKey) (err error)
 	ValidateAccountRemove(ch *aclrecordproto.AclAccountRemove, authorIdentity crypto.PubKey) (err error)
 	ValidateRequestRemove(ch *aclrecordproto.AclAccountRequestRemove, authorIdentity crypto.PubKey) (err error)
 	ValidateReadKeyChange(ch *aclrecordproto.AclReadKeyChange, authorIdentity crypto.PubKey) (err error)
+	ValidateRoleDefine(ch *aclrecordproto.AclRoleDefine, authorIdentity crypto.PubKey) (err error)
+	ValidateRoleRemove(ch *aclrecordproto.AclRoleRemove, authorIdentity crypto.PubKey) (err error)
+	ValidateOwnershipTransfer(ch *aclrecordproto.AclOwnershipTransfer, authorIdentity crypto.PubKey) (err error)
+	ValidateOwnershipAccept(ch *aclrecordproto.AclOwnershipAccept, authorIdentity crypto.PubKey) (err error)
+	ValidateMetadataUpdate(ch *aclrecordproto.AclAccountMetadataUpdate, authorIdentity crypto.PubKey) (err error)
 }
 
 type contentValidator struct {
@@ -28,8 +34,15 @@ func (c *contentValidator) ValidateAclRecordContents(ch *AclRecord) (err error)
 		return ErrIncorrectRecordSequence
 	}
 	aclData := ch.Model.(*aclrecordproto.AclData)
+	if err = c.aclState.checkReadKeyInviteRevokes(aclData.AclContent); err != nil {
+		return
+	}
+	if len(aclData.AclContent) > 1 {
+		_, err = c.aclState.applyBatch(ch)
+		return
+	}
 	for _, content := range aclData.AclContent {
-		err = c.validateAclRecordContent(content, ch.Identity)
+		err = c.validateAclRecordContent(content, ch)
 		if err != nil {
 			return
 		}
@@ -37,7 +50,8 @@ func (c *contentValidator) ValidateAclRecordContents(ch *AclRecord) (err error)
 	return
 }
 
-func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclContentValue, authorIdentity crypto.PubKey) (err error) {
+func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclContentValue, record *AclRecord) (err error) {
+	authorIdentity := record.Identity
 	switch {
 	case ch.GetPermissionChange() != nil:
 		return c.ValidatePermissionChange(ch.GetPermissionChange(), authorIdentity)
@@ -46,7 +60,7 @@ func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclConten
 	case ch.GetInviteRevoke() != nil:
 		return c.ValidateInviteRevoke(ch.GetInviteRevoke(), authorIdentity)
 	case ch.GetRequestJoin() != nil:
-		return c.ValidateRequestJoin(ch.GetRequestJoin(), authorIdentity)
+		return c.ValidateRequestJoin(ch.GetRequestJoin(), authorIdentity, record.AcceptorTimestamp)
 	case ch.GetRequestAccept() != nil:
 		return c.ValidateRequestAccept(ch.GetRequestAccept(), authorIdentity)
 	case ch.GetRequestDecline() != nil:
@@ -57,50 +71,111 @@ func (c *contentValidator) validateAclRecordContent(ch *aclrecordproto.AclConten
 		return c.ValidateRequestRemove(ch.GetAccountRequestRemove(), authorIdentity)
 	case ch.GetReadKeyChange() != nil:
 		return c.ValidateReadKeyChange(ch.GetReadKeyChange(), authorIdentity)
+	case ch.GetInviteJoin() != nil:
+		return c.ValidateInviteJoin(ch.GetInviteJoin(), authorIdentity, record.AcceptorTimestamp)
+	case ch.GetRoleDefine() != nil:
+		return c.ValidateRoleDefine(ch.GetRoleDefine(), authorIdentity)
+	case ch.GetRoleRemove() != nil:
+		return c.ValidateRoleRemove(ch.GetRoleRemove(), authorIdentity)
+	case ch.GetOwnershipTransfer() != nil:
+		return c.ValidateOwnershipTransfer(ch.GetOwnershipTransfer(), authorIdentity)
+	case ch.GetOwnershipAccept() != nil:
+		return c.ValidateOwnershipAccept(ch.GetOwnershipAccept(), authorIdentity)
+	case ch.GetMetadataUpdate() != nil:
+		return c.ValidateMetadataUpdate(ch.GetMetadataUpdate(), authorIdentity)
 	default:
 		return ErrUnexpectedContentType
 	}
 }
 
 func (c *contentValidator) ValidatePermissionChange(ch *aclrecordproto.AclAccountPermissionChange, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityChangePermissions) {
 		return ErrInsufficientPermissions
 	}
 	chIdentity, err := c.keyStore.PubKeyFromProto(ch.Identity)
 	if err != nil {
 		return err
 	}
-	_, exists := c.aclState.accountStates[mapKeyFromPubKey(chIdentity)]
+	state, exists := c.aclState.accountStates[mapKeyFromPubKey(chIdentity)]
 	if !exists {
 		return ErrNoSuchAccount
 	}
-	return
+	// owners can be changed only by transferring the ownership
+	if state.Permissions.IsOwner() {
+		return ErrIsOwner
+	}
+	return c.validateGrantedRole(AclPermissions(ch.Permissions), ch.Role, authorIdentity)
 }
 
 func (c *contentValidator) ValidateInvite(ch *aclrecordproto.AclAccountInvite, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityInvite) {
 		return ErrInsufficientPermissions
 	}
 	_, err = c.keyStore.PubKeyFromProto(ch.InviteKey)
-	return
+	if err != nil {
+		return
+	}
+	switch ch.InviteType {
+	case aclrecordproto.AclInviteType_RequestToJoin:
+		return
+	case aclrecordproto.AclInviteType_AnyoneCanJoin:
+		if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
+			return ErrInsufficientPermissions
+		}
+		permissions := AclPermissions(ch.Permissions)
+		if permissions.NoPermissions() {
+			return ErrInsufficientPermissions
+		}
+		if err = c.validateGrantedRole(permissions, "", authorIdentity); err != nil {
+			return
+		}
+		if len(ch.EncryptedReadKey) == 0 {
+			return ErrIncorrectReadKey
+		}
+		return
+	case aclrecordproto.AclInviteType_PublicReader:
+		if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
+			return ErrInsufficientPermissions
+		}
+		// public reader invite can't be used to join the space
+		if !AclPermissions(ch.Permissions).NoPermissions() || ch.MaxUses != 0 || ch.ExpireTimestamp != 0 {
+			return ErrIncorrectInviteType
+		}
+		if len(ch.EncryptedReadKey) == 0 {
+			return ErrIncorrectReadKey
+		}
+		return
+	default:
+		return ErrIncorrectInviteType
+	}
 }
 
 func (c *contentValidator) ValidateInviteRevoke(ch *aclrecordproto.AclAccountInviteRevoke, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityInvite) {
 		return ErrInsufficientPermissions
 	}
-	_, exists := c.aclState.inviteKeys[ch.InviteRecordId]
+	_, exists := c.aclState.invites[ch.InviteRecordId]
 	if !exists {
 		return ErrNoSuchInvite
 	}
 	return
 }
 
-func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey) (err error) {
-	inviteKey, exists := c.aclState.inviteKeys[ch.InviteRecordId]
+func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequestJoin, authorIdentity crypto.PubKey, acceptorTimestamp int64) (err error) {
+	invite, exists := c.aclState.invites[ch.InviteRecordId]
 	if !exists {
 		return ErrNoSuchInvite
 	}
+	if invite.Type == aclrecordproto.AclInviteType_PublicReader {
+		return ErrIncorrectInviteType
+	}
+	// the expiration is checked only against the time set by the consensus node, so all peers get the same result
+	if invite.IsExpired(acceptorTimestamp) {
+		return ErrInviteExpired
+	}
+	if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+		return ErrInviteExhausted
+	}
 	inviteIdentity, err := c.keyStore.PubKeyFromProto(ch.InviteIdentity)
 	if err != nil {
 		return
@@ -115,7 +190,7 @@ func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequ
 	if err != nil {
 		return err
 	}
-	ok, err := inviteKey.Verify(rawInviteIdentity, ch.InviteIdentitySignature)
+	ok, err := invite.Key.Verify(rawInviteIdentity, ch.InviteIdentitySignature)
 	if err != nil {
 		return ErrInvalidSignature
 	}
@@ -128,14 +203,63 @@ func (c *contentValidator) ValidateRequestJoin(ch *aclrecordproto.AclAccountRequ
 	return
 }
 
+func (c *contentValidator) ValidateInviteJoin(ch *aclrecordproto.AclAccountInviteJoin, authorIdentity crypto.PubKey, acceptorTimestamp int64) (err error) {
+	invite, exists := c.aclState.invites[ch.InviteRecordId]
+	if !exists {
+		return ErrNoSuchInvite
+	}
+	if invite.Type != aclrecordproto.AclInviteType_AnyoneCanJoin {
+		return ErrIncorrectInviteType
+	}
+	// the expiration is checked only against the time set by the consensus node, so all peers get the same result
+	if invite.IsExpired(acceptorTimestamp) {
+		return ErrInviteExpired
+	}
+	if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+		return ErrInviteExhausted
+	}
+	identity, err := c.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return
+	}
+	if !authorIdentity.Equals(identity) {
+		return ErrIncorrectIdentity
+	}
+	idKey := mapKeyFromPubKey(identity)
+	if _, exists := c.aclState.accountStates[idKey]; exists {
+		return ErrAccountExists
+	}
+	if _, exists := c.aclState.pendingRequests[idKey]; exists {
+		return ErrPendingRequest
+	}
+	if AclPermissions(ch.Permissions) != invite.Permissions {
+		return ErrInsufficientPermissions
+	}
+	rawIdentity, err := identity.Raw()
+	if err != nil {
+		return err
+	}
+	ok, err := invite.Key.Verify(rawIdentity, ch.InviteIdentitySignature)
+	if err != nil || !ok {
+		return ErrInvalidSignature
+	}
+	if len(ch.EncryptedReadKey) == 0 {
+		return ErrIncorrectReadKey
+	}
+	if len(ch.Metadata) > MaxMetadataLen {
+		return ErrMetadataTooLarge
+	}
+	return
+}
+
 func (c *contentValidator) ValidateRequestAccept(ch *aclrecordproto.AclAccountRequestAccept, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
 		return ErrInsufficientPermissions
 	}
-	record, exists := c.aclState.requestRecords[ch.RequestRecordId]
-	if !exists {
+	if !c.aclState.isPendingRequest(ch.RequestRecordId) {
 		return ErrNoSuchRequest
 	}
+	record := c.aclState.requestRecords[ch.RequestRecordId]
 	acceptIdentity, err := c.keyStore.PubKeyFromProto(ch.Identity)
 	if err != nil {
 		return
@@ -143,25 +267,27 @@ func (c *contentValidator) ValidateRequestAccept(ch *aclrecordproto.AclAccountRe
 	if !acceptIdentity.Equals(record.RequestIdentity) {
 		return ErrIncorrectIdentity
 	}
-	if ch.Permissions == aclrecordproto.AclUserPermissions_Owner {
-		return ErrInsufficientPermissions
+	// the invite may be revoked after the request was made, then the request still can be accepted
+	if invite, exists := c.aclState.invites[record.InviteRecordId]; exists {
+		if remaining, limited := invite.RemainingUses(); limited && remaining == 0 {
+			return ErrInviteExhausted
+		}
 	}
-	return
+	return c.validateGrantedRole(AclPermissions(ch.Permissions), ch.Role, authorIdentity)
 }
 
 func (c *contentValidator) ValidateRequestDecline(ch *aclrecordproto.AclAccountRequestDecline, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityAcceptRequests) {
 		return ErrInsufficientPermissions
 	}
-	_, exists := c.aclState.requestRecords[ch.RequestRecordId]
-	if !exists {
+	if !c.aclState.isPendingRequest(ch.RequestRecordId) {
 		return ErrNoSuchRequest
 	}
 	return
 }
 
 func (c *contentValidator) ValidateAccountRemove(ch *aclrecordproto.AclAccountRemove, authorIdentity crypto.PubKey) (err error) {
-	if !c.aclState.Permissions(authorIdentity).CanManageAccounts() {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityRemoveAccounts) {
 		return ErrInsufficientPermissions
 	}
 	seenIdentities := map[string]struct{}{}
@@ -199,7 +325,119 @@ func (c *contentValidator) ValidateRequestRemove(ch *aclrecordproto.AclAccountRe
 	return
 }
 
+func (c *contentValidator) ValidateMetadataUpdate(ch *aclrecordproto.AclAccountMetadataUpdate, authorIdentity crypto.PubKey) (err error) {
+	identity, err := c.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return
+	}
+	// only the account itself can change its metadata
+	if !identity.Equals(authorIdentity) {
+		return ErrIncorrectIdentity
+	}
+	if c.aclState.Permissions(authorIdentity).NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	if len(ch.Metadata) > MaxMetadataLen {
+		return ErrMetadataTooLarge
+	}
+	return
+}
+
+func (c *contentValidator) ValidateRoleDefine(ch *aclrecordproto.AclRoleDefine, authorIdentity crypto.PubKey) (err error) {
+	authorCapabilities := c.aclState.Capabilities(authorIdentity)
+	if !authorCapabilities.Has(aclrecordproto.AclCapability_CapabilityManageRoles) {
+		return ErrInsufficientPermissions
+	}
+	if ch.Role == nil || ch.Role.Name == "" || isBuiltinRoleName(ch.Role.Name) {
+		return ErrIncorrectRole
+	}
+	for _, capability := range ch.Role.Capabilities {
+		if _, exists := aclrecordproto.AclCapability_name[int32(capability)]; !exists || capability == aclrecordproto.AclCapability_CapabilityNone {
+			return ErrIncorrectRole
+		}
+	}
+	// the author can't define roles more powerful than their own
+	if !authorCapabilities.Contains(NewAclCapabilities(ch.Role.Capabilities...)) {
+		return ErrInsufficientPermissions
+	}
+	return
+}
+
+func (c *contentValidator) ValidateRoleRemove(ch *aclrecordproto.AclRoleRemove, authorIdentity crypto.PubKey) (err error) {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityManageRoles) {
+		return ErrInsufficientPermissions
+	}
+	if _, exists := c.aclState.roles[ch.Name]; !exists {
+		return ErrNoSuchRole
+	}
+	for _, state := range c.aclState.accountStates {
+		if state.Role == ch.Name {
+			return ErrRoleInUse
+		}
+	}
+	return
+}
+
+func (c *contentValidator) ValidateOwnershipTransfer(ch *aclrecordproto.AclOwnershipTransfer, authorIdentity crypto.PubKey) (err error) {
+	if !c.aclState.Permissions(authorIdentity).IsOwner() {
+		return ErrInsufficientPermissions
+	}
+	identity, err := c.keyStore.PubKeyFromProto(ch.Identity)
+	if err != nil {
+		return
+	}
+	permissions := c.aclState.Permissions(identity)
+	if permissions.NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	if permissions.IsOwner() {
+		return ErrIsOwner
+	}
+	return
+}
+
+func (c *contentValidator) ValidateOwnershipAccept(ch *aclrecordproto.AclOwnershipAccept, authorIdentity crypto.PubKey) (err error) {
+	transfer, exists := c.aclState.ownershipTransfers[ch.TransferRecordId]
+	if !exists {
+		return ErrNoSuchOwnershipTransfer
+	}
+	if !transfer.NewOwnerIdentity.Equals(authorIdentity) {
+		return ErrIncorrectIdentity
+	}
+	// the owner could have lost the ownership after making the offer
+	if !c.aclState.Permissions(transfer.OwnerIdentity).IsOwner() {
+		return ErrInsufficientPermissions
+	}
+	permissions := c.aclState.Permissions(authorIdentity)
+	if permissions.NoPermissions() {
+		return ErrNoSuchAccount
+	}
+	if permissions.IsOwner() {
+		return ErrIsOwner
+	}
+	return
+}
+
+// validateGrantedRole checks that the author can give the role to some account
+func (c *contentValidator) validateGrantedRole(permissions AclPermissions, role string, authorIdentity crypto.PubKey) (err error) {
+	if permissions.IsOwner() {
+		return ErrInsufficientPermissions
+	}
+	if role != "" {
+		if _, exists := c.aclState.roles[role]; !exists {
+			return ErrNoSuchRole
+		}
+	}
+	if !c.aclState.Capabilities(authorIdentity).Contains(c.aclState.resolveCapabilities(permissions, role)) {
+		return ErrInsufficientPermissions
+	}
+	return
+}
+
 func (c *contentValidator) ValidateReadKeyChange(ch *aclrecordproto.AclReadKeyChange, authorIdentity crypto.PubKey) (err error) {
+	if !c.aclState.Capabilities(authorIdentity).Has(aclrecordproto.AclCapability_CapabilityChangeReadKey) {
+		return ErrInsufficientPermissions
+	}
 	return c.validateReadKeyChange(ch, nil)
 }
 
@@ -229,5 +467,37 @@ func (c *contentValidator) validateReadKeyChange(ch *aclrecordproto.AclReadKeyCh
 			return ErrIncorrectNumberOfAccounts
 		}
 	}
+	// the new read key should be available to all AnyoneCanJoin and PublicReader invites
+	readKeyInvites := 0
+	for _, invite := range c.aclState.invites {
+		if invite.HasReadKey() {
+			readKeyInvites++
+		}
+	}
+	if len(ch.InviteKeys) != readKeyInvites {
+		return ErrIncorrectNumberOfAccounts
+	}
+	seenInvites := map[string]struct{}{}
+	for _, encKeys := range ch.InviteKeys {
+		key, err := c.keyStore.PubKeyFromProto(encKeys.Identity)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, invite := range c.aclState.invites {
+			if invite.HasReadKey() && invite.Key.Equals(key) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrNoSuchInvite
+		}
+		keyId := mapKeyFromPubKey(key)
+		if _, exists := seenInvites[keyId]; exists {
+			return ErrDuplicateAccounts
+		}
+		seenInvites[keyId] = struct{}{}
+	}
 	return
 }
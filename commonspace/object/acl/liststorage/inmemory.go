@@ -14,6 +14,10 @@ type inMemoryAclListStorage struct {
 	root    *consensusproto.RawRecordWithId
 	head    string
 	records map[string]*consensusproto.RawRecordWithId
+	// snapshot is the encrypted snapshot of the acl state
+	snapshot []byte
+	// pending is the encrypted queue of the records which are not sent yet
+	pending []byte
 
 	sync.RWMutex
 }
@@ -78,3 +82,32 @@ func (t *inMemoryAclListStorage) GetRawRecord(ctx context.Context, recordId stri
 	}
 	return nil, fmt.Errorf("could not get record with id: %s", recordId)
 }
+
+func (t *inMemoryAclListStorage) StateSnapshot(ctx context.Context) ([]byte, error) {
+	t.RLock()
+	defer t.RUnlock()
+	if t.snapshot == nil {
+		return nil, ErrUnknownSnapshot
+	}
+	return t.snapshot, nil
+}
+
+func (t *inMemoryAclListStorage) SetStateSnapshot(ctx context.Context, snapshot []byte) error {
+	t.Lock()
+	defer t.Unlock()
+	t.snapshot = snapshot
+	return nil
+}
+
+func (t *inMemoryAclListStorage) PendingRecords(ctx context.Context) ([]byte, error) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.pending, nil
+}
+
+func (t *inMemoryAclListStorage) SetPendingRecords(ctx context.Context, records []byte) error {
+	t.Lock()
+	defer t.Unlock()
+	t.pending = records
+	return nil
+}
@@ -9,9 +9,10 @@ import (
 )
 
 var (
-	ErrUnknownAclId  = errors.New("acl does not exist")
-	ErrAclExists     = errors.New("acl already exists")
-	ErrUnknownRecord = errors.New("record doesn't exist")
+	ErrUnknownAclId    = errors.New("acl does not exist")
+	ErrAclExists       = errors.New("acl already exists")
+	ErrUnknownRecord   = errors.New("record doesn't exist")
+	ErrUnknownSnapshot = errors.New("snapshot doesn't exist")
 )
 
 type Exporter interface {
@@ -27,3 +28,30 @@ type ListStorage interface {
 	GetRawRecord(ctx context.Context, id string) (*consensusproto.RawRecordWithId, error)
 	AddRawRecord(ctx context.Context, rec *consensusproto.RawRecordWithId) error
 }
+
+// SnapshotStorage can be implemented by list storages to keep the local snapshot of the acl state,
+// so the list doesn't need to replay all records when it is loaded
+type SnapshotStorage interface {
+	StateSnapshot(ctx context.Context) ([]byte, error)
+	SetStateSnapshot(ctx context.Context, snapshot []byte) error
+}
+
+// PendingStorage keeps the encrypted queue of the acl records of the local account which are not sent yet
+type PendingStorage interface {
+	// PendingRecords returns nil if the queue was not saved
+	PendingRecords(ctx context.Context) ([]byte, error)
+	SetPendingRecords(ctx context.Context, records []byte) error
+}
+
+type snapshotListStorage struct {
+	ListStorage
+	SnapshotStorage
+}
+
+// WithSnapshotStorage returns the list storage which keeps the snapshot of the acl state in the snapshot storage
+func WithSnapshotStorage(storage ListStorage, snapshotStorage SnapshotStorage) ListStorage {
+	return snapshotListStorage{
+		ListStorage:     storage,
+		SnapshotStorage: snapshotStorage,
+	}
+}
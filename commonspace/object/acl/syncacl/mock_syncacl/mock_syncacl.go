@@ -5,6 +5,7 @@
 //
 //	mockgen -destination mock_syncacl/mock_syncacl.go github.com/anyproto/any-sync/commonspace/object/acl/syncacl SyncAcl,SyncClient,RequestFactory,AclSyncProtocol
 //
+
 // Package mock_syncacl is a generated GoMock package.
 package mock_syncacl
 
@@ -15,6 +16,7 @@ import (
 	app "github.com/anyproto/any-sync/app"
 	list "github.com/anyproto/any-sync/commonspace/object/acl/list"
 	headupdater "github.com/anyproto/any-sync/commonspace/object/acl/syncacl/headupdater"
+	pendingacl "github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
 	spacesyncproto "github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	consensusproto "github.com/anyproto/any-sync/consensus/consensusproto"
 	crypto "github.com/anyproto/any-sync/util/crypto"
@@ -58,6 +60,18 @@ func (mr *MockSyncAclMockRecorder) AclState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AclState", reflect.TypeOf((*MockSyncAcl)(nil).AclState))
 }
 
+// AddPendingRecord mocks base method.
+func (m *MockSyncAcl) AddPendingRecord(arg0 pendingacl.Record) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddPendingRecord", arg0)
+}
+
+// AddPendingRecord indicates an expected call of AddPendingRecord.
+func (mr *MockSyncAclMockRecorder) AddPendingRecord(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPendingRecord", reflect.TypeOf((*MockSyncAcl)(nil).AddPendingRecord), arg0)
+}
+
 // AddRawRecord mocks base method.
 func (m *MockSyncAcl) AddRawRecord(arg0 *consensusproto.RawRecordWithId) error {
 	m.ctrl.T.Helper()
@@ -294,6 +308,20 @@ func (mr *MockSyncAclMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockSyncAcl)(nil).Name))
 }
 
+// PendingRecordsCount mocks base method.
+func (m *MockSyncAcl) PendingRecordsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingRecordsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// PendingRecordsCount indicates an expected call of PendingRecordsCount.
+func (mr *MockSyncAclMockRecorder) PendingRecordsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingRecordsCount", reflect.TypeOf((*MockSyncAcl)(nil).PendingRecordsCount))
+}
+
 // RLock mocks base method.
 func (m *MockSyncAcl) RLock() {
 	m.ctrl.T.Helper()
@@ -404,6 +432,20 @@ func (mr *MockSyncAclMockRecorder) Run(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockSyncAcl)(nil).Run), arg0)
 }
 
+// SendPendingRecords mocks base method.
+func (m *MockSyncAcl) SendPendingRecords(arg0 context.Context, arg1 pendingacl.RecordSender) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPendingRecords", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPendingRecords indicates an expected call of SendPendingRecords.
+func (mr *MockSyncAclMockRecorder) SendPendingRecords(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPendingRecords", reflect.TypeOf((*MockSyncAcl)(nil).SendPendingRecords), arg0, arg1)
+}
+
 // SetAclUpdater mocks base method.
 func (m *MockSyncAcl) SetAclUpdater(arg0 headupdater.AclUpdater) {
 	m.ctrl.T.Helper()
@@ -428,6 +470,18 @@ func (mr *MockSyncAclMockRecorder) SetHeadUpdater(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeadUpdater", reflect.TypeOf((*MockSyncAcl)(nil).SetHeadUpdater), arg0)
 }
 
+// SetPendingRecordSender mocks base method.
+func (m *MockSyncAcl) SetPendingRecordSender(arg0 pendingacl.RecordSender) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPendingRecordSender", arg0)
+}
+
+// SetPendingRecordSender indicates an expected call of SetPendingRecordSender.
+func (mr *MockSyncAclMockRecorder) SetPendingRecordSender(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingRecordSender", reflect.TypeOf((*MockSyncAcl)(nil).SetPendingRecordSender), arg0)
+}
+
 // SyncWithPeer mocks base method.
 func (m *MockSyncAcl) SyncWithPeer(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,181 @@
+package pendingacl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ErrUnknownPendingRecord = errors.New("unknown pending acl record")
+
+// RecordBuildFunc builds the record of the operation against the current head of the acl,
+// the error means that the operation can't be made anymore
+type RecordBuildFunc func(acl list.AclList) (rec *consensusproto.RawRecord, err error)
+
+// RecordSender adds the records to the consensus log, it is implemented by consensusclient.Service
+type RecordSender interface {
+	AddRecord(ctx context.Context, logId string, rec *consensusproto.RawRecord) (record *consensusproto.RawRecordWithId, err error)
+}
+
+// Record is the acl operation which waits to be sent to the consensus node,
+// it is rebuilt if the head of the acl changes before the record is accepted
+type Record struct {
+	// Name describes the operation in the errors
+	Name  string
+	Build RecordBuildFunc
+	// Proto describes the operation when the queue is saved, the records without it are not saved
+	// and are lost on restart
+	Proto *aclrecordproto.AclPendingRecord
+}
+
+// ConflictError is returned when the pending operation can't be made on the new head of the acl
+type ConflictError struct {
+	Name string
+	Err  error
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("pending acl record %s conflicts with the acl: %v", e.Name, e.Err)
+}
+
+func (e ConflictError) Unwrap() error {
+	return e.Err
+}
+
+func RequestAccept(payload list.RequestAcceptPayload) Record {
+	return Record{
+		Name: "requestAccept",
+		Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+			return acl.RecordBuilder().BuildRequestAccept(payload)
+		},
+		Proto: &aclrecordproto.AclPendingRecord{
+			Value: &aclrecordproto.AclPendingRecord_RequestAccept{
+				RequestAccept: &aclrecordproto.AclPendingRequestAccept{
+					RequestRecordId: payload.RequestRecordId,
+					Permissions:     aclrecordproto.AclUserPermissions(payload.Permissions),
+					Role:            payload.Role,
+				},
+			},
+		},
+	}
+}
+
+func RequestDecline(requestRecordId string) Record {
+	return Record{
+		Name: "requestDecline",
+		Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+			return acl.RecordBuilder().BuildRequestDecline(requestRecordId)
+		},
+		Proto: &aclrecordproto.AclPendingRecord{
+			Value: &aclrecordproto.AclPendingRecord_RequestDecline{
+				RequestDecline: &aclrecordproto.AclAccountRequestDecline{
+					RequestRecordId: requestRecordId,
+				},
+			},
+		},
+	}
+}
+
+func PermissionChange(payload list.PermissionChangePayload) (Record, error) {
+	identity, err := payload.Identity.Marshall()
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{
+		Name: "permissionChange",
+		Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+			return acl.RecordBuilder().BuildPermissionChange(payload)
+		},
+		Proto: &aclrecordproto.AclPendingRecord{
+			Value: &aclrecordproto.AclPendingRecord_PermissionChange{
+				PermissionChange: &aclrecordproto.AclAccountPermissionChange{
+					Identity:    identity,
+					Permissions: aclrecordproto.AclUserPermissions(payload.Permissions),
+					Role:        payload.Role,
+				},
+			},
+		},
+	}, nil
+}
+
+func AccountRemove(identities []crypto.PubKey, change list.ReadKeyChangePayload) (Record, error) {
+	remove := &aclrecordproto.AclPendingAccountRemove{}
+	for _, identity := range identities {
+		marshalled, err := identity.Marshall()
+		if err != nil {
+			return Record{}, err
+		}
+		remove.Identities = append(remove.Identities, marshalled)
+	}
+	var err error
+	if remove.MetadataPrivKey, err = change.MetadataKey.Marshall(); err != nil {
+		return Record{}, err
+	}
+	if remove.ReadKey, err = change.ReadKey.Marshall(); err != nil {
+		return Record{}, err
+	}
+	return Record{
+		Name: "accountRemove",
+		Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+			return acl.RecordBuilder().BuildAccountRemove(list.AccountRemovePayload{
+				Identities: identities,
+				Change:     change,
+			})
+		},
+		Proto: &aclrecordproto.AclPendingRecord{
+			Value: &aclrecordproto.AclPendingRecord_AccountRemove{AccountRemove: remove},
+		},
+	}, nil
+}
+
+// FromProto restores the record saved with the queue
+func FromProto(rec *aclrecordproto.AclPendingRecord) (Record, error) {
+	switch v := rec.Value.(type) {
+	case *aclrecordproto.AclPendingRecord_RequestAccept:
+		return RequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: v.RequestAccept.RequestRecordId,
+			Permissions:     list.AclPermissions(v.RequestAccept.Permissions),
+			Role:            v.RequestAccept.Role,
+		}), nil
+	case *aclrecordproto.AclPendingRecord_RequestDecline:
+		return RequestDecline(v.RequestDecline.RequestRecordId), nil
+	case *aclrecordproto.AclPendingRecord_PermissionChange:
+		identity, err := crypto.UnmarshalEd25519PublicKeyProto(v.PermissionChange.Identity)
+		if err != nil {
+			return Record{}, err
+		}
+		return PermissionChange(list.PermissionChangePayload{
+			Identity:    identity,
+			Permissions: list.AclPermissions(v.PermissionChange.Permissions),
+			Role:        v.PermissionChange.Role,
+		})
+	case *aclrecordproto.AclPendingRecord_AccountRemove:
+		identities := make([]crypto.PubKey, 0, len(v.AccountRemove.Identities))
+		for _, marshalled := range v.AccountRemove.Identities {
+			identity, err := crypto.UnmarshalEd25519PublicKeyProto(marshalled)
+			if err != nil {
+				return Record{}, err
+			}
+			identities = append(identities, identity)
+		}
+		metadataKey, err := crypto.UnmarshalEd25519PrivateKeyProto(v.AccountRemove.MetadataPrivKey)
+		if err != nil {
+			return Record{}, err
+		}
+		readKey, err := crypto.UnmarshallAESKeyProto(v.AccountRemove.ReadKey)
+		if err != nil {
+			return Record{}, err
+		}
+		return AccountRemove(identities, list.ReadKeyChangePayload{
+			MetadataKey: metadataKey,
+			ReadKey:     readKey,
+		})
+	default:
+		return Record{}, ErrUnknownPendingRecord
+	}
+}
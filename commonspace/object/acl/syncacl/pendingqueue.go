@@ -0,0 +1,114 @@
+package syncacl
+
+import (
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+type pendingRecord struct {
+	pendingacl.Record
+	rawRec *consensusproto.RawRecord
+	// headId is the head of the acl which the record was built on
+	headId string
+}
+
+// prepare builds the record if it was not built yet or the head of the acl was changed
+func (p *pendingRecord) prepare(acl list.AclList) (rawRec *consensusproto.RawRecord, err error) {
+	headId := acl.Head().Id
+	if p.rawRec == nil || p.headId != headId {
+		if rawRec, err = p.Build(acl); err != nil {
+			return nil, pendingacl.ConflictError{Name: p.Name, Err: err}
+		}
+		p.rawRec = rawRec
+		p.headId = headId
+	}
+	if err = acl.ValidateRawRecord(p.rawRec); err != nil {
+		return nil, pendingacl.ConflictError{Name: p.Name, Err: err}
+	}
+	return p.rawRec, nil
+}
+
+type pendingQueue struct {
+	records []*pendingRecord
+}
+
+func (q *pendingQueue) add(rec pendingacl.Record) {
+	q.records = append(q.records, &pendingRecord{Record: rec})
+}
+
+func (q *pendingQueue) first() *pendingRecord {
+	if len(q.records) == 0 {
+		return nil
+	}
+	return q.records[0]
+}
+
+func (q *pendingQueue) removeFirst() {
+	q.records[0] = nil
+	q.records = q.records[1:]
+}
+
+func (q *pendingQueue) len() int {
+	return len(q.records)
+}
+
+// marshall encrypts the records which can be restored, nil means that there is nothing to save
+func (q *pendingQueue) marshall(key crypto.PrivKey) ([]byte, error) {
+	records := &aclrecordproto.AclPendingRecords{}
+	for _, rec := range q.records {
+		if rec.Proto != nil {
+			records.Records = append(records.Records, rec.Proto)
+		}
+	}
+	if len(records.Records) == 0 {
+		return nil, nil
+	}
+	marshalled, err := records.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	symKey, err := derivePendingKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return symKey.Encrypt(marshalled)
+}
+
+// unmarshall adds the saved records to the queue
+func (q *pendingQueue) unmarshall(data []byte, key crypto.PrivKey) error {
+	symKey, err := derivePendingKey(key)
+	if err != nil {
+		return err
+	}
+	decrypted, err := symKey.Decrypt(data)
+	if err != nil {
+		return err
+	}
+	records := &aclrecordproto.AclPendingRecords{}
+	if err = records.Unmarshal(decrypted); err != nil {
+		return err
+	}
+	restored := make([]pendingacl.Record, 0, len(records.Records))
+	for _, proto := range records.Records {
+		rec, err := pendingacl.FromProto(proto)
+		if err != nil {
+			return err
+		}
+		restored = append(restored, rec)
+	}
+	for _, rec := range restored {
+		q.add(rec)
+	}
+	return nil
+}
+
+func derivePendingKey(key crypto.PrivKey) (crypto.SymKey, error) {
+	keyBytes, err := key.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DeriveSymmetricKey(keyBytes, crypto.AnysyncAclPendingPath)
+}
@@ -3,13 +3,19 @@ package syncacl
 import (
 	"context"
 	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/headupdater"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
 	"github.com/anyproto/any-sync/commonspace/object/syncobjectgetter"
 
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app"
 	"github.com/anyproto/any-sync/app/logger"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
 	"github.com/anyproto/any-sync/commonspace/objectsync/synchandler"
 	"github.com/anyproto/any-sync/commonspace/peermanager"
 	"github.com/anyproto/any-sync/commonspace/requestmanager"
@@ -34,6 +40,10 @@ type SyncAcl interface {
 	SetHeadUpdater(updater headupdater.HeadUpdater)
 	SyncWithPeer(ctx context.Context, peerId string) (err error)
 	SetAclUpdater(updater headupdater.AclUpdater)
+	AddPendingRecord(rec pendingacl.Record)
+	PendingRecordsCount() int
+	SendPendingRecords(ctx context.Context, sender pendingacl.RecordSender) (err error)
+	SetPendingRecordSender(sender pendingacl.RecordSender)
 }
 
 func New() SyncAcl {
@@ -47,6 +57,13 @@ type syncAcl struct {
 	headUpdater headupdater.HeadUpdater
 	isClosed    bool
 	aclUpdater  headupdater.AclUpdater
+	pending     pendingQueue
+	sendMx      sync.Mutex
+	// pendingStorage keeps the queue between restarts, it is nil if the queue is not saved
+	pendingStorage liststorage.PendingStorage
+	// sender is used to retry the pending records when the head of the acl changes
+	sender    pendingacl.RecordSender
+	isSending bool
 }
 
 func (s *syncAcl) SetAclUpdater(updater headupdater.AclUpdater) {
@@ -80,11 +97,26 @@ func (s *syncAcl) Init(a *app.App) (err error) {
 	if err != nil {
 		return err
 	}
+	if aclStorage, err = withSnapshotStorage(storage, aclStorage); err != nil {
+		return err
+	}
 	acc := a.MustComponent(accountservice.CName).(accountservice.Service)
 	s.AclList, err = list.BuildAclListWithIdentity(acc.Account(), aclStorage, list.NoOpAcceptorVerifier{})
 	if err != nil {
 		return
 	}
+	if provider, ok := storage.(spacestorage.AclPendingStorageProvider); ok {
+		s.pendingStorage, err = provider.AclPendingStorage()
+		if errors.Is(err, spacestorage.ErrAclStorageNotSupported) {
+			s.pendingStorage, err = nil, nil
+		}
+		if err != nil {
+			return
+		}
+	}
+	if err = s.loadPendingRecords(); err != nil {
+		return
+	}
 	spaceId := storage.Id()
 	requestManager := a.MustComponent(requestmanager.CName).(requestmanager.RequestManager)
 	peerManager := a.MustComponent(peermanager.CName).(peermanager.PeerManager)
@@ -94,6 +126,22 @@ func (s *syncAcl) Init(a *app.App) (err error) {
 	return err
 }
 
+// withSnapshotStorage keeps the snapshot of the acl state if the space storage supports it
+func withSnapshotStorage(storage spacestorage.SpaceStorage, aclStorage liststorage.ListStorage) (liststorage.ListStorage, error) {
+	provider, ok := storage.(spacestorage.AclSnapshotStorageProvider)
+	if !ok {
+		return aclStorage, nil
+	}
+	snapshotStorage, err := provider.AclSnapshotStorage()
+	if errors.Is(err, spacestorage.ErrAclStorageNotSupported) {
+		return aclStorage, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return liststorage.WithSnapshotStorage(aclStorage, snapshotStorage), nil
+}
+
 func (s *syncAcl) AddRawRecord(rawRec *consensusproto.RawRecordWithId) (err error) {
 	if s.isClosed {
 		return ErrSyncAclClosed
@@ -108,6 +156,7 @@ func (s *syncAcl) AddRawRecord(rawRec *consensusproto.RawRecordWithId) (err erro
 	if s.aclUpdater != nil {
 		s.aclUpdater.UpdateAcl(s)
 	}
+	s.retryPendingRecords()
 	return
 }
 
@@ -125,9 +174,126 @@ func (s *syncAcl) AddRawRecords(rawRecords []*consensusproto.RawRecordWithId) (e
 	if s.aclUpdater != nil {
 		s.aclUpdater.UpdateAcl(s)
 	}
+	s.retryPendingRecords()
 	return
 }
 
+// AddPendingRecord adds the operation to the queue, it is sent by SendPendingRecords
+func (s *syncAcl) AddPendingRecord(rec pendingacl.Record) {
+	s.Lock()
+	defer s.Unlock()
+	s.pending.add(rec)
+	s.savePendingRecords()
+}
+
+// SetPendingRecordSender makes the acl send the pending records each time it receives the new records,
+// because the records which conflicted with the records missing locally can be sent after that
+func (s *syncAcl) SetPendingRecordSender(sender pendingacl.RecordSender) {
+	s.Lock()
+	defer s.Unlock()
+	s.sender = sender
+}
+
+func (s *syncAcl) PendingRecordsCount() int {
+	s.RLock()
+	defer s.RUnlock()
+	return s.pending.len()
+}
+
+// SendPendingRecords sends the queued records to the consensus node one by one,
+// each record is rebuilt if the head of the acl changed after it was built.
+// If the operation is not possible anymore it is removed from the queue and pendingacl.ConflictError is returned,
+// on other errors (e.g. if the consensus node has records which are not synced yet) the record stays in the queue
+func (s *syncAcl) SendPendingRecords(ctx context.Context, sender pendingacl.RecordSender) (err error) {
+	s.sendMx.Lock()
+	defer s.sendMx.Unlock()
+	s.Lock()
+	s.isSending = true
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		s.isSending = false
+		s.Unlock()
+	}()
+	for {
+		s.Lock()
+		if s.isClosed {
+			s.Unlock()
+			return ErrSyncAclClosed
+		}
+		rec := s.pending.first()
+		if rec == nil {
+			s.Unlock()
+			return nil
+		}
+		rawRec, err := rec.prepare(s)
+		if err != nil {
+			s.pending.removeFirst()
+			s.savePendingRecords()
+			s.Unlock()
+			return err
+		}
+		s.Unlock()
+
+		res, err := sender.AddRecord(ctx, s.Id(), rawRec)
+		if err != nil {
+			return err
+		}
+
+		s.Lock()
+		s.pending.removeFirst()
+		s.savePendingRecords()
+		err = s.AddRawRecord(res)
+		s.Unlock()
+		// the record could be received from the consensus node before the response
+		if err != nil && err != list.ErrRecordAlreadyExists {
+			return err
+		}
+	}
+}
+
+// retryPendingRecords sends the pending records in the background, it is called under the lock
+func (s *syncAcl) retryPendingRecords() {
+	if s.sender == nil || s.isSending || s.pending.len() == 0 {
+		return
+	}
+	sender := s.sender
+	go func() {
+		if err := s.SendPendingRecords(context.Background(), sender); err != nil {
+			log.Info("failed to send pending acl records", zap.String("aclId", s.Id()), zap.Error(err))
+		}
+	}()
+}
+
+// savePendingRecords is called under the lock, the queue stays in memory if it can't be saved
+func (s *syncAcl) savePendingRecords() {
+	if s.pendingStorage == nil || s.AclState().AccountKey() == nil {
+		return
+	}
+	data, err := s.pending.marshall(s.AclState().AccountKey())
+	if err == nil {
+		err = s.pendingStorage.SetPendingRecords(context.Background(), data)
+	}
+	if err != nil {
+		log.Warn("failed to save pending acl records", zap.String("aclId", s.Id()), zap.Error(err))
+	}
+}
+
+func (s *syncAcl) loadPendingRecords() error {
+	if s.pendingStorage == nil {
+		return nil
+	}
+	data, err := s.pendingStorage.PendingRecords(context.Background())
+	if err != nil || data == nil || s.AclState().AccountKey() == nil {
+		return err
+	}
+	if err = s.pending.unmarshall(data, s.AclState().AccountKey()); err != nil {
+		// the queue made by another account or the broken queue can't be sent anyway
+		log.Warn("failed to load pending acl records", zap.String("aclId", s.Id()), zap.Error(err))
+	}
+	return nil
+}
+
 func (s *syncAcl) SyncWithPeer(ctx context.Context, peerId string) (err error) {
 	s.Lock()
 	defer s.Unlock()
@@ -142,7 +308,8 @@ func (s *syncAcl) Close(ctx context.Context) (err error) {
 	s.Lock()
 	defer s.Unlock()
 	s.isClosed = true
-	return
+	// waiting for the acl snapshot to be saved
+	return s.AclList.Close(ctx)
 }
 
 func (s *syncAcl) Name() (name string) {
@@ -0,0 +1,298 @@
+package syncacl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/mock_syncacl"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
+	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto/consensuserr"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+type testHeadUpdater struct {
+}
+
+func (t testHeadUpdater) UpdateHeads(id string, heads []string) {
+}
+
+type testRecordSender struct {
+	err error
+}
+
+func (t *testRecordSender) AddRecord(ctx context.Context, logId string, rec *consensusproto.RawRecord) (record *consensusproto.RawRecordWithId, err error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return list.WrapAclRecord(rec), nil
+}
+
+type pendingFixture struct {
+	ctrl     *gomock.Controller
+	syncAcl  *syncAcl
+	adminAcl list.AclList
+	sender   *testRecordSender
+}
+
+func newPendingFixture(t *testing.T) *pendingFixture {
+	ctrl := gomock.NewController(t)
+	ownerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	ownerAcl, err := list.NewTestDerivedAcl("spaceId", ownerKeys)
+	require.NoError(t, err)
+	// another device of the owner making the changes concurrently
+	adminAcl, err := list.NewTestAclWithRoot(ownerKeys, ownerAcl.Root())
+	require.NoError(t, err)
+	syncClient := mock_syncacl.NewMockSyncClient(ctrl)
+	syncClient.EXPECT().CreateHeadUpdate(gomock.Any(), gomock.Any()).AnyTimes()
+	syncClient.EXPECT().Broadcast(gomock.Any()).AnyTimes()
+	return &pendingFixture{
+		ctrl: ctrl,
+		syncAcl: &syncAcl{
+			AclList:     ownerAcl,
+			syncClient:  syncClient,
+			headUpdater: testHeadUpdater{},
+		},
+		adminAcl: adminAcl,
+		sender:   &testRecordSender{},
+	}
+}
+
+// addAdminRecord adds the record made by another device, as it is received through sync
+func (fx *pendingFixture) addAdminRecord(t *testing.T, rec *consensusproto.RawRecord) {
+	recWithId := list.WrapAclRecord(rec)
+	require.NoError(t, fx.adminAcl.AddRawRecord(recWithId))
+	fx.syncAcl.Lock()
+	defer fx.syncAcl.Unlock()
+	require.NoError(t, fx.syncAcl.AddRawRecord(recWithId))
+}
+
+// requestJoin adds the join request of the new account
+func (fx *pendingFixture) requestJoin(t *testing.T) (joinerAcl list.AclList, requestId string) {
+	inv, err := fx.adminAcl.RecordBuilder().BuildInvite()
+	require.NoError(t, err)
+	fx.addAdminRecord(t, inv.InviteRec)
+	joinerKeys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	joinerAcl, err = list.NewTestAclWithRoot(joinerKeys, fx.adminAcl.Root())
+	require.NoError(t, err)
+	records, err := fx.adminAcl.RecordsAfter(context.Background(), fx.adminAcl.Id())
+	require.NoError(t, err)
+	require.NoError(t, joinerAcl.AddRawRecords(records))
+	requestJoin, err := joinerAcl.RecordBuilder().BuildRequestJoin(list.RequestJoinPayload{
+		InviteRecordId: joinerAcl.Head().Id,
+		InviteKey:      inv.InviteKey,
+	})
+	require.NoError(t, err)
+	fx.addAdminRecord(t, requestJoin)
+	return joinerAcl, fx.adminAcl.Head().Id
+}
+
+func (fx *pendingFixture) stop() {
+	fx.ctrl.Finish()
+}
+
+func TestSyncAcl_SendPendingRecords(t *testing.T) {
+	ctx := context.Background()
+	t.Run("rebuild after head change", func(t *testing.T) {
+		fx := newPendingFixture(t)
+		defer fx.stop()
+		joinerAcl, requestId := fx.requestJoin(t)
+		fx.syncAcl.AddPendingRecord(pendingacl.RequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: requestId,
+			Permissions:     list.AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+		}))
+
+		// the record is built while the device is offline
+		offlineErr := errors.New("offline")
+		fx.sender.err = offlineErr
+		require.Equal(t, offlineErr, fx.syncAcl.SendPendingRecords(ctx, fx.sender))
+		require.Equal(t, 1, fx.syncAcl.PendingRecordsCount())
+
+		// another device changes the read key in the meantime
+		newReadKey := crypto.NewAES()
+		metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		readKeyChange, err := fx.adminAcl.RecordBuilder().BuildReadKeyChange(list.ReadKeyChangePayload{
+			MetadataKey: metadataKey,
+			ReadKey:     newReadKey,
+		})
+		require.NoError(t, err)
+		fx.addAdminRecord(t, readKeyChange)
+
+		fx.sender.err = nil
+		require.NoError(t, fx.syncAcl.SendPendingRecords(ctx, fx.sender))
+		require.Equal(t, 0, fx.syncAcl.PendingRecordsCount())
+		require.Equal(t, list.AclPermissions(aclrecordproto.AclUserPermissions_Writer), fx.syncAcl.AclState().Permissions(joinerAcl.AclState().AccountKey().GetPublic()))
+
+		// the accepted account gets the new read key
+		records, err := fx.syncAcl.RecordsAfter(ctx, joinerAcl.Head().Id)
+		require.NoError(t, err)
+		require.NoError(t, joinerAcl.AddRawRecords(records))
+		readKey, err := joinerAcl.AclState().CurrentReadKey()
+		require.NoError(t, err)
+		require.True(t, readKey.Equals(newReadKey))
+	})
+	t.Run("conflict", func(t *testing.T) {
+		fx := newPendingFixture(t)
+		defer fx.stop()
+		_, requestId := fx.requestJoin(t)
+		fx.syncAcl.AddPendingRecord(pendingacl.RequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: requestId,
+			Permissions:     list.AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+		}))
+
+		// another device declines the request
+		decline, err := fx.adminAcl.RecordBuilder().BuildRequestDecline(requestId)
+		require.NoError(t, err)
+		fx.addAdminRecord(t, decline)
+
+		err = fx.syncAcl.SendPendingRecords(ctx, fx.sender)
+		var conflictErr pendingacl.ConflictError
+		require.True(t, errors.As(err, &conflictErr))
+		require.Equal(t, "requestAccept", conflictErr.Name)
+		require.True(t, errors.Is(err, list.ErrNoSuchRequest))
+		require.Equal(t, 0, fx.syncAcl.PendingRecordsCount())
+	})
+	t.Run("consensus conflict keeps the record", func(t *testing.T) {
+		fx := newPendingFixture(t)
+		defer fx.stop()
+		_, requestId := fx.requestJoin(t)
+		fx.syncAcl.AddPendingRecord(pendingacl.RequestDecline(requestId))
+
+		fx.sender.err = consensuserr.ErrConflict
+		require.Equal(t, consensuserr.ErrConflict, fx.syncAcl.SendPendingRecords(ctx, fx.sender))
+		require.Equal(t, 1, fx.syncAcl.PendingRecordsCount())
+
+		fx.sender.err = nil
+		require.NoError(t, fx.syncAcl.SendPendingRecords(ctx, fx.sender))
+		require.Equal(t, 0, fx.syncAcl.PendingRecordsCount())
+		require.Empty(t, fx.syncAcl.AclState().JoinRecords())
+	})
+	t.Run("retry on head update", func(t *testing.T) {
+		fx := newPendingFixture(t)
+		defer fx.stop()
+		joinerAcl, requestId := fx.requestJoin(t)
+		fx.syncAcl.SetPendingRecordSender(fx.sender)
+		fx.syncAcl.AddPendingRecord(pendingacl.RequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: requestId,
+			Permissions:     list.AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+		}))
+		fx.sender.err = consensuserr.ErrConflict
+		require.Equal(t, consensuserr.ErrConflict, fx.syncAcl.SendPendingRecords(ctx, fx.sender))
+		fx.sender.err = nil
+
+		// the record missing locally is received, so the pending record is sent again
+		inv, err := fx.adminAcl.RecordBuilder().BuildInvite()
+		require.NoError(t, err)
+		fx.addAdminRecord(t, inv.InviteRec)
+		require.Eventually(t, func() bool {
+			return fx.syncAcl.PendingRecordsCount() == 0
+		}, time.Second, time.Millisecond*10)
+		fx.syncAcl.RLock()
+		defer fx.syncAcl.RUnlock()
+		require.Equal(t, list.AclPermissions(aclrecordproto.AclUserPermissions_Writer), fx.syncAcl.AclState().Permissions(joinerAcl.AclState().AccountKey().GetPublic()))
+	})
+	t.Run("restore saved queue", func(t *testing.T) {
+		fx := newPendingFixture(t)
+		defer fx.stop()
+		joinerAcl, requestId := fx.requestJoin(t)
+		aclStorage, err := liststorage.NewInMemoryAclListStorage(fx.syncAcl.Id(), []*consensusproto.RawRecordWithId{fx.syncAcl.Root()})
+		require.NoError(t, err)
+		pendingStorage := aclStorage.(liststorage.PendingStorage)
+		fx.syncAcl.pendingStorage = pendingStorage
+		fx.syncAcl.AddPendingRecord(pendingacl.RequestAccept(list.RequestAcceptPayload{
+			RequestRecordId: requestId,
+			Permissions:     list.AclPermissions(aclrecordproto.AclUserPermissions_Writer),
+		}))
+		// the records without the description are not saved
+		fx.syncAcl.AddPendingRecord(pendingacl.Record{
+			Name: "transient",
+			Build: func(acl list.AclList) (*consensusproto.RawRecord, error) {
+				return nil, errors.New("not restored")
+			},
+		})
+
+		restored := &syncAcl{
+			AclList:        fx.syncAcl.AclList,
+			syncClient:     fx.syncAcl.syncClient,
+			headUpdater:    testHeadUpdater{},
+			pendingStorage: pendingStorage,
+		}
+		require.NoError(t, restored.loadPendingRecords())
+		require.Equal(t, 1, restored.PendingRecordsCount())
+		require.NoError(t, restored.SendPendingRecords(ctx, fx.sender))
+		require.Equal(t, list.AclPermissions(aclrecordproto.AclUserPermissions_Writer), restored.AclState().Permissions(joinerAcl.AclState().AccountKey().GetPublic()))
+		data, err := pendingStorage.PendingRecords(ctx)
+		require.NoError(t, err)
+		require.Nil(t, data)
+	})
+}
+
+func TestSyncAcl_OptionalStorages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	acl, err := list.NewTestDerivedAcl("spaceId", keys)
+	require.NoError(t, err)
+	aclStorage, err := liststorage.NewInMemoryAclListStorage(acl.Id(), []*consensusproto.RawRecordWithId{acl.Root()})
+	require.NoError(t, err)
+
+	// the space storage without the acl snapshots and the pending queue
+	storage := mock_spacestorage.NewMockSpaceStorage(ctrl)
+	withSnapshots, err := withSnapshotStorage(storage, aclStorage)
+	require.NoError(t, err)
+	require.Equal(t, aclStorage, withSnapshots)
+	s := &syncAcl{AclList: acl}
+	require.NoError(t, s.loadPendingRecords())
+	s.savePendingRecords()
+}
+
+func TestPendingQueue_Marshall(t *testing.T) {
+	accountKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	_, identity, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	remove, err := pendingacl.AccountRemove([]crypto.PubKey{identity}, list.ReadKeyChangePayload{
+		MetadataKey: metadataKey,
+		ReadKey:     crypto.NewAES(),
+	})
+	require.NoError(t, err)
+	change, err := pendingacl.PermissionChange(list.PermissionChangePayload{
+		Identity: identity,
+		Role:     "editor",
+	})
+	require.NoError(t, err)
+	var queue pendingQueue
+	queue.add(remove)
+	queue.add(pendingacl.RequestDecline("requestId"))
+	queue.add(change)
+	data, err := queue.marshall(accountKey)
+	require.NoError(t, err)
+
+	var restored pendingQueue
+	require.NoError(t, restored.unmarshall(data, accountKey))
+	require.Equal(t, queue.len(), restored.len())
+	for i, rec := range queue.records {
+		require.Equal(t, rec.Name, restored.records[i].Name)
+		require.Equal(t, rec.Proto, restored.records[i].Proto)
+	}
+
+	otherKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	require.Error(t, (&pendingQueue{}).unmarshall(data, otherKey))
+}
@@ -1,6 +1,8 @@
 package exporter
 
 import (
+	"context"
+
 	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
@@ -17,6 +19,8 @@ type TreeExporterParams struct {
 	ListStorageExporter liststorage.Exporter
 	TreeStorageExporter treestorage.Exporter
 	DataConverter       DataConverter
+	// PageSize enables reading the changes from the storage page by page instead of iterating the tree in memory
+	PageSize int
 }
 
 type TreeExporter interface {
@@ -27,6 +31,7 @@ type treeExporter struct {
 	listExporter liststorage.Exporter
 	treeExporter treestorage.Exporter
 	converter    DataConverter
+	pageSize     int
 }
 
 func NewTreeExporter(params TreeExporterParams) TreeExporter {
@@ -34,6 +39,7 @@ func NewTreeExporter(params TreeExporterParams) TreeExporter {
 		listExporter: params.ListStorageExporter,
 		treeExporter: params.TreeStorageExporter,
 		converter:    params.DataConverter,
+		pageSize:     params.PageSize,
 	}
 }
 
@@ -57,28 +63,64 @@ func (t *treeExporter) ExportUnencrypted(tree objecttree.ReadableObjectTree) (er
 		}
 		return treeStorage.AddRawChange(raw)
 	}
-	err = tree.IterateRoot(
-		func(change *objecttree.Change, decrypted []byte) (any, error) {
-			return t.converter.Unmarshall(decrypted)
-		},
-		func(change *objecttree.Change) bool {
-			if change.Id == tree.Id() {
-				err = putStorage(change)
+	exportChange := func(change *objecttree.Change) (err error) {
+		if change.Id == tree.Id() {
+			return putStorage(change)
+		}
+		data, err := t.converter.Marshall(change.Model)
+		if err != nil {
+			return
+		}
+		// that means that change is unencrypted
+		change.ReadKeyId = ""
+		change.Compression = treechangeproto.ChangeCompression_Uncompressed
+		change.Data = data
+		return putStorage(change)
+	}
+	if t.pageSize > 0 {
+		err = t.exportPaged(tree, exportChange)
+	} else {
+		err = tree.IterateRoot(
+			func(change *objecttree.Change, decrypted []byte) (any, error) {
+				return t.converter.Unmarshall(decrypted)
+			},
+			func(change *objecttree.Change) bool {
+				err = exportChange(change)
 				return err == nil
-			}
-			var data []byte
-			data, err = t.converter.Marshall(change.Model)
-			if err != nil {
-				return false
-			}
-			// that means that change is unencrypted
-			change.ReadKeyId = ""
-			change.Data = data
-			err = putStorage(change)
-			return err == nil
-		})
+			})
+	}
 	if err != nil {
 		return
 	}
 	return treeStorage.SetHeads(tree.Heads())
 }
+
+func (t *treeExporter) exportPaged(tree objecttree.ReadableObjectTree, exportChange func(change *objecttree.Change) error) (err error) {
+	iter, err := tree.NewPagedIterator(tree.Root().Id)
+	if err != nil {
+		return
+	}
+	for {
+		changes, err := iter.NextPage(context.Background(), t.pageSize)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		for _, change := range changes {
+			if change.Id != tree.Id() {
+				decrypted, err := iter.Unpack(change)
+				if err != nil {
+					return err
+				}
+				if change.Model, err = t.converter.Unmarshall(decrypted); err != nil {
+					return err
+				}
+			}
+			if err = exportChange(change); err != nil {
+				return err
+			}
+		}
+	}
+}
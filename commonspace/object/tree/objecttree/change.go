@@ -28,6 +28,11 @@ type Change struct {
 	DataType    string
 	IsSnapshot  bool
 	IsDerived   bool
+	// Compression is the algorithm which compressed Data before the encryption
+	Compression treechangeproto.ChangeCompression
+	// TreeKey is the generation of the own key of the tree with the id ReadKeyId, it is set in the root
+	// of the tree, in the change rotating the key and in the snapshots
+	TreeKey *treechangeproto.TreeKey
 
 	// iterator helpers
 	visited          bool
@@ -42,12 +47,14 @@ func NewChange(id string, identity crypto.PubKey, ch *treechangeproto.TreeChange
 		Timestamp:   ch.Timestamp,
 		ReadKeyId:   ch.ReadKeyId,
 		Id:          id,
-		Data:        ch.ChangesData,
+		Data:        changesData(ch),
 		SnapshotId:  ch.SnapshotBaseId,
 		IsSnapshot:  ch.IsSnapshot,
 		Identity:    identity,
 		Signature:   signature,
 		DataType:    ch.DataType,
+		Compression: ch.Compression,
+		TreeKey:     ch.TreeKey,
 	}
 }
 
@@ -68,6 +75,7 @@ func NewChangeFromRoot(id string, identity crypto.PubKey, ch *treechangeproto.Ro
 		Data:       data,
 		Model:      changeInfo,
 		IsDerived:  isDerived,
+		TreeKey:    ch.TreeKey,
 	}
 }
 
@@ -21,6 +21,9 @@ type BuilderContent struct {
 	Content        []byte
 	Timestamp      int64
 	DataType       string
+	Compression    treechangeproto.ChangeCompression
+	// TreeKey is the generation of the tree key with the id ReadKeyId which is saved in the change
+	TreeKey *treechangeproto.TreeKey
 }
 
 type InitialContent struct {
@@ -31,6 +34,7 @@ type InitialContent struct {
 	ChangeType    string
 	ChangePayload []byte
 	Timestamp     int64
+	TreeKey       *treechangeproto.TreeKey
 }
 
 type InitialDerivedContent struct {
@@ -119,6 +123,7 @@ func (c *changeBuilder) BuildRoot(payload InitialContent) (ch *Change, rawIdChan
 		ChangePayload: payload.ChangePayload,
 		SpaceId:       payload.SpaceId,
 		Seed:          payload.Seed,
+		TreeKey:       payload.TreeKey,
 	}
 	marshalledChange, err := proto.Marshal(change)
 	if err != nil {
@@ -192,17 +197,20 @@ func (c *changeBuilder) Build(payload BuilderContent) (ch *Change, rawIdChange *
 		Identity:       identity,
 		IsSnapshot:     payload.IsSnapshot,
 		DataType:       payload.DataType,
+		Compression:    payload.Compression,
+		TreeKey:        payload.TreeKey,
+	}
+	content, err := compress(payload.Compression, payload.Content)
+	if err != nil {
+		return
 	}
 	if payload.ReadKey != nil {
-		var encrypted []byte
-		encrypted, err = payload.ReadKey.Encrypt(payload.Content)
+		content, err = payload.ReadKey.Encrypt(content)
 		if err != nil {
 			return
 		}
-		change.ChangesData = encrypted
-	} else {
-		change.ChangesData = payload.Content
 	}
+	setChangesData(change, content)
 	marshalledChange, err := proto.Marshal(change)
 	if err != nil {
 		return
@@ -243,13 +251,15 @@ func (c *changeBuilder) Marshall(ch *Change) (raw *treechangeproto.RawTreeChange
 		TreeHeadIds:    ch.PreviousIds,
 		AclHeadId:      ch.AclHeadId,
 		SnapshotBaseId: ch.SnapshotId,
-		ChangesData:    ch.Data,
 		ReadKeyId:      ch.ReadKeyId,
 		Timestamp:      ch.Timestamp,
 		Identity:       identity,
 		IsSnapshot:     ch.IsSnapshot,
 		DataType:       ch.DataType,
+		Compression:    ch.Compression,
+		TreeKey:        ch.TreeKey,
 	}
+	setChangesData(treeChange, ch.Data)
 	var marshalled []byte
 	marshalled, err = treeChange.Marshal()
 	if err != nil {
@@ -295,6 +305,11 @@ func (c *changeBuilder) unmarshallRawChange(raw *treechangeproto.RawTreeChange,
 	if err != nil {
 		return
 	}
+	// the changes compressed with the algorithms added later can't be read, so they are rejected
+	if !isKnownCompression(unmarshalled.Compression) {
+		err = ErrUnknownCompression
+		return
+	}
 	key, err = c.keys.PubKeyFromProto(unmarshalled.Identity)
 	if err != nil {
 		return
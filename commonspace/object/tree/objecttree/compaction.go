@@ -0,0 +1,200 @@
+package objecttree
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+)
+
+// CompactionPolicy defines which part of the tree history is kept in the storage,
+// the snapshot satisfying the policy which is the oldest one is used as the compaction point.
+// The zero value doesn't compact anything
+type CompactionPolicy struct {
+	// KeepSnapshots is the number of the latest snapshots in the snapshot path which are kept with their changes
+	KeepSnapshots int
+	// KeepDuration keeps all changes which could be made later than now - KeepDuration
+	KeepDuration time.Duration
+	// AckPeers are the peers which must acknowledge the tree before anything is compacted,
+	// usually the responsible nodes of the space from nodeconf
+	AckPeers []string
+}
+
+func (p CompactionPolicy) IsEmpty() bool {
+	return p.KeepSnapshots <= 0 && p.KeepDuration <= 0
+}
+
+// AckSnapshotPath records the snapshot path which the peer reported in the sync messages,
+// Compact keeps the changes after the common snapshot with the acknowledged paths of the policy peers.
+// The paths are kept only in memory, so after the tree is reloaded nothing is compacted
+// until the peers acknowledge it again
+func (ot *objectTree) AckSnapshotPath(peerId string, snapshotPath []string) {
+	// the peers without the tree get the compacted history from the root
+	if len(snapshotPath) == 0 {
+		return
+	}
+	if ot.ackedPaths == nil {
+		ot.ackedPaths = map[string][]string{}
+	}
+	ot.ackedPaths[peerId] = slices.Clone(snapshotPath)
+}
+
+// Compact removes from the storage the changes preceding the compaction point.
+// The compaction point is the oldest of the snapshots required by the policy and the common snapshots
+// with the paths acknowledged by the policy peers, so nothing is removed until all of them acknowledge the tree.
+// The root of the tree and the snapshots of the snapshot path are never removed
+func (ot *objectTree) Compact(policy CompactionPolicy) (removed int, err error) {
+	if policy.IsEmpty() || len(policy.AckPeers) == 0 {
+		return
+	}
+	peerPaths := make([][]string, 0, len(policy.AckPeers))
+	for _, peerId := range policy.AckPeers {
+		peerPath, ok := ot.ackedPaths[peerId]
+		if !ok {
+			return
+		}
+		peerPaths = append(peerPaths, peerPath)
+	}
+	// the compaction point is computed against the current path right before the storage is compacted
+	path := ot.SnapshotPath()
+	pointIdx, err := ot.compactionPoint(path, policy, peerPaths)
+	if err != nil {
+		return
+	}
+	compactedId, err := ot.treeStorage.CompactedSnapshotId()
+	if err != nil {
+		return
+	}
+	if compactedIdx := slices.Index(path, compactedId); compactedIdx != -1 && pointIdx >= compactedIdx {
+		return
+	}
+	if pointIdx >= len(path)-1 {
+		return
+	}
+	keep := make(map[string]struct{}, len(path))
+	for _, id := range path {
+		keep[id] = struct{}{}
+	}
+	ids, err := ot.changesBeforeSnapshot(path[pointIdx], keep)
+	if err != nil {
+		return
+	}
+	if err = ot.treeStorage.Compact(path[pointIdx], ids); err != nil {
+		return
+	}
+	ot.limiter.resetStoredChanges()
+	return len(ids), nil
+}
+
+// compactionPoint returns the index of the compaction point in the snapshot path
+func (ot *objectTree) compactionPoint(path []string, policy CompactionPolicy, peerPaths [][]string) (idx int, err error) {
+	last := len(path) - 1
+	if policy.KeepSnapshots > 1 {
+		idx = policy.KeepSnapshots - 1
+		if idx > last {
+			return last, nil
+		}
+	}
+	if policy.KeepDuration > 0 {
+		cutoff := time.Now().Add(-policy.KeepDuration).Unix()
+		// the changes after the snapshot made before the cutoff can be newer than cutoff, so we keep it
+		for ; idx < last; idx++ {
+			snapshot, err := ot.treeBuilder.loadChange(path[idx])
+			if err != nil {
+				return 0, err
+			}
+			if snapshot.Timestamp <= cutoff {
+				break
+			}
+		}
+	}
+	for _, peerPath := range peerPaths {
+		common, err := commonSnapshotForTwoPaths(path, peerPath)
+		if err != nil {
+			// the peer can't be synced without the full history
+			return last, nil
+		}
+		if commonIdx := slices.Index(path, common); commonIdx > idx {
+			idx = commonIdx
+		}
+	}
+	return
+}
+
+// changesBeforeSnapshot returns the ids of the stored changes preceding the snapshot except the ones from keep
+func (ot *objectTree) changesBeforeSnapshot(snapshotId string, keep map[string]struct{}) (ids []string, err error) {
+	ctx := context.Background()
+	snapshot, err := ot.treeBuilder.loadChange(snapshotId)
+	if err != nil {
+		return
+	}
+	visited := map[string]struct{}{snapshotId: {}}
+	stack := append([]string(nil), snapshot.PreviousIds...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, exists := visited[id]; exists {
+			continue
+		}
+		visited[id] = struct{}{}
+		has, err := ot.treeStorage.HasChange(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// the change was removed by the previous compaction
+		if !has {
+			continue
+		}
+		raw, err := ot.treeStorage.GetRawChange(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ch, err := ot.changeBuilder.Unmarshall(raw, false)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := keep[id]; !exists && id != ot.id {
+			ids = append(ids, id)
+		}
+		stack = append(stack, ch.PreviousIds...)
+	}
+	return
+}
+
+// compactedAfter returns the index of the compacted snapshot in the path if the changes after the snapshot
+// can't be loaded from the storage anymore, otherwise it returns -1
+func (ot *objectTree) compactedAfter(path []string, snapshotId string) (int, error) {
+	compactedId, err := ot.treeStorage.CompactedSnapshotId()
+	if err != nil || compactedId == "" {
+		return -1, err
+	}
+	compactedIdx := slices.Index(path, compactedId)
+	if compactedIdx == -1 || slices.Index(path, snapshotId) <= compactedIdx {
+		return -1, nil
+	}
+	return compactedIdx, nil
+}
+
+// compactedChanges returns the history which is left after the compaction: the root of the tree,
+// the snapshots of the path preceding the compacted snapshot and all changes from the compacted snapshot.
+// The peer receiving them builds the tree from the latest snapshot, because the changes before the compacted
+// snapshot are missing
+func (ot *objectTree) compactedChanges(path []string, compactedIdx int) ([]*treechangeproto.RawTreeChangeWithId, error) {
+	ctx := context.Background()
+	changes := make([]*treechangeproto.RawTreeChangeWithId, 0, len(path)-compactedIdx)
+	// starting from the root, so the older snapshots go first
+	for i := len(path) - 1; i > compactedIdx; i-- {
+		raw, err := ot.treeStorage.GetRawChange(ctx, path[i])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, raw)
+	}
+	loaded, err := ot.rawChangeLoader.Load(path[compactedIdx], ot.tree, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(changes, loaded...), nil
+}
@@ -0,0 +1,85 @@
+package objecttree
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+)
+
+var (
+	ErrUnknownCompression   = errors.New("change data is compressed with unknown algorithm")
+	ErrDecompressedTooLarge = errors.New("decompressed change data exceeds the maximum size")
+)
+
+// maxDecompressedSize limits the size of the decompressed change data, so small changes can't be decompressed to the huge ones
+const maxDecompressedSize = 64 << 20
+
+func isKnownCompression(compression treechangeproto.ChangeCompression) bool {
+	_, known := treechangeproto.ChangeCompression_name[int32(compression)]
+	return known
+}
+
+// compress compresses the change data, it should be done before the encryption
+func compress(compression treechangeproto.ChangeCompression, data []byte) ([]byte, error) {
+	switch compression {
+	case treechangeproto.ChangeCompression_Uncompressed:
+		return data, nil
+	case treechangeproto.ChangeCompression_Flate:
+		buf := &bytes.Buffer{}
+		w, err := flate.NewWriter(buf, flate.DefaultCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = w.Write(data); err != nil {
+			return nil, err
+		}
+		if err = w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, ErrUnknownCompression
+	}
+}
+
+// decompress decompresses the change data after the decryption
+func decompress(compression treechangeproto.ChangeCompression, data []byte) ([]byte, error) {
+	switch compression {
+	case treechangeproto.ChangeCompression_Uncompressed:
+		return data, nil
+	case treechangeproto.ChangeCompression_Flate:
+		r := flate.NewReader(bytes.NewReader(data))
+		defer r.Close()
+		decompressed, err := io.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(decompressed) > maxDecompressedSize {
+			return nil, ErrDecompressedTooLarge
+		}
+		return decompressed, nil
+	default:
+		return nil, ErrUnknownCompression
+	}
+}
+
+// changesData returns the payload of the change, the compressed payload is kept in the separate field,
+// because the clients which don't know the compression would read it as the uncompressed one
+func changesData(ch *treechangeproto.TreeChange) []byte {
+	if ch.Compression != treechangeproto.ChangeCompression_Uncompressed {
+		return ch.CompressedChangesData
+	}
+	return ch.ChangesData
+}
+
+// setChangesData sets the payload of the change to the field depending on the compression
+func setChangesData(ch *treechangeproto.TreeChange, data []byte) {
+	if ch.Compression != treechangeproto.ChangeCompression_Uncompressed {
+		ch.CompressedChangesData = data
+		return
+	}
+	ch.ChangesData = data
+}
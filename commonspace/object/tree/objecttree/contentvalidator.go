@@ -0,0 +1,157 @@
+package objecttree
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+)
+
+var (
+	ErrInvalidContent                    = errors.New("the content of the change is invalid")
+	ErrContentValidationNotSupported     = errors.New("the tree doesn't support content validation")
+	errContentValidatorAlreadyRegistered = errors.New("content validator is already registered")
+)
+
+// ContentValidatorFunc checks the decrypted data of the change, the change is rejected if it returns an error
+type ContentValidatorFunc func(change *Change, decrypted []byte) error
+
+// ContentValidationError is returned when the content of the change is rejected by a validator,
+// it matches ErrInvalidContent and ErrHasInvalidChanges
+type ContentValidationError struct {
+	TreeId   string
+	ChangeId string
+	// Identity is the author of the change
+	Identity string
+	DataType string
+	Err      error
+}
+
+func (e *ContentValidationError) Error() string {
+	return fmt.Sprintf("invalid content of change %s in tree %s: %v", e.ChangeId, e.TreeId, e.Err)
+}
+
+func (e *ContentValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ContentValidationError) Is(target error) bool {
+	return target == ErrInvalidContent || target == ErrHasInvalidChanges
+}
+
+// ContentValidators contains the validators of the content registered for the change types of the trees
+// and for the data types of the changes. It should be filled before it is used by the trees
+type ContentValidators struct {
+	changeTypes map[string]ContentValidatorFunc
+	dataTypes   map[string]ContentValidatorFunc
+}
+
+func NewContentValidators() *ContentValidators {
+	return &ContentValidators{
+		changeTypes: map[string]ContentValidatorFunc{},
+		dataTypes:   map[string]ContentValidatorFunc{},
+	}
+}
+
+// RegisterChangeType registers the validator for all changes of the trees with the change type from the root
+func (v *ContentValidators) RegisterChangeType(changeType string, validate ContentValidatorFunc) error {
+	if _, exists := v.changeTypes[changeType]; exists {
+		return errContentValidatorAlreadyRegistered
+	}
+	v.changeTypes[changeType] = validate
+	return nil
+}
+
+// RegisterDataType registers the validator for the changes with the data type
+func (v *ContentValidators) RegisterDataType(dataType string, validate ContentValidatorFunc) error {
+	if _, exists := v.dataTypes[dataType]; exists {
+		return errContentValidatorAlreadyRegistered
+	}
+	v.dataTypes[dataType] = validate
+	return nil
+}
+
+func (v *ContentValidators) hasValidators(changeType, dataType string) bool {
+	if v == nil {
+		return false
+	}
+	_, changeTypeExists := v.changeTypes[changeType]
+	_, dataTypeExists := v.dataTypes[dataType]
+	return changeTypeExists || dataTypeExists
+}
+
+// Validate runs the validator of the change type and then the validator of the data type of the change
+func (v *ContentValidators) Validate(treeId, changeType string, change *Change, decrypted []byte) error {
+	if v == nil {
+		return nil
+	}
+	for _, validate := range []ContentValidatorFunc{v.changeTypes[changeType], v.dataTypes[change.DataType]} {
+		if validate == nil {
+			continue
+		}
+		if err := validate(change, decrypted); err != nil {
+			validationErr := &ContentValidationError{
+				TreeId:   treeId,
+				ChangeId: change.Id,
+				DataType: change.DataType,
+				Err:      err,
+			}
+			if change.Identity != nil {
+				validationErr.Identity = change.Identity.Account()
+			}
+			return validationErr
+		}
+	}
+	return nil
+}
+
+// WithContentValidators returns the function building the trees which validate the content of the added changes
+func WithContentValidators(build BuildObjectTreeFunc, validators *ContentValidators) BuildObjectTreeFunc {
+	return func(treeStorage treestorage.TreeStorage, aclList list.AclList) (ObjectTree, error) {
+		objTree, err := build(treeStorage, aclList)
+		if err != nil {
+			return nil, err
+		}
+		ot, ok := objTree.(*objectTree)
+		if !ok {
+			return nil, ErrContentValidationNotSupported
+		}
+		ot.contentValidators = validators
+		return ot, nil
+	}
+}
+
+// validateContent decrypts and validates the content of the change which is not yet in the tree
+func (ot *objectTree) validateContent(ch *Change) (err error) {
+	changeType := ot.ChangeInfo().ChangeType
+	if ch.Id == ot.id || !ot.contentValidators.hasValidators(changeType, ch.DataType) {
+		return
+	}
+	if _, exists := ot.keys[ch.ReadKeyId]; ch.ReadKeyId != "" && !exists {
+		// the change may be encrypted with the key which we didn't read yet
+		ot.aclList.RLock()
+		err = ot.readKeysFromAclState(ot.aclList.AclState())
+		if err == nil {
+			err = ot.readChangeTreeKey(ch, ot.aclList.AclState())
+		}
+		ot.aclList.RUnlock()
+		if err != nil {
+			return skipNoReadKey(err)
+		}
+	}
+	decrypted, err := ot.decrypt(ch)
+	if err != nil {
+		return skipNoReadKey(err)
+	}
+	return ot.contentValidators.Validate(ot.id, changeType, ch, decrypted)
+}
+
+// skipNoReadKey doesn't reject the change which can't be decrypted without the read key,
+// e.g. the member didn't receive the new read key yet, so it is added without the validation
+func skipNoReadKey(err error) error {
+	if errors.Is(err, list.ErrNoReadKey) {
+		return nil
+	}
+	return err
+}
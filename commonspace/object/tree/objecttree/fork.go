@@ -0,0 +1,222 @@
+package objecttree
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+)
+
+var (
+	ErrIncorrectForkVersion  = errors.New("the version tree doesn't end with the forked change")
+	ErrIncompleteForkVersion = errors.New("the version tree doesn't contain the previous changes of the copied change")
+)
+
+// ObjectTreeForkPayload is a payload to be passed when we are creating the tree from a version of another tree
+type ObjectTreeForkPayload struct {
+	ObjectTreeCreatePayload
+	// ChangeId is the id of the last change of the source tree included in the fork
+	ChangeId string
+	// WithHistory tells if the changes of the source tree are copied to the fork,
+	// otherwise the fork starts with the single snapshot built by StateBuilder
+	WithHistory bool
+	// Convert and StateBuilder build the snapshot if the history is not copied
+	Convert      ChangeConvertFunc
+	StateBuilder VersionStateBuilder
+	// DataType contains additional info about the data in the snapshot
+	DataType string
+	// Ordering defines the order of the concurrent changes of the source tree
+	Ordering ChangeOrdering
+}
+
+// ForkCreatePayload returns the payload of the root of the fork,
+// the change payload of the root contains the provenance of the fork
+func ForkCreatePayload(sourceTreeId string, payload ObjectTreeForkPayload) (create ObjectTreeCreatePayload, err error) {
+	forkInfo := &treechangeproto.TreeForkInfo{
+		SourceTreeId:   sourceTreeId,
+		SourceChangeId: payload.ChangeId,
+		ChangePayload:  payload.ChangePayload,
+	}
+	create = payload.ObjectTreeCreatePayload
+	create.ChangePayload, err = forkInfo.Marshal()
+	return
+}
+
+// ForkInfo returns the provenance of the fork stored in the change payload of its root
+func ForkInfo(changeInfo *treechangeproto.TreeChangeInfo) (forkInfo *treechangeproto.TreeForkInfo, err error) {
+	forkInfo = &treechangeproto.TreeForkInfo{}
+	err = forkInfo.Unmarshal(changeInfo.ChangePayload)
+	return
+}
+
+// ForkObjectTree adds to the root of the fork the changes with the state of the version tree,
+// the changes are encrypted with the keys of the fork and signed with the key from the payload.
+// The version tree should be built with the forked change as its only head,
+// starting from the root with BuildFromRoot if the history is copied. The copied history keeps the graph of the version tree:
+// the previous changes and the snapshots of the copied changes are replaced with their copies in the fork
+func ForkObjectTree(
+	version ReadableObjectTree,
+	root *treechangeproto.RawTreeChangeWithId,
+	payload ObjectTreeForkPayload,
+	aclList list.AclList) (res treestorage.TreeStorageCreatePayload, err error) {
+	heads := version.Heads()
+	if len(heads) != 1 || heads[0] != payload.ChangeId {
+		err = ErrIncorrectForkVersion
+		return
+	}
+	storage, err := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+	if err != nil {
+		return
+	}
+	objTree, err := BuildObjectTree(storage, aclList)
+	if err != nil {
+		return
+	}
+	fork := objTree.(*objectTree)
+	res = treestorage.TreeStorageCreatePayload{
+		RootRawChange: root,
+		Changes:       []*treechangeproto.RawTreeChangeWithId{root},
+	}
+	var added []*treechangeproto.RawTreeChangeWithId
+	if payload.WithHistory {
+		added, err = fork.addHistory(version, payload)
+	} else {
+		added, err = fork.addSnapshot(version, payload)
+	}
+	if err != nil {
+		return
+	}
+	res.Changes = append(res.Changes, added...)
+	res.Heads = fork.Heads()
+	return
+}
+
+// addHistory copies the changes of the version to the fork in the order of iteration,
+// so the previous changes of each change are already copied
+func (ot *objectTree) addHistory(version ReadableObjectTree, payload ObjectTreeForkPayload) (added []*treechangeproto.RawTreeChangeWithId, err error) {
+	var (
+		forkIds = map[string]string{version.Id(): ot.id}
+		addErr  error
+	)
+	mapIds := func(ids []string) ([]string, error) {
+		mapped := make([]string, 0, len(ids))
+		for _, id := range ids {
+			forkId, exists := forkIds[id]
+			if !exists {
+				return nil, ErrIncompleteForkVersion
+			}
+			mapped = append(mapped, forkId)
+		}
+		return mapped, nil
+	}
+	convert := func(change *Change, decrypted []byte) (any, error) {
+		return decrypted, nil
+	}
+	err = version.IterateRoot(convert, func(change *Change) bool {
+		// the fork has its own root
+		if change.Id == version.Id() {
+			return true
+		}
+		var (
+			prevIds, snapshotIds []string
+			raw                  *treechangeproto.RawTreeChangeWithId
+		)
+		if prevIds, addErr = mapIds(change.PreviousIds); addErr != nil {
+			return false
+		}
+		if snapshotIds, addErr = mapIds([]string{change.SnapshotId}); addErr != nil {
+			return false
+		}
+		data, _ := change.Model.([]byte)
+		raw, addErr = ot.addForkedChange(SignableChangeContent{
+			Data:        data,
+			Key:         payload.PrivKey,
+			IsSnapshot:  change.IsSnapshot,
+			IsEncrypted: payload.IsEncrypted,
+			Timestamp:   change.Timestamp,
+			DataType:    change.DataType,
+			Compression: change.Compression,
+		}, prevIds, snapshotIds[0])
+		if addErr != nil {
+			return false
+		}
+		forkIds[change.Id] = raw.Id
+		added = append(added, raw)
+		return true
+	})
+	if err == nil {
+		err = addErr
+	}
+	return
+}
+
+// addForkedChange adds the change with the given previous changes and snapshot instead of the heads and the root of the tree
+func (ot *objectTree) addForkedChange(content SignableChangeContent, prevIds []string, snapshotId string) (raw *treechangeproto.RawTreeChangeWithId, err error) {
+	cnt, err := ot.prepareBuilderContent(content)
+	if err != nil {
+		return
+	}
+	cnt.TreeHeadIds = prevIds
+	cnt.SnapshotBaseId = snapshotId
+	_, raw, err = ot.changeBuilder.Build(cnt)
+	if err != nil {
+		return
+	}
+	res, err := ot.AddRawChanges(context.Background(), RawChangesPayload{
+		NewHeads:   []string{raw.Id},
+		RawChanges: []*treechangeproto.RawTreeChangeWithId{raw},
+	})
+	if err != nil {
+		return
+	}
+	if len(res.Added) != 1 {
+		return nil, ErrIncompleteForkVersion
+	}
+	return
+}
+
+// addSnapshot adds the single snapshot with the state of the version built by the state builder
+func (ot *objectTree) addSnapshot(version ReadableObjectTree, payload ObjectTreeForkPayload) (added []*treechangeproto.RawTreeChangeWithId, err error) {
+	content, err := snapshotContent(version, payload)
+	if err != nil {
+		return
+	}
+	res, err := ot.AddContent(context.Background(), content)
+	if err != nil {
+		return
+	}
+	return res.Added, nil
+}
+
+func snapshotContent(version ReadableObjectTree, payload ObjectTreeForkPayload) (content SignableChangeContent, err error) {
+	if payload.StateBuilder == nil || payload.Convert == nil {
+		err = ErrNoStateBuilder
+		return
+	}
+	var applyErr error
+	err = version.IterateRoot(payload.Convert, func(change *Change) bool {
+		applyErr = payload.StateBuilder.Apply(change)
+		return applyErr == nil
+	})
+	if err != nil {
+		return
+	}
+	if applyErr != nil {
+		err = applyErr
+		return
+	}
+	data, err := payload.StateBuilder.Snapshot()
+	if err != nil {
+		return
+	}
+	return SignableChangeContent{
+		Data:        data,
+		Key:         payload.PrivKey,
+		IsSnapshot:  true,
+		IsEncrypted: payload.IsEncrypted,
+		Timestamp:   payload.Timestamp,
+		DataType:    payload.DataType,
+	}, nil
+}
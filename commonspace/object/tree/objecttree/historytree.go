@@ -31,6 +31,7 @@ func (h *historyTree) rebuild(params HistoryTreeParams) (err error) {
 		beforeId = params.BeforeId
 		include  = params.IncludeBeforeId
 		full     = params.BuildFullTree
+		fromRoot = params.BuildFromRoot
 	)
 	h.treeBuilder.Reset()
 	if full {
@@ -55,6 +56,10 @@ func (h *historyTree) rebuild(params HistoryTreeParams) (err error) {
 		heads = beforeChange.PreviousIds
 	}
 
+	if fromRoot {
+		h.tree, err = h.treeBuilder.BuildFullFrom(heads)
+		return
+	}
 	h.tree, err = h.treeBuilder.build(heads, nil, nil)
 	return
 }
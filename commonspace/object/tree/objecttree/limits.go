@@ -0,0 +1,354 @@
+package objecttree
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/exp/slices"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+)
+
+var (
+	ErrChangeTooLarge     = errors.New("the change exceeds the maximum size")
+	ErrTooManyChanges     = errors.New("the tree exceeds the maximum number of changes")
+	ErrChangeRateExceeded = errors.New("the identity exceeds the maximum number of changes in the time window")
+	ErrPeerRateExceeded   = errors.New("the peer exceeds the maximum number of changes in the time window")
+	ErrLimitsNotSupported = errors.New("the tree doesn't support limits")
+)
+
+const unknownStoredChanges = -1
+
+// LimitMetric counts the changes rejected by the limits, err is one of the errors of the limits
+type LimitMetric interface {
+	ChangeRejected(err error)
+}
+
+// TreeLimits restrict the size of the changes and the number of the changes in the tree, the zero value means no limit
+type TreeLimits struct {
+	// MaxChangeSize is the maximum size of the raw change in bytes
+	MaxChangeSize int
+	// MaxChanges is the maximum number of the changes stored in the tree
+	MaxChanges int
+	// MaxIdentityChanges is the maximum number of the changes of one identity in the tree made in IdentityWindow,
+	// the window is counted on the timestamps of the changes, so all peers reject the same changes
+	MaxIdentityChanges int
+	IdentityWindow     time.Duration
+	// MaxPeerChanges is the maximum number of the changes which one peer can send to the tree in PeerWindow,
+	// the sync handlers reject the messages exceeding it, so the peer sends the changes again later
+	MaxPeerChanges int
+	PeerWindow     time.Duration
+	// Metric is optional
+	Metric LimitMetric
+}
+
+func (l TreeLimits) IsEmpty() bool {
+	return l.MaxChangeSize <= 0 && l.MaxChanges <= 0 && !l.limitsIdentityRate() && (l.MaxPeerChanges <= 0 || l.PeerWindow <= 0)
+}
+
+// CheckChangesSize returns ErrChangeTooLarge if any of the changes exceeds the maximum size,
+// it can be used to reject the changes before they are unmarshalled
+func (l TreeLimits) CheckChangesSize(changes []*treechangeproto.RawTreeChangeWithId) error {
+	if l.MaxChangeSize <= 0 {
+		return nil
+	}
+	for _, ch := range changes {
+		if len(ch.RawChange) > l.MaxChangeSize {
+			return l.reject(ErrChangeTooLarge)
+		}
+	}
+	return nil
+}
+
+func (l TreeLimits) limitsIdentityRate() bool {
+	return l.MaxIdentityChanges > 0 && l.IdentityWindow > 0
+}
+
+func (l TreeLimits) reject(err error) error {
+	if l.Metric != nil {
+		l.Metric.ChangeRejected(err)
+	}
+	return err
+}
+
+// WithLimits returns the function building the trees which reject the changes exceeding the limits
+func WithLimits(build BuildObjectTreeFunc, limits TreeLimits) BuildObjectTreeFunc {
+	return func(treeStorage treestorage.TreeStorage, aclList list.AclList) (ObjectTree, error) {
+		objTree, err := build(treeStorage, aclList)
+		if err != nil {
+			return nil, err
+		}
+		ot, ok := objTree.(*objectTree)
+		if !ok {
+			return nil, ErrLimitsNotSupported
+		}
+		if !limits.IsEmpty() {
+			ot.limiter = newTreeLimiter(limits)
+		}
+		return ot, nil
+	}
+}
+
+// treeLimiter keeps the state of the tree needed to check the limits
+type treeLimiter struct {
+	TreeLimits
+	// storedChanges is counted when it is needed for the first time
+	storedChanges int
+	// identityTimestamps contains the sorted timestamps of the changes in the tree by their identities,
+	// it is collected when it is needed for the first time and after the tree is rebuilt
+	identityTimestamps map[string][]int64
+}
+
+func newTreeLimiter(limits TreeLimits) *treeLimiter {
+	return &treeLimiter{
+		TreeLimits:    limits,
+		storedChanges: unknownStoredChanges,
+	}
+}
+
+// checkNewChanges checks if the changes which are not yet in the tree can be added
+func (ot *objectTree) checkNewChanges(changes []*Change) (err error) {
+	l := ot.limiter
+	if l == nil || len(changes) == 0 {
+		return
+	}
+	if l.MaxChanges > 0 {
+		if l.storedChanges == unknownStoredChanges {
+			if l.storedChanges, err = ot.countStoredChanges(); err != nil {
+				return
+			}
+		}
+		if l.storedChanges+len(changes) > l.MaxChanges {
+			return l.reject(ErrTooManyChanges)
+		}
+	}
+	if !l.limitsIdentityRate() {
+		return
+	}
+	if l.identityTimestamps == nil {
+		l.collectIdentityTimestamps(ot.tree)
+	}
+	window := int64(l.IdentityWindow / time.Second)
+	if window < 1 {
+		window = 1
+	}
+	for identity, added := range changesTimestamps(changes) {
+		timestamps := mergeTimestamps(l.identityTimestamps[identity], added)
+		for _, ts := range added {
+			// counting the changes of the identity in the window ending with the change
+			from := sort.Search(len(timestamps), func(i int) bool { return timestamps[i] > ts-window })
+			to := sort.Search(len(timestamps), func(i int) bool { return timestamps[i] > ts })
+			if to-from > l.MaxIdentityChanges {
+				return l.reject(ErrChangeRateExceeded)
+			}
+		}
+	}
+	return
+}
+
+// changesStored updates the number of the changes in the tree after they were saved to the storage
+func (l *treeLimiter) changesStored(count int) {
+	if l != nil && l.storedChanges != unknownStoredChanges {
+		l.storedChanges += count
+	}
+}
+
+// changesAdded adds the timestamps of the changes added to the tree, after the rebuild they are collected again
+func (l *treeLimiter) changesAdded(changes []*Change, mode Mode) {
+	if l == nil || l.identityTimestamps == nil {
+		return
+	}
+	if mode == Rebuild {
+		l.identityTimestamps = nil
+		return
+	}
+	for identity, added := range changesTimestamps(changes) {
+		l.identityTimestamps[identity] = mergeTimestamps(l.identityTimestamps[identity], added)
+	}
+}
+
+// treeRebuilt makes the limiter collect the timestamps of the identities again
+func (l *treeLimiter) treeRebuilt() {
+	if l != nil {
+		l.identityTimestamps = nil
+	}
+}
+
+func (l *treeLimiter) collectIdentityTimestamps(tree *Tree) {
+	changes := make([]*Change, 0, len(tree.attached))
+	for _, ch := range tree.attached {
+		changes = append(changes, ch)
+	}
+	l.identityTimestamps = changesTimestamps(changes)
+}
+
+// changesTimestamps returns the sorted timestamps of the changes by their identities
+func changesTimestamps(changes []*Change) map[string][]int64 {
+	timestamps := map[string][]int64{}
+	for _, ch := range changes {
+		if ch.Identity == nil {
+			continue
+		}
+		identity := ch.Identity.Account()
+		timestamps[identity] = append(timestamps[identity], ch.Timestamp)
+	}
+	for _, ts := range timestamps {
+		slices.Sort(ts)
+	}
+	return timestamps
+}
+
+// mergeTimestamps returns the new sorted slice with the timestamps of both slices
+func mergeTimestamps(a, b []int64) []int64 {
+	merged := make([]int64, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] <= b[0] {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
+
+// resetStoredChanges makes the limiter count the changes again, e.g. after they were removed from the storage
+func (l *treeLimiter) resetStoredChanges() {
+	if l != nil {
+		l.storedChanges = unknownStoredChanges
+	}
+}
+
+// countStoredChanges counts the changes in the storage starting from the oldest snapshot from which the tree can be loaded
+func (ot *objectTree) countStoredChanges() (count int, err error) {
+	oldest, err := ot.treeStorage.CompactedSnapshotId()
+	if err != nil {
+		return
+	}
+	if oldest == "" {
+		oldest = ot.id
+	}
+	heads, err := ot.treeStorage.Heads()
+	if err != nil {
+		return
+	}
+	var (
+		ctx     = context.Background()
+		visited = map[string]struct{}{oldest: {}}
+		stack   = append([]string(nil), heads...)
+	)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, exists := visited[id]; exists {
+			continue
+		}
+		raw, err := ot.treeStorage.GetRawChange(ctx, id)
+		if err != nil {
+			// the missing changes are not counted in the same way as they are not loaded to the tree
+			continue
+		}
+		ch, err := ot.changeBuilder.Unmarshall(raw, false)
+		if err != nil {
+			continue
+		}
+		visited[id] = struct{}{}
+		stack = append(stack, ch.PreviousIds...)
+	}
+	return len(visited), nil
+}
+
+// PeerRateLimiter counts the changes sent by the peers to one tree, the sync handlers use it before the tree is locked
+// to guard the tree from the peers flooding it, the rate of the authors of the changes is checked by the tree
+type PeerRateLimiter struct {
+	limits TreeLimits
+	mu     sync.Mutex
+	peers  map[string][]sentChanges
+	now    func() time.Time
+}
+
+type sentChanges struct {
+	at    time.Time
+	count int
+}
+
+// NewPeerRateLimiter returns nil if the rate is not limited, the nil limiter allows everything
+func NewPeerRateLimiter(limits TreeLimits) *PeerRateLimiter {
+	if limits.MaxPeerChanges <= 0 || limits.PeerWindow <= 0 {
+		return nil
+	}
+	return &PeerRateLimiter{
+		limits: limits,
+		peers:  map[string][]sentChanges{},
+		now:    time.Now,
+	}
+}
+
+// Allow counts the changes sent by the peer or returns ErrPeerRateExceeded if the peer exceeds the rate
+func (r *PeerRateLimiter) Allow(peerId string, changes int) error {
+	if r == nil || changes == 0 {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var (
+		now   = r.now()
+		sent  = r.peers[peerId]
+		total int
+	)
+	for len(sent) > 0 && now.Sub(sent[0].at) >= r.limits.PeerWindow {
+		sent = sent[1:]
+	}
+	for _, s := range sent {
+		total += s.count
+	}
+	if total+changes > r.limits.MaxPeerChanges {
+		r.peers[peerId] = sent
+		return r.limits.reject(ErrPeerRateExceeded)
+	}
+	r.peers[peerId] = append(sent, sentChanges{at: now, count: changes})
+	return nil
+}
+
+type prometheusLimitMetric struct {
+	rejected *prometheus.CounterVec
+}
+
+// NewLimitMetric registers the counter of the rejected changes labeled by the exceeded limit
+func NewLimitMetric(reg *prometheus.Registry) (LimitMetric, error) {
+	m := &prometheusLimitMetric{
+		rejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "anysync",
+			Subsystem: "tree",
+			Name:      "rejected_changes",
+			Help:      "changes rejected by the tree limits",
+		}, []string{"limit"}),
+	}
+	if err := reg.Register(m.rejected); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *prometheusLimitMetric) ChangeRejected(err error) {
+	var limit string
+	switch err {
+	case ErrChangeTooLarge:
+		limit = "change_size"
+	case ErrTooManyChanges:
+		limit = "tree_changes"
+	case ErrChangeRateExceeded:
+		limit = "identity_rate"
+	case ErrPeerRateExceeded:
+		limit = "peer_rate"
+	default:
+		limit = "unknown"
+	}
+	m.rejected.WithLabelValues(limit).Inc()
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -destination mock_objecttree/mock_objecttree.go github.com/anyproto/any-sync/commonspace/object/tree/objecttree ObjectTree
 //
+
 // Package mock_objecttree is a generated GoMock package.
 package mock_objecttree
 
@@ -17,6 +18,7 @@ import (
 	objecttree "github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	treechangeproto "github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	treestorage "github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	crypto "github.com/anyproto/any-sync/util/crypto"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,6 +45,18 @@ func (m *MockObjectTree) EXPECT() *MockObjectTreeMockRecorder {
 	return m.recorder
 }
 
+// AckSnapshotPath mocks base method.
+func (m *MockObjectTree) AckSnapshotPath(arg0 string, arg1 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AckSnapshotPath", arg0, arg1)
+}
+
+// AckSnapshotPath indicates an expected call of AckSnapshotPath.
+func (mr *MockObjectTreeMockRecorder) AckSnapshotPath(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckSnapshotPath", reflect.TypeOf((*MockObjectTree)(nil).AckSnapshotPath), arg0, arg1)
+}
+
 // AclList mocks base method.
 func (m *MockObjectTree) AclList() list.AclList {
 	m.ctrl.T.Helper()
@@ -116,6 +130,21 @@ func (mr *MockObjectTreeMockRecorder) ChangesAfterCommonSnapshot(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCommonSnapshot", reflect.TypeOf((*MockObjectTree)(nil).ChangesAfterCommonSnapshot), arg0, arg1)
 }
 
+// ChangesDiff mocks base method.
+func (m *MockObjectTree) ChangesDiff(arg0, arg1 []string, arg2 func(*objecttree.Change, []byte) (any, error)) ([]*objecttree.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesDiff", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*objecttree.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangesDiff indicates an expected call of ChangesDiff.
+func (mr *MockObjectTreeMockRecorder) ChangesDiff(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesDiff", reflect.TypeOf((*MockObjectTree)(nil).ChangesDiff), arg0, arg1, arg2)
+}
+
 // Close mocks base method.
 func (m *MockObjectTree) Close() error {
 	m.ctrl.T.Helper()
@@ -130,6 +159,21 @@ func (mr *MockObjectTreeMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockObjectTree)(nil).Close))
 }
 
+// Compact mocks base method.
+func (m *MockObjectTree) Compact(arg0 objecttree.CompactionPolicy) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockObjectTreeMockRecorder) Compact(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockObjectTree)(nil).Compact), arg0)
+}
+
 // Debug mocks base method.
 func (m *MockObjectTree) Debug(arg0 objecttree.DescriptionParser) (objecttree.DebugInfo, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +346,21 @@ func (mr *MockObjectTreeMockRecorder) Lock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockObjectTree)(nil).Lock))
 }
 
+// NewPagedIterator mocks base method.
+func (m *MockObjectTree) NewPagedIterator(arg0 string) (objecttree.PagedIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPagedIterator", arg0)
+	ret0, _ := ret[0].(objecttree.PagedIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewPagedIterator indicates an expected call of NewPagedIterator.
+func (mr *MockObjectTreeMockRecorder) NewPagedIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPagedIterator", reflect.TypeOf((*MockObjectTree)(nil).NewPagedIterator), arg0)
+}
+
 // PrepareChange mocks base method.
 func (m *MockObjectTree) PrepareChange(arg0 objecttree.SignableChangeContent) (*treechangeproto.RawTreeChangeWithId, error) {
 	m.ctrl.T.Helper()
@@ -341,6 +400,21 @@ func (mr *MockObjectTreeMockRecorder) RUnlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RUnlock", reflect.TypeOf((*MockObjectTree)(nil).RUnlock))
 }
 
+// RevertTo mocks base method.
+func (m *MockObjectTree) RevertTo(arg0 context.Context, arg1 objecttree.RevertParams) (objecttree.AddResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertTo", arg0, arg1)
+	ret0, _ := ret[0].(objecttree.AddResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertTo indicates an expected call of RevertTo.
+func (mr *MockObjectTreeMockRecorder) RevertTo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTo", reflect.TypeOf((*MockObjectTree)(nil).RevertTo), arg0, arg1)
+}
+
 // Root mocks base method.
 func (m *MockObjectTree) Root() *objecttree.Change {
 	m.ctrl.T.Helper()
@@ -355,6 +429,33 @@ func (mr *MockObjectTreeMockRecorder) Root() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Root", reflect.TypeOf((*MockObjectTree)(nil).Root))
 }
 
+// SetSnapshotPolicy mocks base method.
+func (m *MockObjectTree) SetSnapshotPolicy(arg0 objecttree.SnapshotPolicy, arg1 objecttree.SnapshotFunc) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSnapshotPolicy", arg0, arg1)
+}
+
+// SetSnapshotPolicy indicates an expected call of SetSnapshotPolicy.
+func (mr *MockObjectTreeMockRecorder) SetSnapshotPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshotPolicy", reflect.TypeOf((*MockObjectTree)(nil).SetSnapshotPolicy), arg0, arg1)
+}
+
+// ShareTreeKey mocks base method.
+func (m *MockObjectTree) ShareTreeKey(arg0 crypto.PubKey) (*treechangeproto.TreeKeyShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareTreeKey", arg0)
+	ret0, _ := ret[0].(*treechangeproto.TreeKeyShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareTreeKey indicates an expected call of ShareTreeKey.
+func (mr *MockObjectTreeMockRecorder) ShareTreeKey(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareTreeKey", reflect.TypeOf((*MockObjectTree)(nil).ShareTreeKey), arg0)
+}
+
 // SnapshotPath mocks base method.
 func (m *MockObjectTree) SnapshotPath() []string {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 
 	"github.com/anyproto/any-sync/util/crypto"
 
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
@@ -69,6 +70,8 @@ type ReadableObjectTree interface {
 	Debug(parser DescriptionParser) (DebugInfo, error)
 	IterateRoot(convert ChangeConvertFunc, iterate ChangeIterateFunc) error
 	IterateFrom(id string, convert ChangeConvertFunc, iterate ChangeIterateFunc) error
+	NewPagedIterator(fromId string) (PagedIterator, error)
+	ChangesDiff(baseHeads, heads []string, convert ChangeConvertFunc) ([]*Change, error)
 }
 
 type ObjectTree interface {
@@ -76,15 +79,24 @@ type ObjectTree interface {
 
 	SnapshotPath() []string
 	ChangesAfterCommonSnapshot(snapshotPath, heads []string) ([]*treechangeproto.RawTreeChangeWithId, error)
+	AckSnapshotPath(peerId string, snapshotPath []string)
+	Compact(policy CompactionPolicy) (removed int, err error)
+	SetSnapshotPolicy(policy SnapshotPolicy, snapshot SnapshotFunc)
 
 	Storage() treestorage.TreeStorage
 
 	AddContent(ctx context.Context, content SignableChangeContent) (AddResult, error)
 	AddRawChanges(ctx context.Context, changes RawChangesPayload) (AddResult, error)
+	RevertTo(ctx context.Context, params RevertParams) (AddResult, error)
 
+	// UnpackChange verifies the change and returns its data decrypted with the keys of the tree and decompressed,
+	// so the callers which decrypted the data of the encrypted trees themselves should not do it anymore
 	UnpackChange(raw *treechangeproto.RawTreeChangeWithId) (data []byte, err error)
 	PrepareChange(content SignableChangeContent) (res *treechangeproto.RawTreeChangeWithId, err error)
 
+	// ShareTreeKey encrypts the current generation of the tree key for the identity
+	ShareTreeKey(identity crypto.PubKey) (*treechangeproto.TreeKeyShare, error)
+
 	Delete() error
 	Close() error
 	TryClose(objectTTL time.Duration) (bool, error)
@@ -105,6 +117,19 @@ type objectTree struct {
 
 	keys           map[string]crypto.SymKey
 	currentReadKey crypto.SymKey
+	// treeKeys are the generations of the own key of the tree encrypted with the space read keys by their ids,
+	// nil if the tree uses the space keys
+	treeKeys map[string]*treechangeproto.TreeKey
+	// currentTreeKeyId is the generation of the tree key used for the new changes, empty if the key should be rotated
+	currentTreeKeyId string
+	// contentValidators check the decrypted data of the changes before they are added, nil if the content is not validated
+	contentValidators *ContentValidators
+	// limiter rejects the changes exceeding the limits of the tree, nil if the tree has no limits
+	limiter *treeLimiter
+	// snapshotPolicy makes AddContent create the snapshots, nil if the snapshots are created only by the caller
+	snapshotPolicy *snapshotPolicy
+	// ackedPaths are the snapshot paths reported by the peers through the sync protocol by the peer ids
+	ackedPaths map[string][]string
 
 	// buffers
 	difSnapshotBuf  []*treechangeproto.RawTreeChangeWithId
@@ -124,6 +149,8 @@ func (ot *objectTree) rebuildFromStorage(theirHeads []string, newChanges []*Chan
 	if err != nil {
 		return
 	}
+	ot.snapshotPolicy.treeRebuilt()
+	ot.limiter.treeRebuilt()
 
 	// in case there are new heads
 	if theirHeads != nil && oldTree != nil {
@@ -186,6 +213,10 @@ func (ot *objectTree) GetChange(id string) (*Change, error) {
 }
 
 func (ot *objectTree) AddContent(ctx context.Context, content SignableChangeContent) (res AddResult, err error) {
+	content, err = ot.prepareSnapshot(content)
+	if err != nil {
+		return
+	}
 	payload, err := ot.prepareBuilderContent(content)
 	if err != nil {
 		return
@@ -196,9 +227,24 @@ func (ot *objectTree) AddContent(ctx context.Context, content SignableChangeCont
 	oldHeads = append(oldHeads, ot.tree.Heads()...)
 
 	objChange, rawChange, err := ot.changeBuilder.Build(payload)
+	if err != nil {
+		return
+	}
+	err = ot.contentValidators.Validate(ot.id, ot.ChangeInfo().ChangeType, objChange, content.Data)
+	if err != nil {
+		return
+	}
+	if ot.limiter != nil {
+		if err = ot.limiter.CheckChangesSize([]*treechangeproto.RawTreeChangeWithId{rawChange}); err != nil {
+			return
+		}
+		if err = ot.checkNewChanges([]*Change{objChange}); err != nil {
+			return
+		}
+	}
 	if content.IsSnapshot {
 		// clearing tree, because we already saved everything in the last snapshot
-		ot.tree = &Tree{}
+		ot.tree = &Tree{ordering: ot.tree.ordering}
 	}
 	err = ot.tree.AddMergedHead(objChange)
 	if err != nil {
@@ -209,6 +255,18 @@ func (ot *objectTree) AddContent(ctx context.Context, content SignableChangeCont
 	if err != nil {
 		return
 	}
+	ot.limiter.changesStored(1)
+	ot.limiter.changesAdded([]*Change{objChange}, Append)
+	if content.IsSnapshot {
+		ot.snapshotPolicy.snapshotAdded()
+	} else {
+		ot.snapshotPolicy.changesAdded([]*treechangeproto.RawTreeChangeWithId{rawChange}, Append)
+	}
+	if payload.TreeKey != nil {
+		ot.treeKeys[payload.ReadKeyId] = payload.TreeKey
+		ot.keys[payload.ReadKeyId] = payload.ReadKey
+		ot.currentTreeKeyId, ot.currentReadKey = payload.ReadKeyId, payload.ReadKey
+	}
 
 	mode := Append
 	if content.IsSnapshot {
@@ -231,8 +289,39 @@ func (ot *objectTree) UnpackChange(raw *treechangeproto.RawTreeChangeWithId) (da
 	if err != nil {
 		return
 	}
-	data = unmarshalled.Data
-	return
+	if unmarshalled.ReadKeyId == "" {
+		return decompress(unmarshalled.Compression, unmarshalled.Data)
+	}
+	if _, exists := ot.keys[unmarshalled.ReadKeyId]; !exists {
+		// the change may be encrypted with the key which we didn't read yet
+		ot.aclList.RLock()
+		err = ot.readKeysFromAclState(ot.aclList.AclState())
+		if err == nil {
+			err = ot.readChangeTreeKey(unmarshalled, ot.aclList.AclState())
+		}
+		ot.aclList.RUnlock()
+		if err != nil {
+			return
+		}
+	}
+	return ot.decrypt(unmarshalled)
+}
+
+func (ot *objectTree) ShareTreeKey(identity crypto.PubKey) (share *treechangeproto.TreeKeyShare, err error) {
+	if ot.treeKeys == nil {
+		return nil, ErrNoTreeKey
+	}
+	ot.aclList.RLock()
+	err = ot.readTreeKeys(ot.aclList.AclState())
+	ot.aclList.RUnlock()
+	if err != nil {
+		return
+	}
+	// the key is not known or it will be rotated by the next change
+	if ot.currentReadKey == nil {
+		return nil, ErrMissingKey
+	}
+	return newTreeKeyShare(ot.id, ot.currentTreeKeyId, ot.currentReadKey, identity)
 }
 
 func (ot *objectTree) PrepareChange(content SignableChangeContent) (res *treechangeproto.RawTreeChangeWithId, err error) {
@@ -253,13 +342,18 @@ func (ot *objectTree) prepareBuilderContent(content SignableChangeContent) (cnt
 		readKey   crypto.SymKey
 		pubKey    = content.Key.GetPublic()
 		readKeyId string
+		treeKey   *treechangeproto.TreeKey
 	)
-	if !state.Permissions(pubKey).CanWrite() {
+	if !state.Capabilities(pubKey).Has(aclrecordproto.AclCapability_CapabilityWrite) {
 		err = list.ErrInsufficientPermissions
 		return
 	}
 
-	if content.IsEncrypted {
+	if content.IsEncrypted && ot.treeKeys != nil {
+		if readKeyId, readKey, treeKey, err = ot.changeTreeKey(state, content.IsSnapshot); err != nil {
+			return
+		}
+	} else if content.IsEncrypted {
 		readKeyId = state.CurrentReadKeyId()
 		if ot.currentReadKey == nil {
 			err = ErrMissingKey
@@ -282,6 +376,8 @@ func (ot *objectTree) prepareBuilderContent(content SignableChangeContent) (cnt
 		Content:        content.Data,
 		DataType:       content.DataType,
 		Timestamp:      timestamp,
+		Compression:    content.Compression,
+		TreeKey:        treeKey,
 	}
 	return
 }
@@ -305,7 +401,13 @@ func (ot *objectTree) AddRawChanges(ctx context.Context, changesPayload RawChang
 	if err != nil {
 		// rolling back all changes made to inmemory state
 		ot.rebuildFromStorage(nil, nil)
+		return
+	}
+	ot.limiter.changesStored(len(addResult.Added))
+	if ot.limiter != nil {
+		ot.limiter.changesAdded(ot.attachedChanges(addResult.Added), addResult.Mode)
 	}
+	ot.snapshotPolicy.changesAdded(addResult.Added, addResult.Mode)
 	return
 }
 
@@ -325,6 +427,12 @@ func (ot *objectTree) addRawChanges(ctx context.Context, changesPayload RawChang
 	// this will be returned to client, so we shouldn't use buffer here
 	prevHeadsCopy := headsCopy()
 
+	if ot.limiter != nil {
+		if err = ot.limiter.CheckChangesSize(changesPayload.RawChanges); err != nil {
+			return
+		}
+	}
+
 	// filtering changes, verifying and unmarshalling them
 	for idx, ch := range changesPayload.RawChanges {
 		// not unmarshalling the changes if they were already added either as unattached or attached
@@ -340,6 +448,10 @@ func (ot *objectTree) addRawChanges(ctx context.Context, changesPayload RawChang
 			if err != nil {
 				return
 			}
+			// the unattached changes were validated when they were received
+			if err = ot.validateContent(change); err != nil {
+				return
+			}
 		}
 
 		if change.IsSnapshot {
@@ -359,6 +471,10 @@ func (ot *objectTree) addRawChanges(ctx context.Context, changesPayload RawChang
 		return
 	}
 
+	if err = ot.checkNewChanges(ot.newChangesBuf); err != nil {
+		return
+	}
+
 	rollback := func(changes []*Change) {
 		for _, ch := range changes {
 			if _, exists := ot.tree.attached[ch.Id]; exists {
@@ -437,6 +553,17 @@ func (ot *objectTree) addRawChanges(ctx context.Context, changesPayload RawChang
 	}
 }
 
+// attachedChanges returns the changes of the tree with the ids of the raw changes
+func (ot *objectTree) attachedChanges(rawChanges []*treechangeproto.RawTreeChangeWithId) []*Change {
+	changes := make([]*Change, 0, len(rawChanges))
+	for _, raw := range rawChanges {
+		if ch, exists := ot.tree.attached[raw.Id]; exists {
+			changes = append(changes, ch)
+		}
+	}
+	return changes
+}
+
 func (ot *objectTree) createAddResult(oldHeads []string, mode Mode, treeChangesAdded []*Change, rawChanges []*treechangeproto.RawTreeChangeWithId) (addResult AddResult, err error) {
 	headsCopy := func() []string {
 		newHeads := make([]string, 0, len(ot.tree.Heads()))
@@ -511,22 +638,6 @@ func (ot *objectTree) IterateFrom(id string, convert ChangeConvertFunc, iterate
 		ot.tree.Iterate(id, iterate)
 		return
 	}
-	decrypt := func(c *Change) (decrypted []byte, err error) {
-		// the change is not encrypted
-		if c.ReadKeyId == "" {
-			decrypted = c.Data
-			return
-		}
-		readKey, exists := ot.keys[c.ReadKeyId]
-		if !exists {
-			err = list.ErrNoReadKey
-			return
-		}
-
-		decrypted, err = readKey.Decrypt(c.Data)
-		return
-	}
-
 	ot.tree.Iterate(id, func(c *Change) (isContinue bool) {
 		var model any
 		// if already saved as a model
@@ -539,7 +650,7 @@ func (ot *objectTree) IterateFrom(id string, convert ChangeConvertFunc, iterate
 		}
 
 		var decrypted []byte
-		decrypted, err = decrypt(c)
+		decrypted, err = ot.decrypt(c)
 		if err != nil {
 			return false
 		}
@@ -555,6 +666,24 @@ func (ot *objectTree) IterateFrom(id string, convert ChangeConvertFunc, iterate
 	return
 }
 
+func (ot *objectTree) decrypt(c *Change) (decrypted []byte, err error) {
+	// the change is not encrypted
+	if c.ReadKeyId == "" {
+		return decompress(c.Compression, c.Data)
+	}
+	readKey, exists := ot.keys[c.ReadKeyId]
+	if !exists {
+		err = list.ErrNoReadKey
+		return
+	}
+
+	decrypted, err = readKey.Decrypt(c.Data)
+	if err != nil {
+		return
+	}
+	return decompress(c.Compression, decrypted)
+}
+
 func (ot *objectTree) HasChanges(chs ...string) bool {
 	for _, ch := range chs {
 		if _, attachedExists := ot.tree.attached[ch]; !attachedExists {
@@ -623,6 +752,14 @@ func (ot *objectTree) ChangesAfterCommonSnapshot(theirPath, theirHeads []string)
 			return nil, err
 		}
 	}
+	compactedIdx, err := ot.compactedAfter(ourPath, commonSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	// the changes after the common snapshot were removed, so the peer gets everything we have
+	if compactedIdx != -1 {
+		return ot.compactedChanges(ourPath, compactedIdx)
+	}
 
 	return ot.rawChangeLoader.Load(commonSnapshot, ot.tree, theirHeads)
 }
@@ -636,6 +773,18 @@ func (ot *objectTree) validateTree(newChanges []*Change) error {
 	defer ot.aclList.RUnlock()
 	state := ot.aclList.AclState()
 
+	if ot.treeKeys != nil {
+		changes := newChanges
+		if len(changes) == 0 {
+			changes = make([]*Change, 0, len(ot.tree.attached))
+			for _, ch := range ot.tree.attached {
+				changes = append(changes, ch)
+			}
+		}
+		if err := ot.addTreeKeys(changes); err != nil {
+			return err
+		}
+	}
 	err := ot.readKeysFromAclState(state)
 	if err != nil {
 		return err
@@ -648,12 +797,15 @@ func (ot *objectTree) validateTree(newChanges []*Change) error {
 }
 
 func (ot *objectTree) readKeysFromAclState(state *list.AclState) (err error) {
+	if ot.treeKeys != nil {
+		return ot.readTreeKeys(state)
+	}
 	// just not to take lock many times, updating the key map from aclList
 	if len(ot.keys) == len(state.Keys()) {
 		return nil
 	}
 	// if we can't read the keys anyway
-	if state.AccountKey() == nil || state.Permissions(state.AccountKey().GetPublic()).NoPermissions() {
+	if !canReadKeys(state) {
 		return nil
 	}
 	for key, value := range state.Keys() {
@@ -671,6 +823,14 @@ func (ot *objectTree) readKeysFromAclState(state *list.AclState) (err error) {
 	return err
 }
 
+// canReadKeys returns true if the account is a member of the space or reads it with the public reader invite
+func canReadKeys(state *list.AclState) bool {
+	if state.AccountKey() == nil {
+		return false
+	}
+	return !state.Permissions(state.AccountKey().GetPublic()).NoPermissions() || state.IsPublicReader()
+}
+
 func (ot *objectTree) Debug(parser DescriptionParser) (DebugInfo, error) {
 	return objectTreeDebug{}.debugInfo(ot, parser)
 }
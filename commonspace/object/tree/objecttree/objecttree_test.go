@@ -1,9 +1,12 @@
 package objecttree
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"golang.org/x/exp/slices"
+	"strconv"
 	"testing"
 	"time"
 
@@ -11,11 +14,34 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/util/crypto"
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+type concatStateBuilder struct {
+	state []byte
+}
+
+func (c *concatStateBuilder) Apply(change *Change) error {
+	if data, ok := change.Model.([]byte); ok {
+		if change.IsSnapshot {
+			c.state = c.state[:0]
+		}
+		c.state = append(c.state, data...)
+	}
+	return nil
+}
+
+func (c *concatStateBuilder) Snapshot() ([]byte, error) {
+	return c.state, nil
+}
+
+func convertData(change *Change, decrypted []byte) (any, error) {
+	return decrypted, nil
+}
+
 type testTreeContext struct {
 	aclList       list.AclList
 	treeStorage   treestorage.TreeStorage
@@ -23,6 +49,14 @@ type testTreeContext struct {
 	objTree       ObjectTree
 }
 
+type testLimitMetric struct {
+	rejected []error
+}
+
+func (m *testLimitMetric) ChangeRejected(err error) {
+	m.rejected = append(m.rejected, err)
+}
+
 func prepareAclList(t *testing.T) (list.AclList, *accountdata.AccountKeys) {
 	randKeys, err := accountdata.NewRandom()
 	require.NoError(t, err)
@@ -113,43 +147,979 @@ func TestObjectTree(t *testing.T) {
 		oTree, err := BuildObjectTree(store, aclList)
 		require.NoError(t, err)
 
-		t.Run("0 timestamp is changed to current, data type is correct", func(t *testing.T) {
-			start := time.Now()
-			res, err := oTree.AddContent(ctx, SignableChangeContent{
-				Data:        []byte("some"),
-				Key:         keys.SignKey,
-				IsSnapshot:  false,
-				IsEncrypted: true,
-				Timestamp:   0,
-				DataType:    mockDataType,
+		t.Run("0 timestamp is changed to current, data type is correct", func(t *testing.T) {
+			start := time.Now()
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte("some"),
+				Key:         keys.SignKey,
+				IsSnapshot:  false,
+				IsEncrypted: true,
+				Timestamp:   0,
+				DataType:    mockDataType,
+			})
+			end := time.Now()
+			require.NoError(t, err)
+			require.Len(t, oTree.Heads(), 1)
+			require.Equal(t, res.Added[0].Id, oTree.Heads()[0])
+			ch, err := oTree.(*objectTree).changeBuilder.Unmarshall(res.Added[0], true)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, start.Unix(), ch.Timestamp)
+			require.LessOrEqual(t, end.Unix(), ch.Timestamp)
+			require.Equal(t, res.Added[0].Id, oTree.(*objectTree).tree.lastIteratedHeadId)
+			require.Equal(t, mockDataType, ch.DataType)
+		})
+		t.Run("timestamp is set correctly", func(t *testing.T) {
+			someTs := time.Now().Add(time.Hour).Unix()
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte("some"),
+				Key:         keys.SignKey,
+				IsSnapshot:  false,
+				IsEncrypted: true,
+				Timestamp:   someTs,
+			})
+			require.NoError(t, err)
+			require.Len(t, oTree.Heads(), 1)
+			require.Equal(t, res.Added[0].Id, oTree.Heads()[0])
+			ch, err := oTree.(*objectTree).changeBuilder.Unmarshall(res.Added[0], true)
+			require.NoError(t, err)
+			require.Equal(t, ch.Timestamp, someTs)
+			require.Equal(t, res.Added[0].Id, oTree.(*objectTree).tree.lastIteratedHeadId)
+		})
+		t.Run("tree without own key can't be shared", func(t *testing.T) {
+			_, err := oTree.ShareTreeKey(keys.SignKey.GetPublic())
+			require.Equal(t, ErrNoTreeKey, err)
+		})
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		var ids []string
+		for _, data := range []string{"a", "b", "c"} {
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+			ids = append(ids, res.Heads[0])
+		}
+		revertParams := func(changeId string) RevertParams {
+			return RevertParams{
+				ChangeId:     changeId,
+				Convert:      convertData,
+				StateBuilder: &concatStateBuilder{},
+				Key:          keys.SignKey,
+				IsEncrypted:  true,
+			}
+		}
+		currentState := func() string {
+			sb := &concatStateBuilder{}
+			err := oTree.IterateRoot(convertData, func(change *Change) bool {
+				require.NoError(t, sb.Apply(change))
+				return true
+			})
+			require.NoError(t, err)
+			return string(sb.state)
+		}
+		require.Equal(t, "abc", currentState())
+
+		t.Run("revert to current head", func(t *testing.T) {
+			res, err := oTree.RevertTo(ctx, revertParams(ids[2]))
+			require.NoError(t, err)
+			require.Equal(t, Nothing, res.Mode)
+		})
+		t.Run("revert to previous version", func(t *testing.T) {
+			res, err := oTree.RevertTo(ctx, revertParams(ids[0]))
+			require.NoError(t, err)
+			require.Len(t, res.Added, 1)
+			require.Equal(t, []string{ids[2]}, res.OldHeads)
+			ch, err := oTree.GetChange(res.Heads[0])
+			require.NoError(t, err)
+			require.True(t, ch.IsSnapshot)
+			require.Equal(t, []string{ids[2]}, ch.PreviousIds)
+			data, err := oTree.UnpackChange(res.Added[0])
+			require.NoError(t, err)
+			require.Equal(t, "a", string(data))
+
+			_, err = oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte("d"),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+			require.Equal(t, "ad", currentState())
+		})
+		t.Run("unknown change", func(t *testing.T) {
+			_, err := oTree.RevertTo(ctx, revertParams("unknown"))
+			require.Equal(t, ErrNoChangeInTree, err)
+		})
+	})
+
+	t.Run("fork", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		var ids []string
+		for _, data := range []string{"a", "b", "c"} {
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+			ids = append(ids, res.Heads[0])
+		}
+		fork := func(t *testing.T, withHistory bool) (ObjectTree, []*treechangeproto.RawTreeChangeWithId) {
+			payload := ObjectTreeForkPayload{
+				ObjectTreeCreatePayload: ObjectTreeCreatePayload{
+					PrivKey:       keys.SignKey,
+					ChangeType:    "changeType",
+					ChangePayload: []byte("payload"),
+					SpaceId:       "spaceId",
+					IsEncrypted:   true,
+				},
+				ChangeId:     ids[1],
+				WithHistory:  withHistory,
+				Convert:      convertData,
+				StateBuilder: &concatStateBuilder{},
+			}
+			version, err := BuildHistoryTree(HistoryTreeParams{
+				TreeStorage:     store,
+				AclList:         aclList,
+				BeforeId:        ids[1],
+				IncludeBeforeId: true,
+				BuildFromRoot:   withHistory,
+			})
+			require.NoError(t, err)
+			createPayload, err := ForkCreatePayload(oTree.Id(), payload)
+			require.NoError(t, err)
+			forkRoot, err := CreateObjectTreeRoot(createPayload, aclList)
+			require.NoError(t, err)
+			res, err := ForkObjectTree(version, forkRoot, payload, aclList)
+			require.NoError(t, err)
+			forkStore, err := treestorage.NewInMemoryTreeStorage(res.RootRawChange, res.Heads, res.Changes)
+			require.NoError(t, err)
+			forkTree, err := BuildObjectTree(forkStore, aclList)
+			require.NoError(t, err)
+
+			forkInfo, err := ForkInfo(forkTree.ChangeInfo())
+			require.NoError(t, err)
+			require.Equal(t, oTree.Id(), forkInfo.SourceTreeId)
+			require.Equal(t, ids[1], forkInfo.SourceChangeId)
+			require.Equal(t, []byte("payload"), forkInfo.ChangePayload)
+
+			sb := &concatStateBuilder{}
+			err = forkTree.IterateRoot(convertData, func(change *Change) bool {
+				require.NoError(t, sb.Apply(change))
+				return true
+			})
+			require.NoError(t, err)
+			require.Equal(t, "ab", string(sb.state))
+			return forkTree, res.Changes
+		}
+
+		t.Run("snapshot", func(t *testing.T) {
+			_, changes := fork(t, false)
+			require.Len(t, changes, 2)
+		})
+		t.Run("with history", func(t *testing.T) {
+			_, changes := fork(t, true)
+			require.Len(t, changes, 3)
+		})
+		t.Run("version doesn't end with the change", func(t *testing.T) {
+			version, err := BuildHistoryTree(HistoryTreeParams{
+				TreeStorage: store,
+				AclList:     aclList,
+			})
+			require.NoError(t, err)
+			_, err = ForkObjectTree(version, root, ObjectTreeForkPayload{ChangeId: ids[1]}, aclList)
+			require.Equal(t, ErrIncorrectForkVersion, err)
+		})
+		t.Run("with history keeps the graph", func(t *testing.T) {
+			changeCreator, deps := prepareHistoryTreeDeps(aclList)
+			rawChanges := []*treechangeproto.RawTreeChangeWithId{
+				changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+				changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "0"),
+				changeCreator.CreateRaw("3", aclList.Head().Id, "0", false, "1", "2"),
+			}
+			deps.treeStorage.AddRawChangesSetHeads(rawChanges, []string{"3"})
+			version, err := buildHistoryTree(deps, HistoryTreeParams{
+				BeforeId:        "3",
+				IncludeBeforeId: true,
+				BuildFromRoot:   true,
+			})
+			require.NoError(t, err)
+			payload := ObjectTreeForkPayload{
+				ObjectTreeCreatePayload: ObjectTreeCreatePayload{
+					PrivKey:     keys.SignKey,
+					ChangeType:  "changeType",
+					SpaceId:     "spaceId",
+					IsEncrypted: true,
+				},
+				ChangeId:    "3",
+				WithHistory: true,
+			}
+			createPayload, err := ForkCreatePayload(version.Id(), payload)
+			require.NoError(t, err)
+			forkRoot, err := CreateObjectTreeRoot(createPayload, aclList)
+			require.NoError(t, err)
+			res, err := ForkObjectTree(version, forkRoot, payload, aclList)
+			require.NoError(t, err)
+			require.Len(t, res.Changes, 4)
+			require.Len(t, res.Heads, 1)
+			forkStore, err := treestorage.NewInMemoryTreeStorage(res.RootRawChange, res.Heads, res.Changes)
+			require.NoError(t, err)
+			forkTree, err := BuildObjectTree(forkStore, aclList)
+			require.NoError(t, err)
+
+			head, err := forkTree.GetChange(res.Heads[0])
+			require.NoError(t, err)
+			require.Len(t, head.PreviousIds, 2)
+			for _, prevId := range head.PreviousIds {
+				prev, err := forkTree.GetChange(prevId)
+				require.NoError(t, err)
+				require.Equal(t, []string{forkTree.Id()}, prev.PreviousIds)
+			}
+		})
+	})
+
+	t.Run("paged iterator decrypts changes", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		for _, data := range []string{"a", "b"} {
+			_, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+		}
+		iter, err := oTree.NewPagedIterator("")
+		require.NoError(t, err)
+		changes, err := iter.NextPage(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		var state string
+		for _, ch := range changes[1:] {
+			require.NotEmpty(t, ch.ReadKeyId)
+			data, err := iter.Unpack(ch)
+			require.NoError(t, err)
+			state += string(data)
+		}
+		require.Equal(t, "ab", state)
+	})
+
+	t.Run("changes diff decrypts changes", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		var baseHeads []string
+		for _, data := range []string{"a", "b", "c"} {
+			if data == "b" {
+				baseHeads = oTree.Heads()
+			}
+			_, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+		}
+		changes, err := oTree.ChangesDiff(baseHeads, oTree.Heads(), convertData)
+		require.NoError(t, err)
+		var state []string
+		for _, ch := range changes {
+			require.True(t, ch.Identity.Equals(keys.SignKey.GetPublic()))
+			state = append(state, string(ch.Model.([]byte)))
+		}
+		require.Equal(t, []string{"b", "c"}, state)
+	})
+
+	t.Run("compressed changes", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		data := bytes.Repeat([]byte("some text "), 100)
+		var expected []string
+		for _, isEncrypted := range []bool{true, false} {
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        data,
+				Key:         keys.SignKey,
+				IsEncrypted: isEncrypted,
+				Compression: treechangeproto.ChangeCompression_Flate,
+			})
+			require.NoError(t, err)
+			ch, err := oTree.GetChange(res.Heads[0])
+			require.NoError(t, err)
+			require.Equal(t, treechangeproto.ChangeCompression_Flate, ch.Compression)
+			require.Less(t, len(ch.Data), len(data)/2)
+			unpacked, err := oTree.UnpackChange(res.Added[0])
+			require.NoError(t, err)
+			require.Equal(t, data, unpacked)
+			expected = append(expected, string(data))
+		}
+		var iterated []string
+		err = oTree.IterateRoot(convertData, func(change *Change) bool {
+			if change.Id != oTree.Id() {
+				iterated = append(iterated, string(change.Model.([]byte)))
+			}
+			return true
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected, iterated)
+
+		t.Run("compressed payload is in the separate field", func(t *testing.T) {
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        data,
+				Key:         keys.SignKey,
+				Compression: treechangeproto.ChangeCompression_Flate,
+			})
+			require.NoError(t, err)
+			rawChange := &treechangeproto.RawTreeChange{}
+			require.NoError(t, rawChange.Unmarshal(res.Added[0].RawChange))
+			treeChange := &treechangeproto.TreeChange{}
+			require.NoError(t, treeChange.Unmarshal(rawChange.Payload))
+			require.Empty(t, treeChange.ChangesData)
+			require.NotEmpty(t, treeChange.CompressedChangesData)
+		})
+		t.Run("decompressed size is limited", func(t *testing.T) {
+			compressed, err := compress(treechangeproto.ChangeCompression_Flate, make([]byte, maxDecompressedSize+1))
+			require.NoError(t, err)
+			_, err = decompress(treechangeproto.ChangeCompression_Flate, compressed)
+			require.Equal(t, ErrDecompressedTooLarge, err)
+		})
+		t.Run("unknown compression is rejected", func(t *testing.T) {
+			identity, err := keys.SignKey.GetPublic().Marshall()
+			require.NoError(t, err)
+			payload, err := (&treechangeproto.TreeChange{
+				TreeHeadIds: []string{root.Id},
+				Identity:    identity,
+				ChangesData: data,
+				Compression: treechangeproto.ChangeCompression(100),
+			}).Marshal()
+			require.NoError(t, err)
+			rawChange, err := (&treechangeproto.RawTreeChange{Payload: payload}).Marshal()
+			require.NoError(t, err)
+			_, err = oTree.(*objectTree).changeBuilder.Unmarshall(&treechangeproto.RawTreeChangeWithId{
+				RawChange: rawChange,
+				Id:        "id",
+			}, false)
+			require.Equal(t, ErrUnknownCompression, err)
+		})
+	})
+
+	t.Run("content validators", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		errBad := errors.New("bad content")
+		validators := NewContentValidators()
+		require.NoError(t, validators.RegisterChangeType("changeType", func(change *Change, decrypted []byte) error {
+			if string(decrypted) == "bad" {
+				return errBad
+			}
+			return nil
+		}))
+		require.NoError(t, validators.RegisterDataType("number", func(change *Change, decrypted []byte) error {
+			_, err := strconv.Atoi(string(decrypted))
+			return err
+		}))
+		require.Error(t, validators.RegisterDataType("number", nil))
+
+		srcStore, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		srcTree, err := BuildObjectTree(srcStore, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := WithContentValidators(BuildObjectTree, validators)(store, aclList)
+		require.NoError(t, err)
+
+		addContent := func(tree ObjectTree, data, dataType string) (AddResult, error) {
+			return tree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+				DataType:    dataType,
+			})
+		}
+
+		t.Run("add content", func(t *testing.T) {
+			_, err := addContent(oTree, "bad", "")
+			require.ErrorIs(t, err, ErrInvalidContent)
+			require.ErrorIs(t, err, errBad)
+			_, err = addContent(oTree, "a", "number")
+			var validationErr *ContentValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, "number", validationErr.DataType)
+			require.Equal(t, keys.SignKey.GetPublic().Account(), validationErr.Identity)
+			require.Equal(t, []string{root.Id}, oTree.Heads())
+
+			_, err = addContent(oTree, "1", "number")
+			require.NoError(t, err)
+		})
+		t.Run("add raw changes", func(t *testing.T) {
+			good, err := addContent(srcTree, "good", "")
+			require.NoError(t, err)
+			bad, err := addContent(srcTree, "bad", "")
+			require.NoError(t, err)
+			heads := oTree.Heads()
+
+			_, err = oTree.AddRawChanges(ctx, RawChangesPayload{
+				NewHeads:   bad.Heads,
+				RawChanges: append(good.Added, bad.Added...),
+			})
+			require.ErrorIs(t, err, ErrInvalidContent)
+			require.ErrorIs(t, err, ErrHasInvalidChanges)
+			var validationErr *ContentValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, bad.Heads[0], validationErr.ChangeId)
+			require.Equal(t, oTree.Id(), validationErr.TreeId)
+			require.Equal(t, heads, oTree.Heads())
+			require.False(t, oTree.HasChanges(good.Heads...))
+
+			_, err = oTree.AddRawChanges(ctx, RawChangesPayload{
+				NewHeads:   good.Heads,
+				RawChanges: good.Added,
+			})
+			require.NoError(t, err)
+			require.True(t, oTree.HasChanges(good.Heads...))
+		})
+		t.Run("validate raw tree", func(t *testing.T) {
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			tree, err := BuildObjectTree(store, aclList)
+			require.NoError(t, err)
+			good, err := addContent(tree, "good", "")
+			require.NoError(t, err)
+			bad, err := addContent(tree, "bad", "")
+			require.NoError(t, err)
+			payload := treestorage.TreeStorageCreatePayload{
+				RootRawChange: root,
+				Changes:       append([]*treechangeproto.RawTreeChangeWithId{root}, append(good.Added, bad.Added...)...),
+				Heads:         bad.Heads,
+			}
+			require.NoError(t, ValidateRawTree(payload, aclList))
+			err = ValidateRawTreeBuildFunc(payload, WithContentValidators(BuildObjectTree, validators), aclList)
+			require.ErrorIs(t, err, ErrInvalidContent)
+		})
+		t.Run("changes without read key are not validated", func(t *testing.T) {
+			outsiderKeys, err := accountdata.NewRandom()
+			require.NoError(t, err)
+			outsiderAcl, err := list.NewTestAclWithRoot(outsiderKeys, aclList.Root())
+			require.NoError(t, err)
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			tree, err := WithContentValidators(BuildObjectTree, validators)(store, outsiderAcl)
+			require.NoError(t, err)
+			src, err := BuildObjectTree(srcStore, aclList)
+			require.NoError(t, err)
+			bad, err := addContent(src, "bad", "")
+			require.NoError(t, err)
+			rawChanges, err := src.ChangesAfterCommonSnapshot(nil, nil)
+			require.NoError(t, err)
+
+			_, err = tree.AddRawChanges(ctx, RawChangesPayload{
+				NewHeads:   bad.Heads,
+				RawChanges: rawChanges,
+			})
+			require.NoError(t, err)
+			require.True(t, tree.HasChanges(bad.Heads...))
+		})
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+		}, aclList)
+		require.NoError(t, err)
+		metric := &testLimitMetric{}
+		limits := TreeLimits{
+			MaxChangeSize: 1000,
+			MaxChanges:    4,
+			Metric:        metric,
+		}
+		srcStore, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		srcTree, err := BuildObjectTree(srcStore, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := WithLimits(BuildObjectTree, limits)(store, aclList)
+		require.NoError(t, err)
+		addContent := func(tree ObjectTree, data []byte) (AddResult, error) {
+			return tree.AddContent(ctx, SignableChangeContent{
+				Data:        data,
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+		}
+		large := bytes.Repeat([]byte("a"), 2000)
+
+		_, err = addContent(oTree, large)
+		require.ErrorIs(t, err, ErrChangeTooLarge)
+		res, err := addContent(srcTree, large)
+		require.NoError(t, err)
+		_, err = oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: res.Heads, RawChanges: res.Added})
+		require.ErrorIs(t, err, ErrChangeTooLarge)
+
+		for _, data := range []string{"a", "b", "c"} {
+			_, err = addContent(oTree, []byte(data))
+			require.NoError(t, err)
+		}
+		res, err = addContent(srcTree, []byte("d"))
+		require.NoError(t, err)
+		_, err = oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: res.Heads, RawChanges: res.Added})
+		require.ErrorIs(t, err, ErrTooManyChanges)
+		require.Len(t, oTree.Heads(), 1)
+
+		// the changes are counted from the storage when the tree is built again
+		oTree, err = WithLimits(BuildObjectTree, limits)(store, aclList)
+		require.NoError(t, err)
+		_, err = addContent(oTree, []byte("d"))
+		require.ErrorIs(t, err, ErrTooManyChanges)
+
+		// the changes of one identity are counted on their timestamps
+		identityLimits := TreeLimits{MaxIdentityChanges: 2, IdentityWindow: time.Minute, Metric: metric}
+		newTree := func() ObjectTree {
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			tree, err := WithLimits(BuildObjectTree, identityLimits)(store, aclList)
+			require.NoError(t, err)
+			return tree
+		}
+		addContentAt := func(tree ObjectTree, data string, timestamp int64) (AddResult, error) {
+			return tree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+				Timestamp:   timestamp,
+			})
+		}
+		start := time.Now().Unix()
+		oTree = newTree()
+		for i, data := range []string{"a", "b"} {
+			_, err = addContentAt(oTree, data, start+int64(i))
+			require.NoError(t, err)
+		}
+		_, err = addContentAt(oTree, "c", start+2)
+		require.ErrorIs(t, err, ErrChangeRateExceeded)
+		_, err = addContentAt(oTree, "c", start+60)
+		require.NoError(t, err)
+
+		// the peers which are behind receive the changes made in different windows
+		srcStore, _ = treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		srcTree, err = BuildObjectTree(srcStore, aclList)
+		require.NoError(t, err)
+		var added []*treechangeproto.RawTreeChangeWithId
+		for i, data := range []string{"a", "b", "c", "d"} {
+			res, err = addContentAt(srcTree, data, start+int64(i/2*60))
+			require.NoError(t, err)
+			added = append(added, res.Added...)
+		}
+		oTree = newTree()
+		_, err = oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: srcTree.Heads(), RawChanges: added})
+		require.NoError(t, err)
+		res, err = addContentAt(srcTree, "e", start+61)
+		require.NoError(t, err)
+		_, err = oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: res.Heads, RawChanges: res.Added})
+		require.ErrorIs(t, err, ErrChangeRateExceeded)
+
+		// the batch is rejected by every peer in the same way
+		oTree = newTree()
+		_, err = oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: srcTree.Heads(), RawChanges: append(added, res.Added...)})
+		require.ErrorIs(t, err, ErrChangeRateExceeded)
+
+		rateLimiter := NewPeerRateLimiter(TreeLimits{MaxPeerChanges: 2, PeerWindow: time.Minute, Metric: metric})
+		now := time.Now()
+		rateLimiter.now = func() time.Time {
+			return now
+		}
+		require.ErrorIs(t, rateLimiter.Allow("peer1", 3), ErrPeerRateExceeded)
+		require.NoError(t, rateLimiter.Allow("peer1", 2))
+		require.NoError(t, rateLimiter.Allow("peer2", 1))
+		require.NoError(t, rateLimiter.Allow("peer2", 1))
+		require.ErrorIs(t, rateLimiter.Allow("peer2", 1), ErrPeerRateExceeded)
+		now = now.Add(time.Minute)
+		require.NoError(t, rateLimiter.Allow("peer1", 1))
+		require.NoError(t, rateLimiter.Allow("peer2", 2))
+		require.Equal(t, []error{
+			ErrChangeTooLarge, ErrChangeTooLarge, ErrTooManyChanges, ErrTooManyChanges,
+			ErrChangeRateExceeded, ErrChangeRateExceeded, ErrChangeRateExceeded,
+			ErrPeerRateExceeded, ErrPeerRateExceeded,
+		}, metric.rejected)
+	})
+
+	t.Run("snapshot policy", func(t *testing.T) {
+		buildTree := func(t *testing.T) ObjectTree {
+			root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+				PrivKey:     keys.SignKey,
+				ChangeType:  "changeType",
+				SpaceId:     "spaceId",
+				IsEncrypted: true,
+			}, aclList)
+			require.NoError(t, err)
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			oTree, err := BuildObjectTree(store, aclList)
+			require.NoError(t, err)
+			return oTree
+		}
+		// the snapshot contains all data of the tree and the new content
+		snapshot := func(oTree ObjectTree) SnapshotFunc {
+			return func(content SignableChangeContent) ([]byte, error) {
+				var data []byte
+				err := oTree.IterateRoot(convertData, func(change *Change) bool {
+					if change.Id != oTree.Id() {
+						data = append(data, change.Model.([]byte)...)
+					}
+					return true
+				})
+				return append(data, content.Data...), err
+			}
+		}
+		addContent := func(t *testing.T, oTree ObjectTree, data string) *Change {
+			res, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte(data),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+			ch, err := oTree.GetChange(res.Heads[0])
+			require.NoError(t, err)
+			return ch
+		}
+
+		t.Run("max changes", func(t *testing.T) {
+			oTree := buildTree(t)
+			oTree.SetSnapshotPolicy(SnapshotPolicy{MaxChanges: 2}, snapshot(oTree))
+			var snapshots []bool
+			for _, data := range []string{"a", "b", "c", "d", "e", "f"} {
+				snapshots = append(snapshots, addContent(t, oTree, data).IsSnapshot)
+			}
+			require.Equal(t, []bool{false, false, true, false, false, true}, snapshots)
+			raw, err := oTree.Storage().GetRawChange(ctx, oTree.Root().Id)
+			require.NoError(t, err)
+			unpacked, err := oTree.UnpackChange(raw)
+			require.NoError(t, err)
+			require.Equal(t, "abcdef", string(unpacked))
+		})
+		t.Run("max size", func(t *testing.T) {
+			oTree := buildTree(t)
+			oTree.SetSnapshotPolicy(SnapshotPolicy{MaxSize: 1}, snapshot(oTree))
+			require.False(t, addContent(t, oTree, "a").IsSnapshot)
+			require.True(t, addContent(t, oTree, "b").IsSnapshot)
+			require.False(t, addContent(t, oTree, "c").IsSnapshot)
+		})
+		t.Run("counted from the new root after rebuild", func(t *testing.T) {
+			oTree := buildTree(t)
+			oTree.SetSnapshotPolicy(SnapshotPolicy{MaxChanges: 3}, snapshot(oTree))
+			root, err := oTree.Storage().Root()
+			require.NoError(t, err)
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			srcTree, err := BuildObjectTree(store, aclList)
+			require.NoError(t, err)
+			var added []*treechangeproto.RawTreeChangeWithId
+			for _, data := range []string{"a", "b"} {
+				res, err := oTree.AddContent(ctx, SignableChangeContent{
+					Data:        []byte(data),
+					Key:         keys.SignKey,
+					IsEncrypted: true,
+				})
+				require.NoError(t, err)
+				added = append(added, res.Added...)
+			}
+			_, err = srcTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: oTree.Heads(), RawChanges: added})
+			require.NoError(t, err)
+
+			// the snapshot of the other peer becomes the root of the tree
+			added = added[:0]
+			for _, data := range []string{"c", "d"} {
+				res, err := srcTree.AddContent(ctx, SignableChangeContent{
+					Data:        []byte(data),
+					Key:         keys.SignKey,
+					IsEncrypted: true,
+					IsSnapshot:  data == "d",
+				})
+				require.NoError(t, err)
+				added = append(added, res.Added...)
+			}
+			res, err := oTree.AddRawChanges(ctx, RawChangesPayload{NewHeads: srcTree.Heads(), RawChanges: added})
+			require.NoError(t, err)
+			require.Equal(t, Rebuild, res.Mode)
+			require.Equal(t, srcTree.Heads()[0], oTree.Root().Id)
+
+			var snapshots []bool
+			for _, data := range []string{"e", "f", "g", "h"} {
+				snapshots = append(snapshots, addContent(t, oTree, data).IsSnapshot)
+			}
+			require.Equal(t, []bool{false, false, false, true}, snapshots)
+		})
+		t.Run("snapshot error", func(t *testing.T) {
+			oTree := buildTree(t)
+			errSnapshot := errors.New("snapshot error")
+			oTree.SetSnapshotPolicy(SnapshotPolicy{MaxChanges: 1}, func(content SignableChangeContent) ([]byte, error) {
+				return nil, errSnapshot
+			})
+			addContent(t, oTree, "a")
+			_, err := oTree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte("b"),
+				Key:         keys.SignKey,
+				IsEncrypted: true,
+			})
+			require.ErrorIs(t, err, errSnapshot)
+			require.Equal(t, 2, oTree.Len())
+		})
+	})
+
+	t.Run("tree key", func(t *testing.T) {
+		root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+			PrivKey:     keys.SignKey,
+			ChangeType:  "changeType",
+			SpaceId:     "spaceId",
+			IsEncrypted: true,
+			HasTreeKey:  true,
+		}, aclList)
+		require.NoError(t, err)
+		store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+		oTree, err := BuildObjectTree(store, aclList)
+		require.NoError(t, err)
+		res, err := oTree.AddContent(ctx, SignableChangeContent{
+			Data:        []byte("some"),
+			Key:         keys.SignKey,
+			IsEncrypted: true,
+		})
+		require.NoError(t, err)
+		ch, err := oTree.(*objectTree).changeBuilder.Unmarshall(res.Added[0], true)
+		require.NoError(t, err)
+		require.Equal(t, root.Id, ch.ReadKeyId)
+		data, err := oTree.UnpackChange(res.Added[0])
+		require.NoError(t, err)
+		require.Equal(t, []byte("some"), data)
+
+		outsiderKeys, err := accountdata.NewRandom()
+		require.NoError(t, err)
+		outsiderAcl, err := list.NewTestAclWithRoot(outsiderKeys, aclList.Root())
+		require.NoError(t, err)
+		newOutsiderTree := func(build func(store treestorage.TreeStorage) (ObjectTree, error)) ObjectTree {
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			tree, err := build(store)
+			require.NoError(t, err)
+			_, err = tree.AddRawChanges(ctx, RawChangesPayload{
+				NewHeads:   oTree.Heads(),
+				RawChanges: res.Added,
 			})
-			end := time.Now()
 			require.NoError(t, err)
-			require.Len(t, oTree.Heads(), 1)
-			require.Equal(t, res.Added[0].Id, oTree.Heads()[0])
-			ch, err := oTree.(*objectTree).changeBuilder.Unmarshall(res.Added[0], true)
+			return tree
+		}
+		convert := func(change *Change, decrypted []byte) (any, error) {
+			return decrypted, nil
+		}
+
+		t.Run("outsider can't read without share", func(t *testing.T) {
+			tree := newOutsiderTree(func(store treestorage.TreeStorage) (ObjectTree, error) {
+				return BuildObjectTree(store, outsiderAcl)
+			})
+			err := tree.IterateRoot(convert, func(change *Change) bool {
+				return true
+			})
+			require.Equal(t, list.ErrNoReadKey, err)
+		})
+		t.Run("outsider reads with share", func(t *testing.T) {
+			share, err := oTree.ShareTreeKey(outsiderKeys.SignKey.GetPublic())
 			require.NoError(t, err)
-			require.GreaterOrEqual(t, start.Unix(), ch.Timestamp)
-			require.LessOrEqual(t, end.Unix(), ch.Timestamp)
-			require.Equal(t, res.Added[0].Id, oTree.(*objectTree).tree.lastIteratedHeadId)
-			require.Equal(t, mockDataType, ch.DataType)
+			_, err = DecryptTreeKeyShare(share, root.Id, keys.SignKey)
+			require.Equal(t, ErrIncorrectTreeKeyShare, err)
+			treeKey, err := DecryptTreeKeyShare(share, root.Id, outsiderKeys.SignKey)
+			require.NoError(t, err)
+
+			tree := newOutsiderTree(func(store treestorage.TreeStorage) (ObjectTree, error) {
+				return BuildSharedObjectTree(store, outsiderAcl, []SharedTreeKey{treeKey})
+			})
+			var contents [][]byte
+			err = tree.IterateRoot(convert, func(change *Change) bool {
+				if change.Id != root.Id {
+					contents = append(contents, change.Model.([]byte))
+				}
+				return true
+			})
+			require.NoError(t, err)
+			require.Equal(t, [][]byte{[]byte("some")}, contents)
+
+			// the outsider still can't write to the tree
+			_, err = tree.AddContent(ctx, SignableChangeContent{
+				Data:        []byte("other"),
+				Key:         outsiderKeys.SignKey,
+				IsEncrypted: true,
+			})
+			require.Equal(t, list.ErrInsufficientPermissions, err)
 		})
-		t.Run("timestamp is set correctly", func(t *testing.T) {
-			someTs := time.Now().Add(time.Hour).Unix()
-			res, err := oTree.AddContent(ctx, SignableChangeContent{
-				Data:        []byte("some"),
-				Key:         keys.SignKey,
-				IsSnapshot:  false,
+		t.Run("rotation after read key change", func(t *testing.T) {
+			rotationAcl, rotationKeys := prepareAclList(t)
+			root, err := CreateObjectTreeRoot(ObjectTreeCreatePayload{
+				PrivKey:     rotationKeys.SignKey,
+				ChangeType:  "changeType",
+				SpaceId:     "spaceId",
 				IsEncrypted: true,
-				Timestamp:   someTs,
+				HasTreeKey:  true,
+			}, rotationAcl)
+			require.NoError(t, err)
+			store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+			oTree, err := BuildObjectTree(store, rotationAcl)
+			require.NoError(t, err)
+			var added []*treechangeproto.RawTreeChangeWithId
+			addContent := func(data string, isSnapshot bool) *Change {
+				res, err := oTree.AddContent(ctx, SignableChangeContent{
+					Data:        []byte(data),
+					Key:         rotationKeys.SignKey,
+					IsEncrypted: true,
+					IsSnapshot:  isSnapshot,
+				})
+				require.NoError(t, err)
+				added = append(added, res.Added...)
+				ch, err := oTree.GetChange(res.Heads[0])
+				require.NoError(t, err)
+				return ch
+			}
+			readAll := func(tree ObjectTree) (contents []string, err error) {
+				err = tree.IterateRoot(convert, func(change *Change) bool {
+					if change.Id != root.Id {
+						contents = append(contents, string(change.Model.([]byte)))
+					}
+					return true
+				})
+				return
+			}
+			first := addContent("a", false)
+			require.Equal(t, root.Id, first.ReadKeyId)
+			require.Nil(t, first.TreeKey)
+			firstShare, err := oTree.ShareTreeKey(outsiderKeys.SignKey.GetPublic())
+			require.NoError(t, err)
+
+			// the space read key is changed, e.g. after some account was removed
+			metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+			require.NoError(t, err)
+			readKeyChange, err := rotationAcl.RecordBuilder().BuildReadKeyChange(list.ReadKeyChangePayload{
+				MetadataKey: metadataKey,
+				ReadKey:     crypto.NewAES(),
 			})
 			require.NoError(t, err)
-			require.Len(t, oTree.Heads(), 1)
-			require.Equal(t, res.Added[0].Id, oTree.Heads()[0])
-			ch, err := oTree.(*objectTree).changeBuilder.Unmarshall(res.Added[0], true)
+			require.NoError(t, rotationAcl.AddRawRecord(list.WrapAclRecord(readKeyChange)))
+
+			rotated := addContent("b", false)
+			require.NotNil(t, rotated.TreeKey)
+			require.NotEqual(t, root.Id, rotated.ReadKeyId)
+			require.Equal(t, rotationAcl.AclState().CurrentReadKeyId(), rotated.TreeKey.ReadKeyId)
+			next := addContent("c", false)
+			require.Nil(t, next.TreeKey)
+			require.Equal(t, rotated.ReadKeyId, next.ReadKeyId)
+
+			reloaded, err := BuildObjectTree(store, rotationAcl)
 			require.NoError(t, err)
-			require.Equal(t, ch.Timestamp, someTs)
-			require.Equal(t, res.Added[0].Id, oTree.(*objectTree).tree.lastIteratedHeadId)
+			contents, err := readAll(reloaded)
+			require.NoError(t, err)
+			require.Equal(t, []string{"a", "b", "c"}, contents)
+
+			buildShared := func(shares ...*treechangeproto.TreeKeyShare) ObjectTree {
+				var keys []SharedTreeKey
+				for _, share := range shares {
+					key, err := DecryptTreeKeyShare(share, root.Id, outsiderKeys.SignKey)
+					require.NoError(t, err)
+					keys = append(keys, key)
+				}
+				outsiderAcl, err := list.NewTestAclWithRoot(outsiderKeys, rotationAcl.Root())
+				require.NoError(t, err)
+				records, err := rotationAcl.RecordsAfter(ctx, rotationAcl.Id())
+				require.NoError(t, err)
+				require.NoError(t, outsiderAcl.AddRawRecords(records))
+				store, _ := treestorage.NewInMemoryTreeStorage(root, []string{root.Id}, []*treechangeproto.RawTreeChangeWithId{root})
+				tree, err := BuildSharedObjectTree(store, outsiderAcl, keys)
+				require.NoError(t, err)
+				_, err = tree.AddRawChanges(ctx, RawChangesPayload{
+					NewHeads:   oTree.Heads(),
+					RawChanges: added,
+				})
+				require.NoError(t, err)
+				return tree
+			}
+			// the old share doesn't give access to the changes after the rotation
+			_, err = readAll(buildShared(firstShare))
+			require.Equal(t, list.ErrNoReadKey, err)
+			secondShare, err := oTree.ShareTreeKey(outsiderKeys.SignKey.GetPublic())
+			require.NoError(t, err)
+			require.Equal(t, rotated.ReadKeyId, secondShare.KeyId)
+			contents, err = readAll(buildShared(firstShare, secondShare))
+			require.NoError(t, err)
+			require.Equal(t, []string{"a", "b", "c"}, contents)
+
+			// the snapshot keeps the current generation, so the tree is loaded without the rotating change
+			snapshot := addContent("abcd", true)
+			require.Equal(t, rotated.ReadKeyId, snapshot.ReadKeyId)
+			require.Equal(t, rotated.TreeKey, snapshot.TreeKey)
+			addContent("e", false)
+			reloaded, err = BuildObjectTree(store, rotationAcl)
+			require.NoError(t, err)
+			require.Equal(t, snapshot.Id, reloaded.Root().Id)
+			contents, err = readAll(reloaded)
+			require.NoError(t, err)
+			require.Equal(t, []string{"abcd", "e"}, contents)
+
+			// the tree key must be the generation which encrypts the change
+			fakeKey, err := newTreeKey(rotationAcl.AclState(), crypto.NewAES())
+			require.NoError(t, err)
+			_, fake, err := oTree.(*objectTree).changeBuilder.Build(BuilderContent{
+				TreeHeadIds:    oTree.Heads(),
+				AclHeadId:      rotationAcl.Head().Id,
+				SnapshotBaseId: oTree.Root().Id,
+				ReadKeyId:      rotated.ReadKeyId,
+				PrivKey:        rotationKeys.SignKey,
+				ReadKey:        crypto.NewAES(),
+				Content:        []byte("fake"),
+				TreeKey:        fakeKey,
+			})
+			require.NoError(t, err)
+			_, err = oTree.AddRawChanges(ctx, RawChangesPayload{
+				NewHeads:   []string{fake.Id},
+				RawChanges: []*treechangeproto.RawTreeChangeWithId{fake},
+			})
+			require.ErrorIs(t, err, ErrHasInvalidChanges)
 		})
 	})
 
@@ -849,6 +1819,352 @@ func TestObjectTree(t *testing.T) {
 		})
 	})
 
+	t.Run("compaction", func(t *testing.T) {
+		prepare := func(t *testing.T) testTreeContext {
+			ctx := prepareTreeContext(t, aclList)
+			changeCreator := ctx.changeCreator
+			rawChanges := []*treechangeproto.RawTreeChangeWithId{
+				changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+				changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "1"),
+				changeCreator.CreateRaw("3", aclList.Head().Id, "0", true, "2"),
+				changeCreator.CreateRaw("4", aclList.Head().Id, "3", false, "3"),
+				changeCreator.CreateRaw("5", aclList.Head().Id, "3", true, "4"),
+				changeCreator.CreateRaw("6", aclList.Head().Id, "5", false, "5"),
+			}
+			_, err := ctx.objTree.AddRawChanges(context.Background(), RawChangesPayload{
+				NewHeads:   []string{"6"},
+				RawChanges: rawChanges,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"5", "3", "0"}, ctx.objTree.SnapshotPath())
+			return ctx
+		}
+		storedIds := func(ctx testTreeContext) (ids []string) {
+			for id := range ctx.treeStorage.(*treestorage.InMemoryTreeStorage).Changes {
+				ids = append(ids, id)
+			}
+			slices.Sort(ids)
+			return
+		}
+
+		peers := []string{"peer1"}
+		bothPeers := []string{"peer1", "peer2"}
+
+		changeIds := func(changes []*treechangeproto.RawTreeChangeWithId) (ids []string) {
+			for _, ch := range changes {
+				ids = append(ids, ch.Id)
+			}
+			slices.Sort(ids)
+			return
+		}
+
+		t.Run("keep snapshots", func(t *testing.T) {
+			ctx := prepare(t)
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 2, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 2, removed)
+			require.Equal(t, []string{"0", "3", "4", "5", "6"}, storedIds(ctx))
+			compactedId, err := ctx.treeStorage.CompactedSnapshotId()
+			require.NoError(t, err)
+			require.Equal(t, "3", compactedId)
+
+			changes, err := ctx.objTree.ChangesAfterCommonSnapshot([]string{"3", "0"}, []string{"4"})
+			require.NoError(t, err)
+			require.Equal(t, []string{"5", "6"}, changeIds(changes))
+
+			// the snapshots of the path are kept
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 1, removed)
+			require.Equal(t, []string{"0", "3", "5", "6"}, storedIds(ctx))
+			require.Equal(t, []string{"5", "3", "0"}, ctx.objTree.SnapshotPath())
+
+			// the compaction point is not moved back
+			ctx.objTree.AckSnapshotPath("peer2", []string{"3", "0"})
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 2, AckPeers: bothPeers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+
+			objTree, err := BuildTestableTree(ctx.treeStorage, aclList)
+			require.NoError(t, err)
+			require.Equal(t, "5", objTree.Root().Id)
+			require.Equal(t, []string{"6"}, objTree.Heads())
+		})
+		t.Run("compacted history is served", func(t *testing.T) {
+			ctx := prepare(t)
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 3, removed)
+
+			for _, theirPath := range [][]string{nil, {"0"}} {
+				changes, err := ctx.objTree.ChangesAfterCommonSnapshot(theirPath, []string{"1"})
+				require.NoError(t, err)
+				require.Equal(t, []string{"0", "3", "5", "6"}, changeIds(changes))
+				require.Equal(t, "0", changes[0].Id)
+			}
+
+			// the new peer builds the tree from the latest snapshot
+			changes, err := ctx.objTree.ChangesAfterCommonSnapshot(nil, nil)
+			require.NoError(t, err)
+			store, err := treestorage.NewInMemoryTreeStorage(changes[0], []string{"6"}, changes)
+			require.NoError(t, err)
+			objTree, err := BuildTestableTree(store, aclList)
+			require.NoError(t, err)
+			require.Equal(t, "5", objTree.Root().Id)
+			require.Equal(t, []string{"6"}, objTree.Heads())
+			require.Equal(t, []string{"5", "3", "0"}, objTree.SnapshotPath())
+		})
+		t.Run("no compaction without policy or acknowledgements", func(t *testing.T) {
+			ctx := prepare(t)
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			removed, err = ctx.objTree.Compact(CompactionPolicy{AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+			require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, storedIds(ctx))
+		})
+		t.Run("all policy peers acknowledge", func(t *testing.T) {
+			ctx := prepare(t)
+			// the acknowledgements of the other peers are not counted
+			ctx.objTree.AckSnapshotPath("client", []string{"5", "3", "0"})
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: bothPeers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+
+			// the acknowledgements are not kept after the tree is reloaded
+			objTree, err := BuildTestableTree(ctx.treeStorage, aclList)
+			require.NoError(t, err)
+			removed, err = objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+			require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, storedIds(ctx))
+
+			objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			removed, err = objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 3, removed)
+		})
+		t.Run("peers behind", func(t *testing.T) {
+			ctx := prepare(t)
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			ctx.objTree.AckSnapshotPath("peer2", []string{"3", "0"})
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: bothPeers})
+			require.NoError(t, err)
+			require.Equal(t, 2, removed)
+			changes, err := ctx.objTree.ChangesAfterCommonSnapshot([]string{"3", "0"}, []string{"4"})
+			require.NoError(t, err)
+			require.Equal(t, []string{"5", "6"}, changeIds(changes))
+
+			ctx = prepare(t)
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			ctx.objTree.AckSnapshotPath("peer2", []string{"0"})
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: bothPeers})
+			require.NoError(t, err)
+			require.Equal(t, 0, removed)
+
+			// the peer caught up
+			ctx.objTree.AckSnapshotPath("peer2", []string{"5", "3", "0"})
+			removed, err = ctx.objTree.Compact(CompactionPolicy{KeepSnapshots: 1, AckPeers: bothPeers})
+			require.NoError(t, err)
+			require.Equal(t, 3, removed)
+		})
+		t.Run("keep duration", func(t *testing.T) {
+			ctx := prepare(t)
+			ctx.objTree.AckSnapshotPath("peer1", []string{"5", "3", "0"})
+			// the timestamps of the test changes are zero, so only the latest snapshot is kept
+			removed, err := ctx.objTree.Compact(CompactionPolicy{KeepDuration: time.Hour, AckPeers: peers})
+			require.NoError(t, err)
+			require.Equal(t, 3, removed)
+			require.Equal(t, []string{"0", "3", "5", "6"}, storedIds(ctx))
+		})
+	})
+
+	t.Run("paged iterator", func(t *testing.T) {
+		ctx := prepareTreeContext(t, aclList)
+		changeCreator := ctx.changeCreator
+		objTree := ctx.objTree
+
+		rawChanges := []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "0", true, "2"),
+			changeCreator.CreateRaw("4", aclList.Head().Id, "0", false, "2"),
+			changeCreator.CreateRaw("5", aclList.Head().Id, "0", false, "1"),
+			changeCreator.CreateRaw("6", aclList.Head().Id, "0", true, "3", "4", "5"),
+		}
+		_, err := objTree.AddRawChanges(context.Background(), RawChangesPayload{
+			NewHeads:   []string{"6"},
+			RawChanges: rawChanges,
+		})
+		require.NoError(t, err)
+		readPages := func(iter PagedIterator, limit int) (pages [][]string) {
+			for {
+				changes, err := iter.NextPage(context.Background(), limit)
+				require.NoError(t, err)
+				if len(changes) == 0 {
+					return
+				}
+				var ids []string
+				for _, ch := range changes {
+					ids = append(ids, ch.Id)
+				}
+				pages = append(pages, ids)
+			}
+		}
+
+		t.Run("from root", func(t *testing.T) {
+			iter, err := objTree.NewPagedIterator("")
+			require.NoError(t, err)
+			require.Equal(t, 7, iter.Len())
+			pages := readPages(iter, 3)
+			require.Equal(t, [][]string{{"0", "1", "2"}, {"3", "4", "5"}, {"6"}}, pages)
+			require.Equal(t, 7, iter.Cursor())
+
+			// the order is the same as the order of the full tree
+			hTree, err := BuildNonVerifiableHistoryTree(HistoryTreeParams{
+				TreeStorage:   ctx.treeStorage,
+				AclList:       aclList,
+				BuildFullTree: true,
+			})
+			require.NoError(t, err)
+			var treeOrder []string
+			err = hTree.IterateRoot(nil, func(change *Change) bool {
+				treeOrder = append(treeOrder, change.Id)
+				return true
+			})
+			require.NoError(t, err)
+			var pagedOrder []string
+			for _, page := range pages {
+				pagedOrder = append(pagedOrder, page...)
+			}
+			require.Equal(t, treeOrder, pagedOrder)
+		})
+		t.Run("from change", func(t *testing.T) {
+			iter, err := objTree.NewPagedIterator("3")
+			require.NoError(t, err)
+			require.Equal(t, [][]string{{"3", "6"}}, readPages(iter, 10))
+		})
+		t.Run("branches are iterated depth first", func(t *testing.T) {
+			ctx := prepareTreeContext(t, aclList)
+			_, err := ctx.objTree.AddRawChanges(context.Background(), RawChangesPayload{
+				NewHeads: []string{"2", "3"},
+				RawChanges: []*treechangeproto.RawTreeChangeWithId{
+					ctx.changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+					ctx.changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "0"),
+					ctx.changeCreator.CreateRaw("3", aclList.Head().Id, "0", false, "1"),
+				},
+			})
+			require.NoError(t, err)
+			var treeOrder []string
+			err = ctx.objTree.IterateRoot(nil, func(change *Change) bool {
+				treeOrder = append(treeOrder, change.Id)
+				return true
+			})
+			require.NoError(t, err)
+			require.Equal(t, []string{"0", "1", "3", "2"}, treeOrder)
+			iter, err := ctx.objTree.NewPagedIterator("")
+			require.NoError(t, err)
+			require.Equal(t, [][]string{treeOrder}, readPages(iter, 10))
+		})
+		t.Run("unknown change", func(t *testing.T) {
+			_, err := objTree.NewPagedIterator("unknown")
+			require.Equal(t, ErrNoChangeInTree, err)
+		})
+	})
+
+	t.Run("custom ordering of history tree and paged iterator", func(t *testing.T) {
+		ctx := prepareTreeContext(t, aclList)
+		_, err := ctx.objTree.AddRawChanges(context.Background(), RawChangesPayload{
+			NewHeads: []string{"1", "2"},
+			RawChanges: []*treechangeproto.RawTreeChangeWithId{
+				ctx.changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+				ctx.changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "0"),
+			},
+		})
+		require.NoError(t, err)
+		reversed := func(a, b *Change) bool {
+			return a.Id > b.Id
+		}
+		iterate := func(tree ReadableObjectTree) (ids []string) {
+			err := tree.IterateRoot(nil, func(change *Change) bool {
+				ids = append(ids, change.Id)
+				return true
+			})
+			require.NoError(t, err)
+			return
+		}
+
+		hTree, err := BuildNonVerifiableHistoryTree(HistoryTreeParams{
+			TreeStorage: ctx.treeStorage,
+			AclList:     aclList,
+			Ordering:    reversed,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"0", "2", "1"}, iterate(hTree))
+
+		objTree, err := WithOrdering(BuildTestableTree, reversed)(ctx.treeStorage, aclList)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0", "2", "1"}, iterate(objTree))
+		iter, err := objTree.NewPagedIterator("")
+		require.NoError(t, err)
+		changes, err := iter.NextPage(context.Background(), 10)
+		require.NoError(t, err)
+		var ids []string
+		for _, ch := range changes {
+			ids = append(ids, ch.Id)
+		}
+		require.Equal(t, []string{"0", "2", "1"}, ids)
+	})
+
+	t.Run("changes diff", func(t *testing.T) {
+		ctx := prepareTreeContext(t, aclList)
+		changeCreator := ctx.changeCreator
+		objTree := ctx.objTree
+
+		rawChanges := []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", false, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "0", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "0", true, "2"),
+			changeCreator.CreateRaw("4", aclList.Head().Id, "0", false, "2"),
+			changeCreator.CreateRaw("5", aclList.Head().Id, "0", false, "1"),
+			changeCreator.CreateRaw("6", aclList.Head().Id, "0", true, "3", "4", "5"),
+		}
+		_, err := objTree.AddRawChanges(context.Background(), RawChangesPayload{
+			NewHeads:   []string{"6"},
+			RawChanges: rawChanges,
+		})
+		require.NoError(t, err)
+		diffIds := func(baseHeads, heads []string) (ids []string) {
+			changes, err := objTree.ChangesDiff(baseHeads, heads, nil)
+			require.NoError(t, err)
+			for _, ch := range changes {
+				ids = append(ids, ch.Id)
+			}
+			return
+		}
+
+		require.Equal(t, []string{"3", "4", "5", "6"}, diffIds([]string{"2"}, []string{"6"}))
+		require.Equal(t, []string{"5"}, diffIds([]string{"4"}, []string{"5"}))
+		require.Equal(t, []string{"3", "5"}, diffIds([]string{"4"}, []string{"3", "5"}))
+		require.Empty(t, diffIds([]string{"6"}, []string{"2"}))
+		require.Equal(t, []string{"0", "1", "2"}, diffIds(nil, []string{"2"}))
+		_, err = objTree.ChangesDiff([]string{"unknown"}, []string{"6"}, nil)
+		require.Equal(t, ErrNoChangeInTree, err)
+	})
+
 	t.Run("add new changes related to previous snapshot", func(t *testing.T) {
 		ctx := prepareTreeContext(t, aclList)
 		treeStorage := ctx.treeStorage
@@ -974,6 +2290,36 @@ func TestObjectTree(t *testing.T) {
 		assert.Equal(t, "0", hTree.Root().Id)
 	})
 
+	t.Run("test history tree build from root", func(t *testing.T) {
+		changeCreator, deps := prepareHistoryTreeDeps(aclList)
+
+		rawChanges := []*treechangeproto.RawTreeChangeWithId{
+			changeCreator.CreateRaw("1", aclList.Head().Id, "0", true, "0"),
+			changeCreator.CreateRaw("2", aclList.Head().Id, "1", false, "1"),
+			changeCreator.CreateRaw("3", aclList.Head().Id, "1", true, "2"),
+			changeCreator.CreateRaw("4", aclList.Head().Id, "3", false, "3"),
+		}
+		deps.treeStorage.AddRawChangesSetHeads(rawChanges, []string{"4"})
+		hTree, err := buildHistoryTree(deps, HistoryTreeParams{
+			BeforeId:        "3",
+			IncludeBeforeId: true,
+			BuildFromRoot:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3"}, hTree.Heads())
+		assert.Equal(t, "0", hTree.Root().Id)
+
+		// the full tree ignores BeforeId
+		hTree, err = buildHistoryTree(deps, HistoryTreeParams{
+			BeforeId:        "3",
+			IncludeBeforeId: true,
+			BuildFullTree:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"4"}, hTree.Heads())
+		assert.Equal(t, "0", hTree.Root().Id)
+	})
+
 	t.Run("test history tree include", func(t *testing.T) {
 		changeCreator, deps := prepareHistoryTreeDeps(aclList)
 
@@ -9,6 +9,8 @@ type DebugInfo struct {
 	Graphviz     string
 	Heads        []string
 	SnapshotPath []string
+	// Graph can be rendered as DOT, Mermaid or JSON on every platform
+	Graph TreeGraph
 }
 
 func (o objectTreeDebug) debugInfo(ot *objectTree, parser DescriptionParser) (di DebugInfo, err error) {
@@ -17,6 +19,10 @@ func (o objectTreeDebug) debugInfo(ot *objectTree, parser DescriptionParser) (di
 	if err != nil {
 		return
 	}
+	di.Graph, err = ot.tree.RenderGraph(parser)
+	if err != nil {
+		return
+	}
 	di.TreeString = ot.tree.String()
 	di.TreeLen = ot.tree.Len()
 	di.Heads = ot.Heads()
@@ -15,6 +15,9 @@ type ObjectTreeCreatePayload struct {
 	IsEncrypted   bool
 	Seed          []byte
 	Timestamp     int64
+	// HasTreeKey tells if the tree should be encrypted with its own key,
+	// which can be shared with other identities without sharing the space read key
+	HasTreeKey bool
 }
 
 type ObjectTreeDerivePayload struct {
@@ -30,6 +33,11 @@ type HistoryTreeParams struct {
 	BeforeId        string
 	IncludeBeforeId bool
 	BuildFullTree   bool
+	// BuildFromRoot tells to build the tree from the root ending with BeforeId,
+	// unlike BuildFullTree which builds the tree from the root with the current heads ignoring BeforeId
+	BuildFromRoot bool
+	// Ordering defines the order of the concurrent changes, it should be the same as the ordering of the tree
+	Ordering ChangeOrdering
 }
 
 type objectTreeDeps struct {
@@ -39,6 +47,8 @@ type objectTreeDeps struct {
 	validator       ObjectTreeValidator
 	rawChangeLoader *rawChangeLoader
 	aclList         list.AclList
+	// treeKeys are the generations of the own key of the tree known in advance
+	treeKeys []SharedTreeKey
 }
 
 type BuildObjectTreeFunc = func(treeStorage treestorage.TreeStorage, aclList list.AclList) (ObjectTree, error)
@@ -145,6 +155,18 @@ func BuildObjectTree(treeStorage treestorage.TreeStorage, aclList list.AclList)
 	return buildObjectTree(deps)
 }
 
+// BuildSharedObjectTree builds the tree having its own key for the identity which is not a member of the space,
+// the generations of the key can be obtained from the shares using DecryptTreeKeyShare
+func BuildSharedObjectTree(treeStorage treestorage.TreeStorage, aclList list.AclList, treeKeys []SharedTreeKey) (ObjectTree, error) {
+	rootChange, err := treeStorage.Root()
+	if err != nil {
+		return nil, err
+	}
+	deps := defaultObjectTreeDeps(rootChange, treeStorage, aclList)
+	deps.treeKeys = treeKeys
+	return buildObjectTree(deps)
+}
+
 func BuildNonVerifiableHistoryTree(params HistoryTreeParams) (HistoryTree, error) {
 	rootChange, err := params.TreeStorage.Root()
 	if err != nil {
@@ -164,8 +186,12 @@ func BuildHistoryTree(params HistoryTreeParams) (HistoryTree, error) {
 }
 
 func CreateObjectTreeRoot(payload ObjectTreeCreatePayload, aclList list.AclList) (root *treechangeproto.RawTreeChangeWithId, err error) {
+	var treeKey *treechangeproto.TreeKey
 	aclList.RLock()
 	aclHeadId := aclList.Head().Id
+	if payload.HasTreeKey {
+		treeKey, err = newTreeKey(aclList.AclState(), crypto.NewAES())
+	}
 	aclList.RUnlock()
 
 	if err != nil {
@@ -179,6 +205,7 @@ func CreateObjectTreeRoot(payload ObjectTreeCreatePayload, aclList list.AclList)
 		ChangePayload: payload.ChangePayload,
 		Timestamp:     payload.Timestamp,
 		Seed:          payload.Seed,
+		TreeKey:       treeKey,
 	}
 
 	_, root, err = NewChangeBuilder(crypto.NewKeyStorage(), nil).BuildRoot(cnt)
@@ -211,7 +238,12 @@ func buildObjectTree(deps objectTreeDeps) (ObjectTree, error) {
 		newSnapshotsBuf: make([]*Change, 0, 10),
 	}
 
-	err := objTree.rebuildFromStorage(nil, nil)
+	err := objTree.readRootTreeKey(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = objTree.rebuildFromStorage(nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +279,12 @@ func buildHistoryTree(deps objectTreeDeps, params HistoryTreeParams) (ht History
 		newSnapshotsBuf: make([]*Change, 0, 10),
 	}
 
+	err = objTree.readRootTreeKey(deps)
+	if err != nil {
+		return nil, err
+	}
+
+	objTree.treeBuilder.ordering = params.Ordering
 	hTree := &historyTree{objectTree: objTree}
 	err = hTree.rebuildFromStorage(params)
 	if err != nil {
@@ -265,3 +303,25 @@ func buildHistoryTree(deps objectTreeDeps, params HistoryTreeParams) (ht History
 	objTree.root = header
 	return hTree, nil
 }
+
+func (ot *objectTree) readRootTreeKey(deps objectTreeDeps) (err error) {
+	rawRoot, err := ot.treeStorage.Root()
+	if err != nil {
+		return
+	}
+	rootKey, err := rootTreeKey(rawRoot)
+	if err != nil {
+		return
+	}
+	if rootKey == nil {
+		if len(deps.treeKeys) != 0 {
+			return ErrNoTreeKey
+		}
+		return
+	}
+	ot.treeKeys = map[string]*treechangeproto.TreeKey{ot.id: rootKey}
+	for _, key := range deps.treeKeys {
+		ot.keys[key.KeyId] = key.Key
+	}
+	return
+}
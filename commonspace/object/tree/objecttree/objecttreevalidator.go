@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
 	"github.com/anyproto/any-sync/util/slice"
@@ -63,7 +64,7 @@ func (v *objectTreeValidator) validateChange(tree *Tree, aclList list.AclList, c
 	if err != nil {
 		return
 	}
-	if !userState.Permissions.CanWrite() {
+	if !userState.Capabilities.Has(aclrecordproto.AclCapability_CapabilityWrite) {
 		err = list.ErrInsufficientPermissions
 		return
 	}
@@ -94,11 +95,17 @@ func (v *objectTreeValidator) validateChange(tree *Tree, aclList list.AclList, c
 }
 
 func ValidateRawTree(payload treestorage.TreeStorageCreatePayload, aclList list.AclList) (err error) {
+	return ValidateRawTreeBuildFunc(payload, BuildObjectTree, aclList)
+}
+
+// ValidateRawTreeBuildFunc validates the tree built by the function, so the tree is checked
+// by the content validators and the limits of the function the same way as when it is loaded
+func ValidateRawTreeBuildFunc(payload treestorage.TreeStorageCreatePayload, build BuildObjectTreeFunc, aclList list.AclList) (err error) {
 	treeStorage, err := treestorage.NewInMemoryTreeStorage(payload.RootRawChange, []string{payload.RootRawChange.Id}, nil)
 	if err != nil {
 		return
 	}
-	tree, err := BuildObjectTree(treeStorage, aclList)
+	tree, err := build(treeStorage, aclList)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,89 @@
+package objecttree
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ErrOrderingNotSupported = errors.New("the tree doesn't support custom ordering")
+
+// ChangeOrdering returns true if the concurrent change a should be iterated before b.
+// The order must be total and must depend only on the contents of the changes,
+// so the tree is iterated in the same order by all peers
+type ChangeOrdering func(a, b *Change) bool
+
+// OrderById is the default ordering of the concurrent changes
+func OrderById(a, b *Change) bool {
+	return a.Id < b.Id
+}
+
+// OrderByTimestamp iterates the earlier changes first, the changes with equal timestamps are ordered by id
+func OrderByTimestamp(a, b *Change) bool {
+	if a.Timestamp != b.Timestamp {
+		return a.Timestamp < b.Timestamp
+	}
+	return a.Id < b.Id
+}
+
+// OrderByIdentityPriority iterates the changes of the identities with higher priority last,
+// so their changes are applied on top of the others, the changes with equal priority are ordered by id
+func OrderByIdentityPriority(priority func(identity crypto.PubKey) int) ChangeOrdering {
+	return func(a, b *Change) bool {
+		pa, pb := identityPriority(priority, a), identityPriority(priority, b)
+		if pa != pb {
+			return pa < pb
+		}
+		return a.Id < b.Id
+	}
+}
+
+func identityPriority(priority func(identity crypto.PubKey) int, ch *Change) int {
+	if ch.Identity == nil {
+		return 0
+	}
+	return priority(ch.Identity)
+}
+
+// WithOrdering returns the function building the trees which iterate the concurrent changes in the given order
+func WithOrdering(build BuildObjectTreeFunc, ordering ChangeOrdering) BuildObjectTreeFunc {
+	return func(treeStorage treestorage.TreeStorage, aclList list.AclList) (ObjectTree, error) {
+		objTree, err := build(treeStorage, aclList)
+		if err != nil {
+			return nil, err
+		}
+		ot, ok := objTree.(*objectTree)
+		if !ok {
+			return nil, ErrOrderingNotSupported
+		}
+		ot.setOrdering(ordering)
+		return ot, nil
+	}
+}
+
+func (ot *objectTree) setOrdering(ordering ChangeOrdering) {
+	ot.treeBuilder.ordering = ordering
+	ot.tree.setOrdering(ordering)
+}
+
+func (t *Tree) setOrdering(ordering ChangeOrdering) {
+	t.ordering = ordering
+	for _, ch := range t.attached {
+		sort.SliceStable(ch.Next, func(i, j int) bool {
+			return ordering(ch.Next[i], ch.Next[j])
+		})
+	}
+	if t.root != nil {
+		t.updateHeads()
+	}
+}
+
+func (t *Tree) less(a, b *Change) bool {
+	if t.ordering == nil {
+		return OrderById(a, b)
+	}
+	return t.ordering(a, b)
+}
@@ -0,0 +1,253 @@
+package objecttree
+
+import (
+	"context"
+	"sort"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// PagedIterator iterates over the changes of the tree reading them from the storage page by page.
+// The order is built from the index of the ids and the links of the changes, the changes themselves
+// are loaded when the page is requested
+type PagedIterator interface {
+	// NextPage returns at most limit next changes in the order of the tree iteration, the empty page means the end of iteration.
+	// The data of the returned changes is not decrypted
+	NextPage(ctx context.Context, limit int) ([]*Change, error)
+	// Unpack returns the decrypted data of the change
+	Unpack(change *Change) ([]byte, error)
+	// Cursor returns the number of the changes already returned
+	Cursor() int
+	// Len returns the total number of the changes to iterate
+	Len() int
+}
+
+// NewPagedIterator returns the iterator over the stored changes starting with fromId and including all changes after it,
+// if fromId is empty the iteration starts from the root or from the compacted snapshot if the tree was compacted
+func (ot *objectTree) NewPagedIterator(fromId string) (PagedIterator, error) {
+	if fromId == "" {
+		compactedId, err := ot.treeStorage.CompactedSnapshotId()
+		if err != nil {
+			return nil, err
+		}
+		fromId = ot.id
+		if compactedId != "" {
+			fromId = compactedId
+		}
+	}
+	heads, err := ot.treeStorage.Heads()
+	if err != nil {
+		return nil, err
+	}
+	order, err := storageOrder(context.Background(), ot.treeStorage, ot.changeBuilder, ot.tree.ordering, heads, fromId)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.SymKey, len(ot.keys))
+	for id, key := range ot.keys {
+		keys[id] = key
+	}
+	return &pagedIterator{
+		rootId:        ot.id,
+		treeStorage:   ot.treeStorage,
+		changeBuilder: ot.changeBuilder,
+		keys:          keys,
+		order:         order,
+	}, nil
+}
+
+type pagedIterator struct {
+	rootId        string
+	treeStorage   treestorage.TreeStorage
+	changeBuilder ChangeBuilder
+	keys          map[string]crypto.SymKey
+	order         []string
+	pos           int
+}
+
+func (p *pagedIterator) NextPage(ctx context.Context, limit int) (changes []*Change, err error) {
+	end := p.pos + limit
+	if end > len(p.order) || limit <= 0 {
+		end = len(p.order)
+	}
+	changes = make([]*Change, 0, end-p.pos)
+	for _, id := range p.order[p.pos:end] {
+		raw, err := p.treeStorage.GetRawChange(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ch, err := p.changeBuilder.Unmarshall(raw, true)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, ch)
+	}
+	p.pos = end
+	return
+}
+
+func (p *pagedIterator) Unpack(change *Change) ([]byte, error) {
+	if change.Id == p.rootId {
+		return change.Data, nil
+	}
+	if change.ReadKeyId == "" {
+		return decompress(change.Compression, change.Data)
+	}
+	readKey, exists := p.keys[change.ReadKeyId]
+	if !exists {
+		return nil, list.ErrNoReadKey
+	}
+	decrypted, err := readKey.Decrypt(change.Data)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(change.Compression, decrypted)
+}
+
+func (p *pagedIterator) Cursor() int {
+	return p.pos
+}
+
+func (p *pagedIterator) Len() int {
+	return len(p.order)
+}
+
+// orderNode is the compact entry of the change in the index used to order the stored changes
+type orderNode struct {
+	id       string
+	prevIds  []string
+	next     []int
+	visited  bool
+	finished bool
+}
+
+// storageOrder returns the ids of the change fromId and all stored changes after it in the same order as Tree.Iterate.
+// Only the ids and the links of the changes are kept in the index, the concurrent changes are loaded again
+// to be compared by the ordering and the data is read again when the page is requested
+func storageOrder(ctx context.Context, storage treestorage.TreeStorage, builder ChangeBuilder, ordering ChangeOrdering, heads []string, fromId string) ([]string, error) {
+	if ordering == nil {
+		ordering = OrderById
+	}
+	var (
+		nodes   []orderNode
+		indexes = make(map[string]int)
+		stack   = append([]string(nil), heads...)
+	)
+	loadHeader := func(id string) (*Change, error) {
+		raw, err := storage.GetRawChange(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return builder.Unmarshall(raw, false)
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, exists := indexes[id]; exists {
+			continue
+		}
+		has, err := storage.HasChange(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// the change was removed by compaction
+		if !has {
+			continue
+		}
+		ch, err := loadHeader(id)
+		if err != nil {
+			return nil, err
+		}
+		indexes[id] = len(nodes)
+		node := orderNode{id: id}
+		if id != fromId {
+			node.prevIds = ch.PreviousIds
+			stack = append(stack, ch.PreviousIds...)
+		}
+		nodes = append(nodes, node)
+	}
+	start, exists := indexes[fromId]
+	if !exists {
+		return nil, ErrNoChangeInTree
+	}
+
+	// linking the changes the same way as they are linked in the tree
+	for idx := range nodes {
+		for _, prevId := range nodes[idx].prevIds {
+			if prevIdx, exists := indexes[prevId]; exists {
+				nodes[prevIdx].next = append(nodes[prevIdx].next, idx)
+			}
+		}
+		nodes[idx].prevIds = nil
+	}
+	var concurrent []*Change
+	for idx := range nodes {
+		next := nodes[idx].next
+		if len(next) < 2 {
+			continue
+		}
+		concurrent = concurrent[:0]
+		for _, nextIdx := range next {
+			ch, err := loadHeader(nodes[nextIdx].id)
+			if err != nil {
+				return nil, err
+			}
+			concurrent = append(concurrent, ch)
+		}
+		sort.Sort(concurrentChanges{changes: concurrent, next: next, ordering: ordering})
+	}
+
+	// the same topological sort as in the tree iterator
+	var (
+		sorted = make([]int, 0, len(nodes))
+		visit  = []int{start}
+	)
+	for len(visit) > 0 {
+		idx := visit[len(visit)-1]
+		visit = visit[:len(visit)-1]
+		node := &nodes[idx]
+		if node.finished {
+			sorted = append(sorted, idx)
+			node.finished = false
+			continue
+		}
+		if node.visited {
+			continue
+		}
+		visit = append(visit, idx)
+		node.visited = true
+		node.finished = true
+		for _, nextIdx := range node.next {
+			if !nodes[nextIdx].visited {
+				visit = append(visit, nextIdx)
+			}
+		}
+	}
+	order := make([]string, 0, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		order = append(order, nodes[sorted[i]].id)
+	}
+	return order, nil
+}
+
+// concurrentChanges sorts the indexes of the next changes together with the loaded changes
+type concurrentChanges struct {
+	changes  []*Change
+	next     []int
+	ordering ChangeOrdering
+}
+
+func (c concurrentChanges) Len() int {
+	return len(c.next)
+}
+
+func (c concurrentChanges) Less(i, j int) bool {
+	return c.ordering(c.changes[i], c.changes[j])
+}
+
+func (c concurrentChanges) Swap(i, j int) {
+	c.changes[i], c.changes[j] = c.changes[j], c.changes[i]
+	c.next[i], c.next[j] = c.next[j], c.next[i]
+}
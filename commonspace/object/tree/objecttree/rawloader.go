@@ -133,60 +133,11 @@ func (r *rawChangeLoader) loadFromStorage(commonSnapshot string, heads, breakpoi
 	}
 	r.cache[commonSnapshot] = rawCacheEntry{position: -1}
 
-	dfs := func(
-		commonSnapshot string,
-		heads []string,
-		shouldVisit func(entry rawCacheEntry, mapExists bool) bool,
-		visit func(entry rawCacheEntry) rawCacheEntry) bool {
-
-		// resetting stack
-		r.idStack = r.idStack[:0]
-		r.idStack = append(r.idStack, heads...)
-
-		commonSnapshotVisited := false
-		var err error
-		for len(r.idStack) > 0 {
-			id := r.idStack[len(r.idStack)-1]
-			r.idStack = r.idStack[:len(r.idStack)-1]
-
-			entry, exists := r.cache[id]
-			if !shouldVisit(entry, exists) {
-				continue
-			}
-			if id == commonSnapshot {
-				commonSnapshotVisited = true
-				continue
-			}
-			if !exists {
-				entry, err = r.loadEntry(id)
-				if err != nil {
-					continue
-				}
-			}
-			// setting the counter when we visit
-			entry = visit(entry)
-			r.cache[id] = entry
-
-			for _, prev := range entry.change.PreviousIds {
-				if prev == commonSnapshot {
-					commonSnapshotVisited = true
-					break
-				}
-				prevEntry, exists := r.cache[prev]
-				if !shouldVisit(prevEntry, exists) {
-					continue
-				}
-				r.idStack = append(r.idStack, prev)
-			}
-		}
-		return commonSnapshotVisited
-	}
-
 	// preparing first pass
 	r.idStack = append(r.idStack, heads...)
 	var buffer []*treechangeproto.RawTreeChangeWithId
 
-	rootVisited := dfs(commonSnapshot, heads,
+	rootVisited := r.dfs(commonSnapshot, heads,
 		func(_ rawCacheEntry, mapExists bool) bool {
 			return !mapExists
 		},
@@ -212,7 +163,7 @@ func (r *rawChangeLoader) loadFromStorage(commonSnapshot string, heads, breakpoi
 	}
 
 	// marking all visited as nil
-	dfs(commonSnapshot, existingBreakpoints,
+	r.dfs(commonSnapshot, existingBreakpoints,
 		func(entry rawCacheEntry, mapExists bool) bool {
 			// only going through already loaded changes
 			return mapExists && !entry.removed
@@ -233,6 +184,106 @@ func (r *rawChangeLoader) loadFromStorage(commonSnapshot string, heads, breakpoi
 	return buffer, nil
 }
 
+// LoadDiff returns the changes from storage which are reachable from heads but not from baseHeads.
+// The changes before the common snapshot of all heads are reachable from both sets, so they are not loaded
+func (r *rawChangeLoader) LoadDiff(commonSnapshot string, heads, baseHeads []string) ([]*Change, error) {
+	// resetting cache
+	r.cache = make(map[string]rawCacheEntry)
+	defer func() {
+		r.cache = nil
+	}()
+	r.cache[commonSnapshot] = rawCacheEntry{position: -1}
+
+	var buffer []*Change
+	r.dfs(commonSnapshot, heads,
+		func(_ rawCacheEntry, mapExists bool) bool {
+			return !mapExists
+		},
+		func(entry rawCacheEntry) rawCacheEntry {
+			buffer = append(buffer, entry.change)
+			entry.position = len(buffer) - 1
+			return entry
+		})
+
+	// if there are no base heads then we should load the common snapshot also
+	if len(baseHeads) == 0 {
+		common, err := r.loadEntry(commonSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		return append(buffer, common.change), nil
+	}
+
+	// unlike breakpoints in Load the base heads can be concurrent to heads,
+	// so we also go through the changes which were not loaded in the first pass
+	r.dfs(commonSnapshot, baseHeads,
+		func(entry rawCacheEntry, mapExists bool) bool {
+			return !mapExists || !entry.removed
+		},
+		func(entry rawCacheEntry) rawCacheEntry {
+			entry.removed = true
+			if entry.position != -1 {
+				buffer[entry.position] = nil
+			}
+			return entry
+		})
+
+	// discarding visited
+	buffer = slice.DiscardFromSlice(buffer, func(change *Change) bool {
+		return change == nil
+	})
+	return buffer, nil
+}
+
+func (r *rawChangeLoader) dfs(
+	commonSnapshot string,
+	heads []string,
+	shouldVisit func(entry rawCacheEntry, mapExists bool) bool,
+	visit func(entry rawCacheEntry) rawCacheEntry) bool {
+
+	// resetting stack
+	r.idStack = r.idStack[:0]
+	r.idStack = append(r.idStack, heads...)
+
+	commonSnapshotVisited := false
+	var err error
+	for len(r.idStack) > 0 {
+		id := r.idStack[len(r.idStack)-1]
+		r.idStack = r.idStack[:len(r.idStack)-1]
+
+		entry, exists := r.cache[id]
+		if !shouldVisit(entry, exists) {
+			continue
+		}
+		if id == commonSnapshot {
+			commonSnapshotVisited = true
+			continue
+		}
+		if !exists {
+			entry, err = r.loadEntry(id)
+			if err != nil {
+				continue
+			}
+		}
+		// setting the counter when we visit
+		entry = visit(entry)
+		r.cache[id] = entry
+
+		for _, prev := range entry.change.PreviousIds {
+			if prev == commonSnapshot {
+				commonSnapshotVisited = true
+				break
+			}
+			prevEntry, exists := r.cache[prev]
+			if !shouldVisit(prevEntry, exists) {
+				continue
+			}
+			r.idStack = append(r.idStack, prev)
+		}
+	}
+	return commonSnapshotVisited
+}
+
 func (r *rawChangeLoader) loadEntry(id string) (entry rawCacheEntry, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
@@ -0,0 +1,132 @@
+package objecttree
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ErrNoStateBuilder = errors.New("state builder and convert func are required for revert")
+
+// VersionStateBuilder builds the state of the tree at some version
+type VersionStateBuilder interface {
+	// Apply applies the change to the state, the changes are passed in the order of the tree
+	// starting from the closest snapshot, Model of the change is the result of ChangeConvertFunc
+	Apply(change *Change) error
+	// Snapshot returns the data of the snapshot change containing the whole state
+	Snapshot() ([]byte, error)
+}
+
+// RevertParams is a payload to be passed when we are reverting the tree to one of its versions
+type RevertParams struct {
+	// ChangeId is the id of the change which is the last one included in the version
+	ChangeId string
+	// Convert converts the decrypted data of the changes before passing them to StateBuilder
+	Convert ChangeConvertFunc
+	// StateBuilder builds the content of the snapshot from the changes of the version
+	StateBuilder VersionStateBuilder
+	// Key is the key which will be used to sign the snapshot
+	Key crypto.PrivKey
+	// IsEncrypted tells if we encrypt the snapshot with the relevant symmetric key
+	IsEncrypted bool
+	// Timestamp is a timestamp of the snapshot, if it is <= 0, then we use current timestamp
+	Timestamp int64
+	// DataType contains additional info about the data in the snapshot
+	DataType string
+}
+
+// RevertTo adds the snapshot with the state of the tree at the change on top of current heads,
+// so the revert is synced as any other change. Nothing is added if the change is the only head of the tree
+func (ot *objectTree) RevertTo(ctx context.Context, params RevertParams) (res AddResult, err error) {
+	if params.StateBuilder == nil || params.Convert == nil {
+		err = ErrNoStateBuilder
+		return
+	}
+	heads := ot.tree.Heads()
+	if len(heads) == 1 && heads[0] == params.ChangeId {
+		headsCopy := append([]string(nil), heads...)
+		res = AddResult{
+			OldHeads: headsCopy,
+			Heads:    headsCopy,
+			Mode:     Nothing,
+		}
+		return
+	}
+	hasChange, err := ot.treeStorage.HasChange(ctx, params.ChangeId)
+	if err != nil {
+		return
+	}
+	if !hasChange {
+		err = ErrNoChangeInTree
+		return
+	}
+	version, err := ot.versionTree(params.ChangeId)
+	if err != nil {
+		return
+	}
+	var applyErr error
+	err = version.IterateRoot(params.Convert, func(change *Change) bool {
+		applyErr = params.StateBuilder.Apply(change)
+		return applyErr == nil
+	})
+	if err != nil {
+		return
+	}
+	if applyErr != nil {
+		err = applyErr
+		return
+	}
+	data, err := params.StateBuilder.Snapshot()
+	if err != nil {
+		return
+	}
+	return ot.AddContent(ctx, SignableChangeContent{
+		Data:        data,
+		Key:         params.Key,
+		IsSnapshot:  true,
+		IsEncrypted: params.IsEncrypted,
+		Timestamp:   params.Timestamp,
+		DataType:    params.DataType,
+	})
+}
+
+// versionTree builds the history tree with the change as the only head using the keys of the tree
+func (ot *objectTree) versionTree(changeId string) (*historyTree, error) {
+	keys := make(map[string]crypto.SymKey, len(ot.keys))
+	for id, key := range ot.keys {
+		keys[id] = key
+	}
+	var treeKeys map[string]*treechangeproto.TreeKey
+	if ot.treeKeys != nil {
+		treeKeys = make(map[string]*treechangeproto.TreeKey, len(ot.treeKeys))
+		for id, key := range ot.treeKeys {
+			treeKeys[id] = key
+		}
+	}
+	treeBuilder := newTreeBuilder(true, ot.treeStorage, ot.changeBuilder)
+	treeBuilder.ordering = ot.treeBuilder.ordering
+	hTree := &historyTree{objectTree: &objectTree{
+		id:               ot.id,
+		treeStorage:      ot.treeStorage,
+		treeBuilder:      treeBuilder,
+		validator:        ot.validator,
+		aclList:          ot.aclList,
+		changeBuilder:    ot.changeBuilder,
+		rawRoot:          ot.rawRoot,
+		root:             ot.root,
+		keys:             keys,
+		currentReadKey:   ot.currentReadKey,
+		treeKeys:         treeKeys,
+		currentTreeKeyId: ot.currentTreeKeyId,
+	}}
+	err := hTree.rebuildFromStorage(HistoryTreeParams{
+		BeforeId:        changeId,
+		IncludeBeforeId: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hTree, nil
+}
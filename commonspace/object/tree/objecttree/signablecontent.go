@@ -1,6 +1,7 @@
 package objecttree
 
 import (
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/util/crypto"
 )
 
@@ -18,4 +19,7 @@ type SignableChangeContent struct {
 	Timestamp int64
 	// DataType contains additional info about the data in the payload
 	DataType string
+	// Compression is the algorithm to compress the data with before the encryption,
+	// the clients not supporting the compression can't read such changes
+	Compression treechangeproto.ChangeCompression
 }
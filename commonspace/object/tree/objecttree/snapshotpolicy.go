@@ -0,0 +1,128 @@
+package objecttree
+
+import (
+	"context"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+)
+
+// SnapshotPolicy defines when AddContent makes a snapshot instead of the regular change,
+// the changes are counted since the snapshot which is the root of the tree, the zero values are not checked
+type SnapshotPolicy struct {
+	// MaxChanges is the number of the changes after which the next change is a snapshot
+	MaxChanges int
+	// MaxSize is the total size of the raw changes in bytes after which the next change is a snapshot
+	MaxSize int
+}
+
+func (p SnapshotPolicy) IsEmpty() bool {
+	return p.MaxChanges <= 0 && p.MaxSize <= 0
+}
+
+// SnapshotFunc returns the data of the snapshot which contains the state of the tree with the content applied,
+// it is called by AddContent under the lock of the tree
+type SnapshotFunc func(content SignableChangeContent) (data []byte, err error)
+
+// SetSnapshotPolicy makes AddContent ask for the snapshot data when the policy triggers,
+// the empty policy or nil snapshot func disables it
+func (ot *objectTree) SetSnapshotPolicy(policy SnapshotPolicy, snapshot SnapshotFunc) {
+	if policy.IsEmpty() || snapshot == nil {
+		ot.snapshotPolicy = nil
+		return
+	}
+	ot.snapshotPolicy = &snapshotPolicy{
+		SnapshotPolicy: policy,
+		snapshot:       snapshot,
+	}
+}
+
+type snapshotPolicy struct {
+	SnapshotPolicy
+	snapshot SnapshotFunc
+	// changes and size are counted since the root of the tree when they are needed for the first time
+	// and after the tree is rebuilt, then they are updated with the added changes
+	counted bool
+	changes int
+	size    int
+}
+
+// prepareSnapshot replaces the content with the snapshot if the policy triggers
+func (ot *objectTree) prepareSnapshot(content SignableChangeContent) (SignableChangeContent, error) {
+	p := ot.snapshotPolicy
+	if p == nil || content.IsSnapshot {
+		return content, nil
+	}
+	shouldSnapshot, err := ot.snapshotPolicyTriggered()
+	if err != nil || !shouldSnapshot {
+		return content, err
+	}
+	data, err := p.snapshot(content)
+	if err != nil {
+		return content, err
+	}
+	content.Data = data
+	content.IsSnapshot = true
+	return content, nil
+}
+
+func (ot *objectTree) snapshotPolicyTriggered() (bool, error) {
+	p := ot.snapshotPolicy
+	if !p.counted {
+		if err := ot.countSnapshotPolicyChanges(); err != nil {
+			return false, err
+		}
+	}
+	return (p.MaxChanges > 0 && p.changes >= p.MaxChanges) || (p.MaxSize > 0 && p.size >= p.MaxSize), nil
+}
+
+// countSnapshotPolicyChanges counts the changes after the root of the tree, their sizes are loaded from the storage
+func (ot *objectTree) countSnapshotPolicyChanges() error {
+	p := ot.snapshotPolicy
+	p.changes, p.size = 0, 0
+	rootId := ot.tree.RootId()
+	for id := range ot.tree.attached {
+		if id == rootId {
+			continue
+		}
+		p.changes++
+		if p.MaxSize <= 0 {
+			continue
+		}
+		raw, err := ot.treeStorage.GetRawChange(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		p.size += len(raw.RawChange)
+	}
+	p.counted = true
+	return nil
+}
+
+// changesAdded updates the counters with the changes added to the tree, after the rebuild the changes are counted again
+func (p *snapshotPolicy) changesAdded(changes []*treechangeproto.RawTreeChangeWithId, mode Mode) {
+	if p == nil || !p.counted {
+		return
+	}
+	if mode == Rebuild {
+		p.counted = false
+		return
+	}
+	for _, ch := range changes {
+		p.changes++
+		p.size += len(ch.RawChange)
+	}
+}
+
+// snapshotAdded resets the counters, because the snapshot is the new root of the tree
+func (p *snapshotPolicy) snapshotAdded() {
+	if p != nil {
+		p.counted, p.changes, p.size = true, 0, 0
+	}
+}
+
+// treeRebuilt makes the policy count the changes again
+func (p *snapshotPolicy) treeRebuilt() {
+	if p != nil {
+		p.counted = false
+	}
+}
@@ -26,6 +26,8 @@ type Tree struct {
 	waitList       map[string][]string
 	invalidChanges map[string]struct{}
 	possibleRoots  []*Change
+	// ordering defines the order of the concurrent changes, nil means ordering by id
+	ordering ChangeOrdering
 
 	// bufs
 	visitedBuf []*Change
@@ -262,13 +264,13 @@ func (t *Tree) attach(c *Change, newEl bool) {
 		// prev id must already be attached if we attach this id, so we don't need to check if it exists
 		prev := t.attached[id]
 		// appending c to next changes of all previous changes
-		if len(prev.Next) == 0 || prev.Next[len(prev.Next)-1].Id <= c.Id {
+		if len(prev.Next) == 0 || !t.less(c, prev.Next[len(prev.Next)-1]) {
 			prev.Next = append(prev.Next, c)
 		} else {
 			// inserting in correct position, before the change which is greater or equal
 			insertIdx := 0
 			for idx, el := range prev.Next {
-				if el.Id >= c.Id {
+				if !t.less(el, c) {
 					insertIdx = idx
 					break
 				}
@@ -1,11 +1,13 @@
 package objecttree
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/anyproto/any-sync/util/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -292,6 +294,71 @@ func TestTree_Iterate(t *testing.T) {
 	})
 }
 
+func TestTree_Ordering(t *testing.T) {
+	newTimedChange := func(id string, timestamp int64, prevIds ...string) *Change {
+		ch := newChange(id, "0", prevIds...)
+		ch.Timestamp = timestamp
+		return ch
+	}
+	changes := func() []*Change {
+		return []*Change{
+			newSnapshot("0", ""),
+			newTimedChange("a", 3, "0"),
+			newTimedChange("b", 2, "0"),
+			newTimedChange("c", 1, "0"),
+		}
+	}
+	iterate := func(tr *Tree) (res []string) {
+		tr.Iterate("0", func(c *Change) (isContinue bool) {
+			res = append(res, c.Id)
+			return true
+		})
+		return
+	}
+
+	t.Run("default ordering by id", func(t *testing.T) {
+		tr := new(Tree)
+		tr.Add(changes()...)
+		assert.Equal(t, []string{"0", "a", "b", "c"}, iterate(tr))
+		assert.Equal(t, "c", tr.lastIteratedHeadId)
+	})
+	t.Run("ordering by timestamp", func(t *testing.T) {
+		tr := &Tree{ordering: OrderByTimestamp}
+		tr.Add(changes()...)
+		assert.Equal(t, []string{"0", "c", "b", "a"}, iterate(tr))
+		assert.Equal(t, "a", tr.lastIteratedHeadId)
+	})
+	t.Run("set ordering on built tree", func(t *testing.T) {
+		tr := new(Tree)
+		tr.Add(changes()...)
+		tr.setOrdering(OrderByTimestamp)
+		assert.Equal(t, []string{"0", "c", "b", "a"}, iterate(tr))
+		assert.Equal(t, "a", tr.lastIteratedHeadId)
+	})
+	t.Run("ordering by identity priority", func(t *testing.T) {
+		keys := make([]crypto.PubKey, 3)
+		for i := range keys {
+			_, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
+			require.NoError(t, err)
+			keys[i] = pubKey
+		}
+		chs := changes()
+		for i, ch := range chs[1:] {
+			ch.Identity = keys[i]
+		}
+		priority := func(identity crypto.PubKey) int {
+			if identity.Equals(keys[0]) {
+				return 1
+			}
+			return 0
+		}
+		tr := &Tree{ordering: OrderByIdentityPriority(priority)}
+		tr.Add(chs...)
+		assert.Equal(t, []string{"0", "b", "c", "a"}, iterate(tr))
+		assert.Equal(t, "a", tr.lastIteratedHeadId)
+	})
+}
+
 func BenchmarkTree_Add(b *testing.B) {
 	getChanges := func() []*Change {
 		return []*Change{
@@ -342,3 +409,44 @@ func BenchmarkTree_IterateLinear(b *testing.B) {
 		}
 	})
 }
+
+func TestTree_RenderGraph(t *testing.T) {
+	tr := new(Tree)
+	tr.Add(
+		newSnapshot("0", ""),
+		newChange("1", "0", "0"),
+		newChange("2", "0", "1"),
+		newChange("x", "0", "missing"),
+	)
+	graph, err := tr.RenderGraph(NoOpDescriptionParser)
+	require.NoError(t, err)
+	var ids []string
+	for _, n := range graph.Nodes {
+		ids = append(ids, n.Id)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "x", "missing"}, ids)
+	assert.True(t, graph.Nodes[0].IsRoot)
+	assert.True(t, graph.Nodes[0].IsSnapshot)
+	assert.True(t, graph.Nodes[2].IsHead)
+	assert.False(t, graph.Nodes[3].IsAttached)
+	assert.True(t, graph.Nodes[4].IsMissing)
+	assert.Equal(t, []GraphEdge{{"1", "0"}, {"2", "1"}, {"x", "missing"}}, graph.Edges)
+
+	t.Run("dot", func(t *testing.T) {
+		dot := graph.Dot()
+		assert.Contains(t, dot, `"2" -> "1";`)
+		assert.Contains(t, dot, `"missing" [label="missing: not in Tree", style=dashed];`)
+	})
+	t.Run("mermaid", func(t *testing.T) {
+		mermaid := graph.Mermaid()
+		assert.Contains(t, mermaid, "n2 --> n1")
+		assert.Contains(t, mermaid, "class n2 head")
+	})
+	t.Run("json", func(t *testing.T) {
+		data, err := graph.JSON()
+		require.NoError(t, err)
+		var decoded TreeGraph
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, graph, decoded)
+	})
+}
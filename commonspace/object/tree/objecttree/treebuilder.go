@@ -24,6 +24,7 @@ type treeBuilder struct {
 	cache            map[string]*Change
 	tree             *Tree
 	keepInMemoryData bool
+	ordering         ChangeOrdering
 
 	// buffers
 	idStack    []string
@@ -40,7 +41,7 @@ func newTreeBuilder(keepData bool, storage treestorage.TreeStorage, builder Chan
 
 func (tb *treeBuilder) Reset() {
 	tb.cache = make(map[string]*Change)
-	tb.tree = &Tree{}
+	tb.tree = &Tree{ordering: tb.ordering}
 }
 
 func (tb *treeBuilder) Build(theirHeads []string, newChanges []*Change) (*Tree, error) {
@@ -52,15 +53,20 @@ func (tb *treeBuilder) Build(theirHeads []string, newChanges []*Change) (*Tree,
 }
 
 func (tb *treeBuilder) BuildFull() (*Tree, error) {
-	defer func() {
-		tb.cache = make(map[string]*Change)
-	}()
-	tb.cache = make(map[string]*Change)
 	heads, err := tb.treeStorage.Heads()
 	if err != nil {
 		return nil, err
 	}
-	err = tb.buildTree(heads, tb.treeStorage.Id())
+	return tb.BuildFullFrom(heads)
+}
+
+// BuildFullFrom builds the tree starting from the root with the given heads
+func (tb *treeBuilder) BuildFullFrom(heads []string) (*Tree, error) {
+	defer func() {
+		tb.cache = make(map[string]*Change)
+	}()
+	tb.cache = make(map[string]*Change)
+	err := tb.buildTree(heads, tb.treeStorage.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +112,10 @@ func (tb *treeBuilder) build(heads []string, theirHeads []string, newChanges []*
 			breakpoint = oldBreakpoint
 		}
 	}
+	breakpoint, err = tb.notCompactedBreakpoint(breakpoint)
+	if err != nil {
+		return nil, err
+	}
 	proposedHeads = append(proposedHeads, heads...)
 
 	log.With(zap.Strings("heads", proposedHeads), zap.String("id", tb.treeStorage.Id())).Debug("building tree")
@@ -116,6 +126,27 @@ func (tb *treeBuilder) build(heads []string, theirHeads []string, newChanges []*
 	return tb.tree, nil
 }
 
+// notCompactedBreakpoint returns the compacted snapshot if the breakpoint precedes it,
+// because the changes after such breakpoint are not in the storage anymore
+func (tb *treeBuilder) notCompactedBreakpoint(breakpoint string) (string, error) {
+	compactedId, err := tb.treeStorage.CompactedSnapshotId()
+	if err != nil || compactedId == "" || compactedId == breakpoint {
+		return breakpoint, err
+	}
+	snapshotId := compactedId
+	for snapshotId != "" {
+		ch, err := tb.loadChange(snapshotId)
+		if err != nil {
+			return "", err
+		}
+		if ch.SnapshotId == breakpoint {
+			return compactedId, nil
+		}
+		snapshotId = ch.SnapshotId
+	}
+	return breakpoint, nil
+}
+
 func (tb *treeBuilder) buildTree(heads []string, breakpoint string) (err error) {
 	ch, err := tb.loadChange(breakpoint)
 	if err != nil {
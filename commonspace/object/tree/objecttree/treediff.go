@@ -0,0 +1,94 @@
+package objecttree
+
+import (
+	"context"
+)
+
+// ChangesDiff returns the changes which are reachable from heads but not from baseHeads in the order of the tree,
+// e.g. the changes made since the tree was seen with baseHeads. The models of the changes are set by convert
+// from their decrypted data, the root is returned without a model
+func (ot *objectTree) ChangesDiff(baseHeads, heads []string, convert ChangeConvertFunc) (changes []*Change, err error) {
+	allHeads := make([]string, 0, len(baseHeads)+len(heads))
+	allHeads = append(allHeads, baseHeads...)
+	allHeads = append(allHeads, heads...)
+	for _, head := range allHeads {
+		has, err := ot.treeStorage.HasChange(context.Background(), head)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, ErrNoChangeInTree
+		}
+	}
+	ot.treeBuilder.cache = make(map[string]*Change)
+	defer func() {
+		ot.treeBuilder.cache = make(map[string]*Change)
+	}()
+	commonSnapshot, err := ot.treeBuilder.findBreakpoint(allHeads, true)
+	if err != nil {
+		return
+	}
+	changes, err = ot.rawChangeLoader.LoadDiff(commonSnapshot, heads, baseHeads)
+	if err != nil {
+		return
+	}
+	changes = ot.tree.topologicalOrder(changes)
+	if convert == nil {
+		return
+	}
+	for _, ch := range changes {
+		if ch.Id == ot.id {
+			continue
+		}
+		decrypted, err := ot.decrypt(ch)
+		if err != nil {
+			return nil, err
+		}
+		if ch.Model, err = convert(ch, decrypted); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// topologicalOrder orders the changes so each change goes after its previous changes from the same set,
+// the concurrent changes are ordered in the same way as in the tree
+func (t *Tree) topologicalOrder(changes []*Change) []*Change {
+	byId := make(map[string]*Change, len(changes))
+	for _, ch := range changes {
+		byId[ch.Id] = ch
+	}
+	prevCount := make(map[string]int, len(changes))
+	next := make(map[string][]*Change, len(changes))
+	var ready []*Change
+	for _, ch := range changes {
+		for _, prev := range ch.PreviousIds {
+			if _, exists := byId[prev]; exists {
+				prevCount[ch.Id]++
+				next[prev] = append(next[prev], ch)
+			}
+		}
+		if prevCount[ch.Id] == 0 {
+			ready = append(ready, ch)
+		}
+	}
+	ordered := make([]*Change, 0, len(changes))
+	for len(ready) > 0 {
+		minIdx := 0
+		for i := 1; i < len(ready); i++ {
+			if t.less(ready[i], ready[minIdx]) {
+				minIdx = i
+			}
+		}
+		ch := ready[minIdx]
+		ready = append(ready[:minIdx], ready[minIdx+1:]...)
+		ordered = append(ordered, ch)
+		for _, n := range next[ch.Id] {
+			prevCount[n.Id]--
+			if prevCount[n.Id] == 0 {
+				ready = append(ready, n)
+			}
+		}
+	}
+	return ordered
+}
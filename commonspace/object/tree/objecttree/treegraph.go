@@ -3,8 +3,11 @@
 
 package objecttree
 
-import "fmt"
-
+// Graph renders the tree in DOT format without graphviz on the platforms where its bindings are not available
 func (t *Tree) Graph(parser DescriptionParser) (data string, err error) {
-	return "", fmt.Errorf("not supported")
+	graph, err := t.RenderGraph(parser)
+	if err != nil {
+		return
+	}
+	return graph.Dot(), nil
 }
@@ -0,0 +1,243 @@
+package objecttree
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	ErrNoTreeKey             = errors.New("tree doesn't have its own key")
+	ErrIncorrectTreeKeyShare = errors.New("tree key share is made for another tree or identity")
+	ErrIncorrectTreeKey      = errors.New("tree key doesn't match the read key id of the change")
+)
+
+// SharedTreeKey is the generation of the tree key decrypted from the share
+type SharedTreeKey struct {
+	KeyId string
+	Key   crypto.SymKey
+}
+
+// newTreeKey encrypts the key of the tree with the current read key of the space
+func newTreeKey(state *list.AclState, key crypto.SymKey) (treeKey *treechangeproto.TreeKey, err error) {
+	readKey, err := state.CurrentReadKey()
+	if err != nil {
+		return
+	}
+	encryptionKey, err := deriveTreeKeyEncryptionKey(readKey)
+	if err != nil {
+		return
+	}
+	protoKey, err := key.Marshall()
+	if err != nil {
+		return
+	}
+	encrypted, err := encryptionKey.Encrypt(protoKey)
+	if err != nil {
+		return
+	}
+	return &treechangeproto.TreeKey{
+		ReadKeyId:    state.CurrentReadKeyId(),
+		EncryptedKey: encrypted,
+	}, nil
+}
+
+// decryptTreeKey decrypts the key of the tree with the read key of the space
+func decryptTreeKey(treeKey *treechangeproto.TreeKey, state *list.AclState) (key crypto.SymKey, err error) {
+	readKey, exists := state.Keys()[treeKey.ReadKeyId]
+	if !exists || readKey.ReadKey == nil {
+		return nil, list.ErrNoReadKey
+	}
+	encryptionKey, err := deriveTreeKeyEncryptionKey(readKey.ReadKey)
+	if err != nil {
+		return
+	}
+	protoKey, err := encryptionKey.Decrypt(treeKey.EncryptedKey)
+	if err != nil {
+		return
+	}
+	return crypto.UnmarshallAESKeyProto(protoKey)
+}
+
+func deriveTreeKeyEncryptionKey(readKey crypto.SymKey) (crypto.SymKey, error) {
+	raw, err := readKey.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DeriveSymmetricKey(raw, crypto.AnysyncTreeKeyPath)
+}
+
+// rootTreeKey returns the encrypted key of the tree or nil if the tree doesn't have its own key
+func rootTreeKey(rawRoot *treechangeproto.RawTreeChangeWithId) (treeKey *treechangeproto.TreeKey, err error) {
+	raw := &treechangeproto.RawTreeChange{}
+	if err = proto.Unmarshal(rawRoot.RawChange, raw); err != nil {
+		return
+	}
+	root := &treechangeproto.RootChange{}
+	if err = proto.Unmarshal(raw.Payload, root); err != nil {
+		return
+	}
+	return root.TreeKey, nil
+}
+
+// newTreeKeyShare encrypts the generation of the key of the tree for the identity
+func newTreeKeyShare(treeId, keyId string, treeKey crypto.SymKey, identity crypto.PubKey) (share *treechangeproto.TreeKeyShare, err error) {
+	rawIdentity, err := identity.Marshall()
+	if err != nil {
+		return
+	}
+	protoKey, err := treeKey.Marshall()
+	if err != nil {
+		return
+	}
+	encrypted, err := identity.Encrypt(protoKey)
+	if err != nil {
+		return
+	}
+	return &treechangeproto.TreeKeyShare{
+		TreeId:       treeId,
+		Identity:     rawIdentity,
+		EncryptedKey: encrypted,
+		KeyId:        keyId,
+	}, nil
+}
+
+// DecryptTreeKeyShare returns the generation of the key of the tree encrypted for the owner of the private key,
+// it decrypts only the changes made before the key is rotated, so the new share is needed after that
+func DecryptTreeKeyShare(share *treechangeproto.TreeKeyShare, treeId string, key crypto.PrivKey) (treeKey SharedTreeKey, err error) {
+	identity, err := key.GetPublic().Marshall()
+	if err != nil {
+		return
+	}
+	if share.TreeId != treeId || !bytes.Equal(share.Identity, identity) {
+		return SharedTreeKey{}, ErrIncorrectTreeKeyShare
+	}
+	protoKey, err := key.Decrypt(share.EncryptedKey)
+	if err != nil {
+		return
+	}
+	treeKey.Key, err = crypto.UnmarshallAESKeyProto(protoKey)
+	if err != nil {
+		return
+	}
+	treeKey.KeyId = share.KeyId
+	if treeKey.KeyId == "" {
+		treeKey.KeyId = treeId
+	}
+	return
+}
+
+// treeKeyId returns the id of the generation of the tree key, the key from the root has the id of the tree
+// and the rotated keys are identified by the cid of the encrypted key
+func (ot *objectTree) treeKeyId(treeKey *treechangeproto.TreeKey) (string, error) {
+	if rootKey := ot.treeKeys[ot.id]; rootKey != nil && rootKey.ReadKeyId == treeKey.ReadKeyId &&
+		bytes.Equal(rootKey.EncryptedKey, treeKey.EncryptedKey) {
+		return ot.id, nil
+	}
+	return cidutil.NewCidFromBytes(treeKey.EncryptedKey)
+}
+
+// addTreeKeys saves the generations of the tree key from the changes added to the tree
+func (ot *objectTree) addTreeKeys(changes []*Change) error {
+	for _, ch := range changes {
+		if ch.TreeKey == nil || ch.Id == ot.id {
+			continue
+		}
+		keyId, err := ot.treeKeyId(ch.TreeKey)
+		if err != nil {
+			return err
+		}
+		if keyId != ch.ReadKeyId {
+			return ErrIncorrectTreeKey
+		}
+		ot.treeKeys[keyId] = ch.TreeKey
+	}
+	return nil
+}
+
+// readTreeKeys decrypts the generations of the tree key and selects the current one,
+// which is the generation encrypted with the current read key of the space.
+// The concurrent rotations can make several such generations, then the one with the smallest id is used
+func (ot *objectTree) readTreeKeys(state *list.AclState) (err error) {
+	canRead := canReadKeys(state)
+	currentReadKeyId := state.CurrentReadKeyId()
+	ot.currentTreeKeyId = ""
+	for keyId, treeKey := range ot.treeKeys {
+		if treeKey.ReadKeyId == currentReadKeyId && (ot.currentTreeKeyId == "" || keyId < ot.currentTreeKeyId) {
+			ot.currentTreeKeyId = keyId
+		}
+		// the key may be known already, e.g. it was shared with the identity outside of the space
+		if _, exists := ot.keys[keyId]; exists || !canRead {
+			continue
+		}
+		key, err := decryptTreeKey(treeKey, state)
+		if err != nil {
+			// we will try again when we receive the read key
+			if errors.Is(err, list.ErrNoReadKey) {
+				continue
+			}
+			return err
+		}
+		ot.keys[keyId] = key
+	}
+	ot.currentReadKey = ot.keys[ot.currentTreeKeyId]
+	return nil
+}
+
+// readChangeTreeKey decrypts the generation of the tree key from the change which is not added to the tree yet
+func (ot *objectTree) readChangeTreeKey(ch *Change, state *list.AclState) error {
+	if ch.TreeKey == nil || ot.treeKeys == nil || !canReadKeys(state) {
+		return nil
+	}
+	if _, exists := ot.keys[ch.ReadKeyId]; exists {
+		return nil
+	}
+	keyId, err := ot.treeKeyId(ch.TreeKey)
+	if err != nil {
+		return err
+	}
+	if keyId != ch.ReadKeyId {
+		return ErrIncorrectTreeKey
+	}
+	key, err := decryptTreeKey(ch.TreeKey, state)
+	if err != nil {
+		if errors.Is(err, list.ErrNoReadKey) {
+			return nil
+		}
+		return err
+	}
+	ot.keys[keyId] = key
+	return nil
+}
+
+// changeTreeKey returns the generation of the tree key for the new change. The new generation is made if the space
+// read key was changed after the current one, so the accounts which lost the access can't read the next changes.
+// The snapshots keep the current generation, because the change which made it is not loaded with the tree
+func (ot *objectTree) changeTreeKey(state *list.AclState, isSnapshot bool) (keyId string, key crypto.SymKey, treeKey *treechangeproto.TreeKey, err error) {
+	if err = ot.readTreeKeys(state); err != nil {
+		return
+	}
+	if ot.currentTreeKeyId == "" {
+		key = crypto.NewAES()
+		if treeKey, err = newTreeKey(state, key); err != nil {
+			return
+		}
+		keyId, err = cidutil.NewCidFromBytes(treeKey.EncryptedKey)
+		return
+	}
+	if ot.currentReadKey == nil {
+		err = ErrMissingKey
+		return
+	}
+	keyId, key = ot.currentTreeKeyId, ot.currentReadKey
+	if isSnapshot {
+		treeKey = ot.treeKeys[keyId]
+	}
+	return
+}
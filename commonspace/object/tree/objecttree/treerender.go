@@ -0,0 +1,240 @@
+package objecttree
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TreeGraph is the graph of the changes of the tree which can be rendered without graphviz
+type TreeGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a change in the graph of the tree
+type GraphNode struct {
+	Id string `json:"id"`
+	// Order contains the positions of the change in the iteration of the tree, it is empty for unattached changes
+	Order       string   `json:"order,omitempty"`
+	Timestamp   int64    `json:"timestamp,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	AclHeadId   string   `json:"aclHeadId,omitempty"`
+	ReadKeyId   string   `json:"readKeyId,omitempty"`
+	SnapshotId  string   `json:"snapshotId,omitempty"`
+	IsSnapshot  bool     `json:"isSnapshot,omitempty"`
+	IsRoot      bool     `json:"isRoot,omitempty"`
+	IsHead      bool     `json:"isHead,omitempty"`
+	IsAttached  bool     `json:"isAttached"`
+	IsMissing   bool     `json:"isMissing,omitempty"`
+	Description []string `json:"description,omitempty"`
+}
+
+// GraphEdge links the change with one of its previous changes
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RenderGraph returns the graph of all changes of the tree including the unattached ones,
+// the previous changes which are not in the tree are added as missing nodes
+func (t *Tree) RenderGraph(parser DescriptionParser) (graph TreeGraph, err error) {
+	var (
+		order    = make(map[string]string)
+		position = make(map[string]int)
+		seq      = 0
+		nodes    = make(map[string]struct{})
+		heads    = make(map[string]struct{}, len(t.headIds))
+	)
+	for _, id := range t.headIds {
+		heads[id] = struct{}{}
+	}
+	if t.root != nil {
+		t.Iterate(t.RootId(), func(c *Change) (isContinue bool) {
+			if v := order[c.Id]; v == "" {
+				order[c.Id] = fmt.Sprint(seq)
+				position[c.Id] = seq
+			} else {
+				order[c.Id] = fmt.Sprintf("%s,%d", v, seq)
+			}
+			seq++
+			return true
+		})
+	}
+	addChange := func(c *Change, isAttached bool) error {
+		description, err := parser.ParseChange(c, c.Id == t.RootId())
+		if err != nil {
+			return err
+		}
+		node := GraphNode{
+			Id:          c.Id,
+			Order:       order[c.Id],
+			Timestamp:   c.Timestamp,
+			AclHeadId:   c.AclHeadId,
+			ReadKeyId:   c.ReadKeyId,
+			SnapshotId:  c.SnapshotId,
+			IsSnapshot:  c.IsSnapshot,
+			IsRoot:      c.Id == t.RootId(),
+			IsAttached:  isAttached,
+			Description: description,
+		}
+		if c.Identity != nil {
+			node.Author = c.Identity.Account()
+		}
+		_, node.IsHead = heads[c.Id]
+		nodes[c.Id] = struct{}{}
+		graph.Nodes = append(graph.Nodes, node)
+		return nil
+	}
+	for _, c := range sortedChanges(t.attached, position) {
+		if err = addChange(c, true); err != nil {
+			return
+		}
+	}
+	for _, c := range sortedChanges(t.unAttached, position) {
+		if err = addChange(c, false); err != nil {
+			return
+		}
+	}
+	for _, node := range graph.Nodes {
+		var c *Change
+		if node.IsAttached {
+			c = t.attached[node.Id]
+		} else {
+			c = t.unAttached[node.Id]
+		}
+		for _, prevId := range c.PreviousIds {
+			graph.Edges = append(graph.Edges, GraphEdge{From: c.Id, To: prevId})
+			if _, exists := nodes[prevId]; !exists {
+				nodes[prevId] = struct{}{}
+				graph.Nodes = append(graph.Nodes, GraphNode{Id: prevId, IsMissing: true})
+			}
+		}
+	}
+	return
+}
+
+// sortedChanges returns the changes in the iteration order of the tree, the changes which were not iterated are sorted by id
+func sortedChanges(changes map[string]*Change, position map[string]int) []*Change {
+	res := make([]*Change, 0, len(changes))
+	for _, c := range changes {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		pi, iterated := position[res[i].Id]
+		pj, jIterated := position[res[j].Id]
+		if iterated != jIterated {
+			return iterated
+		}
+		if iterated && pi != pj {
+			return pi < pj
+		}
+		return res[i].Id < res[j].Id
+	})
+	return res
+}
+
+// Dot renders the graph in graphviz DOT format, the snapshots are drawn as boxes and the heads are filled
+func (g TreeGraph) Dot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph tree {\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.label("\n"))}
+		switch {
+		case n.IsMissing:
+			attrs = append(attrs, "style=dashed")
+		case n.IsHead:
+			attrs = append(attrs, `style=filled`, `fillcolor="lightblue"`)
+		case !n.IsAttached:
+			attrs = append(attrs, `style=filled`, `fillcolor="lightgrey"`)
+		}
+		if n.IsSnapshot {
+			attrs = append(attrs, "shape=box")
+		}
+		fmt.Fprintf(&sb, "\t%s [%s];\n", dotQuote(n.Id), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "\t%s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the graph as mermaid flowchart, the snapshots are drawn as boxes and the other changes as rounded boxes
+func (g TreeGraph) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart BT\n")
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.Id] = id
+		label := mermaidQuote(n.label("<br/>"))
+		if n.IsSnapshot {
+			fmt.Fprintf(&sb, "\t%s[%s]\n", id, label)
+		} else {
+			fmt.Fprintf(&sb, "\t%s(%s)\n", id, label)
+		}
+		switch {
+		case n.IsMissing:
+			fmt.Fprintf(&sb, "\tclass %s missing\n", id)
+		case n.IsHead:
+			fmt.Fprintf(&sb, "\tclass %s head\n", id)
+		case !n.IsAttached:
+			fmt.Fprintf(&sb, "\tclass %s unattached\n", id)
+		}
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "\t%s --> %s\n", ids[e.From], ids[e.To])
+	}
+	sb.WriteString("\tclassDef head fill:#add8e6\n")
+	sb.WriteString("\tclassDef unattached fill:#d3d3d3\n")
+	sb.WriteString("\tclassDef missing stroke-dasharray:5 5\n")
+	return sb.String()
+}
+
+// JSON returns the list of the nodes and the edges of the graph
+func (g TreeGraph) JSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+func (n GraphNode) label(sep string) string {
+	if n.IsMissing {
+		return n.Id + ": not in Tree"
+	}
+	ord := n.Order
+	if ord == "" {
+		ord = "miss"
+	}
+	lines := []string{
+		"Id: " + n.Id,
+		"Ord: " + ord,
+		"Time: " + time.Unix(n.Timestamp, 0).Format("02.01.06 15:04:05"),
+	}
+	if n.IsSnapshot {
+		lines = append(lines, "Snapshot")
+	}
+	if n.Author != "" {
+		lines = append(lines, "Author: "+n.Author)
+	}
+	if n.AclHeadId != "" {
+		lines = append(lines, "AclHead: "+n.AclHeadId)
+	}
+	if n.ReadKeyId != "" {
+		lines = append(lines, "ReadKey: "+n.ReadKeyId)
+	}
+	lines = append(lines, "Changes: "+strings.Join(n.Description, ","))
+	return strings.Join(lines, sep)
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/anyproto/any-sync/commonspace/object/tree/synctree (interfaces: SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol)
+// Source: github.com/anyproto/any-sync/commonspace/object/tree/synctree (interfaces: SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol,InvalidContentReporter)
 //
 // Generated by this command:
 //
-//	mockgen -destination mock_synctree/mock_synctree.go github.com/anyproto/any-sync/commonspace/object/tree/synctree SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol
+//	mockgen -destination mock_synctree/mock_synctree.go github.com/anyproto/any-sync/commonspace/object/tree/synctree SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol,InvalidContentReporter
 //
+
 // Package mock_synctree is a generated GoMock package.
 package mock_synctree
 
@@ -19,6 +20,7 @@ import (
 	treechangeproto "github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	treestorage "github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
 	spacesyncproto "github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	crypto "github.com/anyproto/any-sync/util/crypto"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -45,6 +47,18 @@ func (m *MockSyncTree) EXPECT() *MockSyncTreeMockRecorder {
 	return m.recorder
 }
 
+// AckSnapshotPath mocks base method.
+func (m *MockSyncTree) AckSnapshotPath(arg0 string, arg1 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AckSnapshotPath", arg0, arg1)
+}
+
+// AckSnapshotPath indicates an expected call of AckSnapshotPath.
+func (mr *MockSyncTreeMockRecorder) AckSnapshotPath(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckSnapshotPath", reflect.TypeOf((*MockSyncTree)(nil).AckSnapshotPath), arg0, arg1)
+}
+
 // AclList mocks base method.
 func (m *MockSyncTree) AclList() list.AclList {
 	m.ctrl.T.Helper()
@@ -118,6 +132,21 @@ func (mr *MockSyncTreeMockRecorder) ChangesAfterCommonSnapshot(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesAfterCommonSnapshot", reflect.TypeOf((*MockSyncTree)(nil).ChangesAfterCommonSnapshot), arg0, arg1)
 }
 
+// ChangesDiff mocks base method.
+func (m *MockSyncTree) ChangesDiff(arg0, arg1 []string, arg2 func(*objecttree.Change, []byte) (any, error)) ([]*objecttree.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangesDiff", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*objecttree.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangesDiff indicates an expected call of ChangesDiff.
+func (mr *MockSyncTreeMockRecorder) ChangesDiff(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangesDiff", reflect.TypeOf((*MockSyncTree)(nil).ChangesDiff), arg0, arg1, arg2)
+}
+
 // Close mocks base method.
 func (m *MockSyncTree) Close() error {
 	m.ctrl.T.Helper()
@@ -132,6 +161,21 @@ func (mr *MockSyncTreeMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSyncTree)(nil).Close))
 }
 
+// Compact mocks base method.
+func (m *MockSyncTree) Compact(arg0 objecttree.CompactionPolicy) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockSyncTreeMockRecorder) Compact(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockSyncTree)(nil).Compact), arg0)
+}
+
 // Debug mocks base method.
 func (m *MockSyncTree) Debug(arg0 objecttree.DescriptionParser) (objecttree.DebugInfo, error) {
 	m.ctrl.T.Helper()
@@ -333,6 +377,21 @@ func (mr *MockSyncTreeMockRecorder) Lock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockSyncTree)(nil).Lock))
 }
 
+// NewPagedIterator mocks base method.
+func (m *MockSyncTree) NewPagedIterator(arg0 string) (objecttree.PagedIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPagedIterator", arg0)
+	ret0, _ := ret[0].(objecttree.PagedIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewPagedIterator indicates an expected call of NewPagedIterator.
+func (mr *MockSyncTreeMockRecorder) NewPagedIterator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPagedIterator", reflect.TypeOf((*MockSyncTree)(nil).NewPagedIterator), arg0)
+}
+
 // PrepareChange mocks base method.
 func (m *MockSyncTree) PrepareChange(arg0 objecttree.SignableChangeContent) (*treechangeproto.RawTreeChangeWithId, error) {
 	m.ctrl.T.Helper()
@@ -372,6 +431,21 @@ func (mr *MockSyncTreeMockRecorder) RUnlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RUnlock", reflect.TypeOf((*MockSyncTree)(nil).RUnlock))
 }
 
+// RevertTo mocks base method.
+func (m *MockSyncTree) RevertTo(arg0 context.Context, arg1 objecttree.RevertParams) (objecttree.AddResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertTo", arg0, arg1)
+	ret0, _ := ret[0].(objecttree.AddResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertTo indicates an expected call of RevertTo.
+func (mr *MockSyncTreeMockRecorder) RevertTo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertTo", reflect.TypeOf((*MockSyncTree)(nil).RevertTo), arg0, arg1)
+}
+
 // Root mocks base method.
 func (m *MockSyncTree) Root() *objecttree.Change {
 	m.ctrl.T.Helper()
@@ -398,6 +472,33 @@ func (mr *MockSyncTreeMockRecorder) SetListener(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetListener", reflect.TypeOf((*MockSyncTree)(nil).SetListener), arg0)
 }
 
+// SetSnapshotPolicy mocks base method.
+func (m *MockSyncTree) SetSnapshotPolicy(arg0 objecttree.SnapshotPolicy, arg1 objecttree.SnapshotFunc) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSnapshotPolicy", arg0, arg1)
+}
+
+// SetSnapshotPolicy indicates an expected call of SetSnapshotPolicy.
+func (mr *MockSyncTreeMockRecorder) SetSnapshotPolicy(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshotPolicy", reflect.TypeOf((*MockSyncTree)(nil).SetSnapshotPolicy), arg0, arg1)
+}
+
+// ShareTreeKey mocks base method.
+func (m *MockSyncTree) ShareTreeKey(arg0 crypto.PubKey) (*treechangeproto.TreeKeyShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareTreeKey", arg0)
+	ret0, _ := ret[0].(*treechangeproto.TreeKeyShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareTreeKey indicates an expected call of ShareTreeKey.
+func (mr *MockSyncTreeMockRecorder) ShareTreeKey(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareTreeKey", reflect.TypeOf((*MockSyncTree)(nil).ShareTreeKey), arg0)
+}
+
 // SnapshotPath mocks base method.
 func (m *MockSyncTree) SnapshotPath() []string {
 	m.ctrl.T.Helper()
@@ -907,3 +1008,38 @@ func (mr *MockTreeSyncProtocolMockRecorder) HeadUpdate(arg0, arg1, arg2 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadUpdate", reflect.TypeOf((*MockTreeSyncProtocol)(nil).HeadUpdate), arg0, arg1, arg2)
 }
+
+// MockInvalidContentReporter is a mock of InvalidContentReporter interface.
+type MockInvalidContentReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvalidContentReporterMockRecorder
+}
+
+// MockInvalidContentReporterMockRecorder is the mock recorder for MockInvalidContentReporter.
+type MockInvalidContentReporterMockRecorder struct {
+	mock *MockInvalidContentReporter
+}
+
+// NewMockInvalidContentReporter creates a new mock instance.
+func NewMockInvalidContentReporter(ctrl *gomock.Controller) *MockInvalidContentReporter {
+	mock := &MockInvalidContentReporter{ctrl: ctrl}
+	mock.recorder = &MockInvalidContentReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvalidContentReporter) EXPECT() *MockInvalidContentReporterMockRecorder {
+	return m.recorder
+}
+
+// ReportInvalidContent mocks base method.
+func (m *MockInvalidContentReporter) ReportInvalidContent(arg0 string, arg1 *objecttree.ContentValidationError) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReportInvalidContent", arg0, arg1)
+}
+
+// ReportInvalidContent indicates an expected call of ReportInvalidContent.
+func (mr *MockInvalidContentReporterMockRecorder) ReportInvalidContent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportInvalidContent", reflect.TypeOf((*MockInvalidContentReporter)(nil).ReportInvalidContent), arg0, arg1)
+}
@@ -1,4 +1,4 @@
-//go:generate mockgen -destination mock_synctree/mock_synctree.go github.com/anyproto/any-sync/commonspace/object/tree/synctree SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol
+//go:generate mockgen -destination mock_synctree/mock_synctree.go github.com/anyproto/any-sync/commonspace/object/tree/synctree SyncTree,ReceiveQueue,HeadNotifiable,SyncClient,RequestFactory,TreeSyncProtocol,InvalidContentReporter
 package synctree
 
 import (
@@ -10,6 +10,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/updatelistener"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
 	"github.com/anyproto/any-sync/commonspace/objectsync/synchandler"
 	"github.com/anyproto/any-sync/commonspace/spacestorage"
@@ -47,6 +48,7 @@ type syncTree struct {
 	syncStatus syncstatus.StatusUpdater
 	notifiable HeadNotifiable
 	listener   updatelistener.UpdateListener
+	counter    ChangeCounter
 	onClose    func(id string)
 	isClosed   bool
 	isDeleted  bool
@@ -54,6 +56,17 @@ type syncTree struct {
 
 var log = logger.NewNamed("common.commonspace.synctree")
 
+// InvalidContentReporter is notified when the peer sends the changes which are rejected by the content validators,
+// so the peer can be flagged
+type InvalidContentReporter interface {
+	ReportInvalidContent(senderId string, err *objecttree.ContentValidationError)
+}
+
+// ChangeCounter is notified about the changes added to the tree
+type ChangeCounter interface {
+	ChangesAdded(changes []*objecttree.Change)
+}
+
 type ResponsiblePeersGetter interface {
 	GetResponsiblePeers(ctx context.Context) (peers []peer.Peer, err error)
 }
@@ -71,6 +84,12 @@ type BuildDeps struct {
 	SyncStatus      syncstatus.StatusUpdater
 	PeerGetter      ResponsiblePeersGetter
 	BuildObjectTree objecttree.BuildObjectTreeFunc
+	// InvalidContentReporter is optional
+	InvalidContentReporter InvalidContentReporter
+	// Limits are checked for the changes received from the peers before the tree is locked
+	Limits objecttree.TreeLimits
+	// ChangeCounter is optional
+	ChangeCounter ChangeCounter
 }
 
 func BuildSyncTreeOrGetRemote(ctx context.Context, id string, deps BuildDeps) (t SyncTree, err error) {
@@ -86,6 +105,9 @@ func BuildSyncTreeOrGetRemote(ctx context.Context, id string, deps BuildDeps) (t
 }
 
 func PutSyncTree(ctx context.Context, payload treestorage.TreeStorageCreatePayload, deps BuildDeps) (t SyncTree, err error) {
+	if err = deps.Limits.CheckChangesSize(payload.Changes); err != nil {
+		return
+	}
 	deps.TreeStorage, err = deps.SpaceStorage.CreateTreeStorage(payload)
 	if err != nil {
 		return
@@ -105,9 +127,10 @@ func buildSyncTree(ctx context.Context, sendUpdate bool, deps BuildDeps) (t Sync
 		notifiable: deps.HeadNotifiable,
 		onClose:    deps.OnClose,
 		listener:   deps.Listener,
+		counter:    deps.ChangeCounter,
 		syncStatus: deps.SyncStatus,
 	}
-	syncHandler := newSyncTreeHandler(deps.SpaceId, syncTree, syncClient, deps.SyncStatus)
+	syncHandler := newSyncTreeHandler(deps.SpaceId, syncTree, syncClient, deps.SyncStatus, deps.InvalidContentReporter, deps.Limits)
 	syncTree.SyncHandler = syncHandler
 	t = syncTree
 	syncTree.Lock()
@@ -150,6 +173,7 @@ func (s *syncTree) AddContent(ctx context.Context, content objecttree.SignableCh
 	if err != nil {
 		return
 	}
+	s.countChanges(res.Added)
 	if s.notifiable != nil {
 		s.notifiable.UpdateHeads(s.Id(), res.Heads)
 	}
@@ -178,6 +202,7 @@ func (s *syncTree) AddRawChanges(ctx context.Context, changesPayload objecttree.
 		}
 	}
 	if res.Mode != objecttree.Nothing {
+		s.countChanges(res.Added)
 		if s.notifiable != nil {
 			s.notifiable.UpdateHeads(s.Id(), res.Heads)
 		}
@@ -187,6 +212,19 @@ func (s *syncTree) AddRawChanges(ctx context.Context, changesPayload objecttree.
 	return
 }
 
+func (s *syncTree) countChanges(added []*treechangeproto.RawTreeChangeWithId) {
+	if s.counter == nil || len(added) == 0 {
+		return
+	}
+	changes := make([]*objecttree.Change, 0, len(added))
+	for _, raw := range added {
+		if ch, err := s.GetChange(raw.Id); err == nil {
+			changes = append(changes, ch)
+		}
+	}
+	s.counter.ChangesAdded(changes)
+}
+
 func (s *syncTree) Delete() (err error) {
 	log.Debug("deleting sync tree", zap.String("id", s.Id()))
 	defer func() {
@@ -138,4 +138,40 @@ func Test_BuildSyncTree(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expectedRes, res)
 	})
+
+	t.Run("added changes are counted", func(t *testing.T) {
+		counter := &testChangeCounter{}
+		tr.counter = counter
+		defer func() {
+			tr.counter = nil
+		}()
+		changes := []*treechangeproto.RawTreeChangeWithId{{Id: "some"}}
+		payload := objecttree.RawChangesPayload{
+			NewHeads:   nil,
+			RawChanges: changes,
+		}
+		expectedRes := objecttree.AddResult{
+			Added: changes,
+			Mode:  objecttree.Append,
+		}
+		change := &objecttree.Change{Id: "some", ReadKeyId: "readKeyId"}
+		objTreeMock.EXPECT().AddRawChanges(gomock.Any(), gomock.Eq(payload)).
+			Return(expectedRes, nil)
+		objTreeMock.EXPECT().GetChange("some").Return(change, nil)
+		updateListenerMock.EXPECT().Update(tr)
+
+		syncClientMock.EXPECT().CreateHeadUpdate(gomock.Eq(tr), gomock.Eq(changes)).Return(headUpdate)
+		syncClientMock.EXPECT().Broadcast(gomock.Eq(headUpdate))
+		_, err := tr.AddRawChanges(ctx, payload)
+		require.NoError(t, err)
+		require.Equal(t, []*objecttree.Change{change}, counter.changes)
+	})
+}
+
+type testChangeCounter struct {
+	changes []*objecttree.Change
+}
+
+func (c *testChangeCounter) ChangesAdded(changes []*objecttree.Change) {
+	c.changes = append(c.changes, changes...)
 }
@@ -26,6 +26,8 @@ type syncTreeHandler struct {
 	syncProtocol TreeSyncProtocol
 	syncStatus   syncstatus.StatusUpdater
 	spaceId      string
+	limits       objecttree.TreeLimits
+	rateLimiter  *objecttree.PeerRateLimiter
 
 	handlerLock     sync.Mutex
 	pendingRequests map[string]struct{}
@@ -34,13 +36,15 @@ type syncTreeHandler struct {
 
 const maxQueueSize = 5
 
-func newSyncTreeHandler(spaceId string, objTree objecttree.ObjectTree, syncClient SyncClient, syncStatus syncstatus.StatusUpdater) synchandler.SyncHandler {
+func newSyncTreeHandler(spaceId string, objTree objecttree.ObjectTree, syncClient SyncClient, syncStatus syncstatus.StatusUpdater, reporter InvalidContentReporter, limits objecttree.TreeLimits) synchandler.SyncHandler {
 	return &syncTreeHandler{
 		objTree:         objTree,
-		syncProtocol:    newTreeSyncProtocol(spaceId, objTree, syncClient),
+		syncProtocol:    newTreeSyncProtocol(spaceId, objTree, syncClient, reporter),
 		syncClient:      syncClient,
 		syncStatus:      syncStatus,
 		spaceId:         spaceId,
+		limits:          limits,
+		rateLimiter:     objecttree.NewPeerRateLimiter(limits),
 		pendingRequests: make(map[string]struct{}),
 	}
 }
@@ -55,6 +59,12 @@ func (s *syncTreeHandler) HandleRequest(ctx context.Context, senderId string, re
 	if fullSyncRequest == nil {
 		return nil, ErrMessageIsNotRequest
 	}
+	if err = s.limits.CheckChangesSize(fullSyncRequest.Changes); err != nil {
+		return
+	}
+	if err = s.rateLimiter.Allow(senderId, len(fullSyncRequest.Changes)); err != nil {
+		return
+	}
 	// setting pending requests
 	s.handlerLock.Lock()
 	_, exists := s.pendingRequests[senderId]
@@ -91,6 +101,11 @@ func (s *syncTreeHandler) HandleMessage(ctx context.Context, senderId string, ms
 	if err != nil {
 		return
 	}
+	// rejecting the changes exceeding the limits before waiting for the tree
+	changes := treechangeproto.GetChanges(unmarshalled)
+	if err = s.limits.CheckChangesSize(changes); err != nil {
+		return
+	}
 	heads := treechangeproto.GetHeads(unmarshalled)
 	s.syncStatus.HeadsReceive(senderId, msg.ObjectId, heads)
 	s.handlerLock.Lock()
@@ -100,6 +115,11 @@ func (s *syncTreeHandler) HandleMessage(ctx context.Context, senderId string, ms
 		return
 	}
 	s.handlerLock.Unlock()
+	// guarding the tree from the flooding peers, the changes rejected by the rate are not stored,
+	// so the peer sends them again with the next update or sync
+	if err = s.rateLimiter.Allow(senderId, len(changes)); err != nil {
+		return
+	}
 	return s.handleMessage(ctx, unmarshalled, senderId)
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree/mock_objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/mock_synctree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
@@ -198,6 +200,39 @@ func TestSyncTreeHandler_HandleMessage(t *testing.T) {
 		err := fx.syncHandler.HandleMessage(ctx, fx.senderId, objectMsg)
 		require.NoError(t, err)
 	})
+
+	t.Run("handle head update message, change exceeds size limit", func(t *testing.T) {
+		fx := newSyncHandlerFixture(t)
+		defer fx.stop()
+		fx.syncHandler.limits = objecttree.TreeLimits{MaxChangeSize: 4}
+		chWithId := &treechangeproto.RawTreeChangeWithId{}
+		headUpdate := &treechangeproto.TreeHeadUpdate{
+			Heads:   []string{"h3"},
+			Changes: []*treechangeproto.RawTreeChangeWithId{{Id: "h3", RawChange: []byte("large change")}},
+		}
+		treeMsg := treechangeproto.WrapHeadUpdate(headUpdate, chWithId)
+		objectMsg, _ := spacesyncproto.MarshallSyncMessage(treeMsg, "spaceId", fx.treeId)
+
+		err := fx.syncHandler.HandleMessage(ctx, fx.senderId, objectMsg)
+		require.ErrorIs(t, err, objecttree.ErrChangeTooLarge)
+	})
+
+	t.Run("handle head update message, sender exceeds rate limit", func(t *testing.T) {
+		fx := newSyncHandlerFixture(t)
+		defer fx.stop()
+		fx.syncHandler.rateLimiter = objecttree.NewPeerRateLimiter(objecttree.TreeLimits{MaxPeerChanges: 1, PeerWindow: time.Minute})
+		require.NoError(t, fx.syncHandler.rateLimiter.Allow(fx.senderId, 1))
+		chWithId := &treechangeproto.RawTreeChangeWithId{}
+		headUpdate := &treechangeproto.TreeHeadUpdate{
+			Heads:   []string{"h3"},
+			Changes: []*treechangeproto.RawTreeChangeWithId{{Id: "h3", RawChange: []byte("change")}},
+		}
+		treeMsg := treechangeproto.WrapHeadUpdate(headUpdate, chWithId)
+		objectMsg, _ := spacesyncproto.MarshallSyncMessage(treeMsg, "spaceId", fx.treeId)
+
+		err := fx.syncHandler.HandleMessage(ctx, fx.senderId, objectMsg)
+		require.ErrorIs(t, err, objecttree.ErrPeerRateExceeded)
+	})
 }
 
 func TestSyncTreeHandler_HandleRequest(t *testing.T) {
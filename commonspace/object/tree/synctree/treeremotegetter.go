@@ -104,9 +104,13 @@ func (t treeRemoteGetter) getTree(ctx context.Context) (treeStorage treestorage.
 		Heads:         fullSyncResp.Heads,
 	}
 
-	// basically building tree with in-memory storage and validating that it was without errors
+	if err = t.deps.Limits.CheckChangesSize(payload.Changes); err != nil {
+		return
+	}
+	// basically building tree with in-memory storage and validating that it was without errors,
+	// the tree is built by the same function as the stored one, so the content of the changes is validated too
 	log.With(zap.String("id", t.treeId)).DebugCtx(ctx, "validating tree")
-	err = objecttree.ValidateRawTree(payload, t.deps.AclList)
+	err = objecttree.ValidateRawTreeBuildFunc(payload, t.deps.BuildObjectTree, t.deps.AclList)
 	if err != nil {
 		return
 	}
@@ -2,6 +2,7 @@ package synctree
 
 import (
 	"context"
+	"errors"
 
 	"github.com/anyproto/any-sync/app/logger"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
@@ -21,14 +22,16 @@ type treeSyncProtocol struct {
 	spaceId    string
 	objTree    objecttree.ObjectTree
 	reqFactory RequestFactory
+	reporter   InvalidContentReporter
 }
 
-func newTreeSyncProtocol(spaceId string, objTree objecttree.ObjectTree, reqFactory RequestFactory) *treeSyncProtocol {
+func newTreeSyncProtocol(spaceId string, objTree objecttree.ObjectTree, reqFactory RequestFactory, reporter InvalidContentReporter) *treeSyncProtocol {
 	return &treeSyncProtocol{
 		log:        log.With(zap.String("spaceId", spaceId), zap.String("treeId", objTree.Id())),
 		spaceId:    spaceId,
 		objTree:    objTree,
 		reqFactory: reqFactory,
+		reporter:   reporter,
 	}
 }
 
@@ -57,6 +60,9 @@ func (t *treeSyncProtocol) HeadUpdate(ctx context.Context, senderId string, upda
 		}
 	}()
 
+	// the peer has the tree from the snapshots of its path, so they are kept on compaction
+	objTree.AckSnapshotPath(senderId, update.SnapshotPath)
+
 	// isEmptyUpdate is sent when the tree is brought up from cache
 	if isEmptyUpdate {
 		headEquals := slice.UnsortedEquals(objTree.Heads(), update.Heads)
@@ -74,7 +80,7 @@ func (t *treeSyncProtocol) HeadUpdate(ctx context.Context, senderId string, upda
 		return
 	}
 
-	_, err = objTree.AddRawChanges(ctx, objecttree.RawChangesPayload{
+	err = t.addRawChanges(ctx, senderId, objecttree.RawChangesPayload{
 		NewHeads:   update.Heads,
 		RawChanges: update.Changes,
 	})
@@ -109,8 +115,9 @@ func (t *treeSyncProtocol) FullSyncRequest(ctx context.Context, senderId string,
 		}
 	}()
 
+	objTree.AckSnapshotPath(senderId, request.SnapshotPath)
 	if len(request.Changes) != 0 && !t.hasHeads(objTree, request.Heads) {
-		_, err = objTree.AddRawChanges(ctx, objecttree.RawChangesPayload{
+		err = t.addRawChanges(ctx, senderId, objecttree.RawChangesPayload{
 			NewHeads:   request.Heads,
 			RawChanges: request.Changes,
 		})
@@ -137,17 +144,28 @@ func (t *treeSyncProtocol) FullSyncResponse(ctx context.Context, senderId string
 			log.DebugCtx(ctx, "full sync response succeeded")
 		}
 	}()
+	objTree.AckSnapshotPath(senderId, response.SnapshotPath)
 	if t.hasHeads(objTree, response.Heads) {
 		return
 	}
 
-	_, err = objTree.AddRawChanges(ctx, objecttree.RawChangesPayload{
+	err = t.addRawChanges(ctx, senderId, objecttree.RawChangesPayload{
 		NewHeads:   response.Heads,
 		RawChanges: response.Changes,
 	})
 	return
 }
 
+// addRawChanges adds the changes of the peer to the tree and reports the peer if the content of the changes is invalid
+func (t *treeSyncProtocol) addRawChanges(ctx context.Context, senderId string, payload objecttree.RawChangesPayload) (err error) {
+	_, err = t.objTree.AddRawChanges(ctx, payload)
+	var validationErr *objecttree.ContentValidationError
+	if t.reporter != nil && errors.As(err, &validationErr) {
+		t.reporter.ReportInvalidContent(senderId, validationErr)
+	}
+	return
+}
+
 func (t *treeSyncProtocol) hasHeads(ot objecttree.ObjectTree, heads []string) bool {
 	return slice.UnsortedEquals(ot.Heads(), heads) || ot.HasChanges(heads...)
 }
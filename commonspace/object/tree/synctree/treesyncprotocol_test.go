@@ -20,6 +20,7 @@ type treeSyncProtocolFixture struct {
 	treeId         string
 	objectTreeMock *testObjTreeMock
 	reqFactory     *mock_synctree.MockRequestFactory
+	reporter       *mock_synctree.MockInvalidContentReporter
 	ctrl           *gomock.Controller
 	syncProtocol   TreeSyncProtocol
 }
@@ -31,8 +32,11 @@ func newSyncProtocolFixture(t *testing.T) *treeSyncProtocolFixture {
 	}
 	spaceId := "spaceId"
 	reqFactory := mock_synctree.NewMockRequestFactory(ctrl)
+	reporter := mock_synctree.NewMockInvalidContentReporter(ctrl)
 	objTree.EXPECT().Id().Return("treeId")
-	syncProtocol := newTreeSyncProtocol(spaceId, objTree, reqFactory)
+	// every message of the peer acknowledges its snapshot path
+	objTree.EXPECT().AckSnapshotPath("senderId", gomock.Any()).AnyTimes()
+	syncProtocol := newTreeSyncProtocol(spaceId, objTree, reqFactory, reporter)
 	return &treeSyncProtocolFixture{
 		log:            log,
 		spaceId:        spaceId,
@@ -40,6 +44,7 @@ func newSyncProtocolFixture(t *testing.T) *treeSyncProtocolFixture {
 		treeId:         "treeId",
 		objectTreeMock: objTree,
 		reqFactory:     reqFactory,
+		reporter:       reporter,
 		ctrl:           ctrl,
 		syncProtocol:   syncProtocol,
 	}
@@ -239,6 +244,35 @@ func TestTreeSyncProtocol_FullSyncRequest(t *testing.T) {
 		_, err := fx.syncProtocol.FullSyncRequest(ctx, fx.senderId, fullSyncRequest)
 		require.Error(t, err)
 	})
+
+	t.Run("full sync request with change, invalid content is reported", func(t *testing.T) {
+		fx := newSyncProtocolFixture(t)
+		defer fx.stop()
+		chWithId := &treechangeproto.RawTreeChangeWithId{}
+		fullSyncRequest := &treechangeproto.TreeFullSyncRequest{
+			Heads:        []string{"h1"},
+			Changes:      []*treechangeproto.RawTreeChangeWithId{chWithId},
+			SnapshotPath: []string{"h1"},
+		}
+		validationErr := &objecttree.ContentValidationError{
+			TreeId:   fx.treeId,
+			ChangeId: "h1",
+			Err:      fmt.Errorf("bad content"),
+		}
+
+		fx.objectTreeMock.EXPECT().Heads().Return([]string{"h2"}).AnyTimes()
+		fx.objectTreeMock.EXPECT().HasChanges(gomock.Eq([]string{"h1"})).Return(false)
+		fx.objectTreeMock.EXPECT().
+			AddRawChanges(gomock.Any(), gomock.Eq(objecttree.RawChangesPayload{
+				NewHeads:   []string{"h1"},
+				RawChanges: []*treechangeproto.RawTreeChangeWithId{chWithId},
+			})).
+			Return(objecttree.AddResult{}, validationErr)
+		fx.reporter.EXPECT().ReportInvalidContent(fx.senderId, validationErr)
+
+		_, err := fx.syncProtocol.FullSyncRequest(ctx, fx.senderId, fullSyncRequest)
+		require.ErrorIs(t, err, objecttree.ErrInvalidContent)
+	})
 }
 
 func TestTreeSyncProtocol_FullSyncResponse(t *testing.T) {
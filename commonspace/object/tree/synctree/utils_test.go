@@ -182,7 +182,7 @@ func createSyncHandler(peerId, spaceId string, objTree objecttree.ObjectTree, lo
 		ObjectTree: objTree,
 		SyncClient: syncClient,
 	}
-	handler := newSyncTreeHandler(spaceId, netTree, syncClient, syncstatus.NewNoOpSyncStatus())
+	handler := newSyncTreeHandler(spaceId, netTree, syncClient, syncstatus.NewNoOpSyncStatus(), nil, objecttree.TreeLimits{})
 	return &testSyncHandler{
 		SyncHandler: handler,
 		batcher:     mb.New[protocolMsg](0),
@@ -238,7 +238,7 @@ func (h *testSyncHandler) HandleMessage(ctx context.Context, senderId string, re
 	if err != nil {
 		return
 	}
-	h.SyncHandler = newSyncTreeHandler(request.SpaceId, netTree, h.syncClient, syncstatus.NewNoOpSyncStatus())
+	h.SyncHandler = newSyncTreeHandler(request.SpaceId, netTree, h.syncClient, syncstatus.NewNoOpSyncStatus(), nil, objecttree.TreeLimits{})
 	headUpdate := NewRequestFactory().CreateHeadUpdate(netTree, res.Added)
 	h.syncClient.Broadcast(headUpdate)
 	return nil
@@ -51,3 +51,17 @@ func GetHeads(msg *TreeSyncMessage) (heads []string) {
 		return nil
 	}
 }
+
+func GetChanges(msg *TreeSyncMessage) (changes []*RawTreeChangeWithId) {
+	content := msg.GetContent()
+	switch {
+	case content.GetHeadUpdate() != nil:
+		return content.GetHeadUpdate().Changes
+	case content.GetFullSyncRequest() != nil:
+		return content.GetFullSyncRequest().Changes
+	case content.GetFullSyncResponse() != nil:
+		return content.GetFullSyncResponse().Changes
+	default:
+		return nil
+	}
+}
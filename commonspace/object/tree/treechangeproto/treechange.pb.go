@@ -22,6 +22,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// ChangeCompression is the algorithm of the compression of the change data,
+// the clients which don't know the algorithm reject the change
+type ChangeCompression int32
+
+const (
+	ChangeCompression_Uncompressed ChangeCompression = 0
+	ChangeCompression_Flate        ChangeCompression = 1
+)
+
+var ChangeCompression_name = map[int32]string{
+	0: "Uncompressed",
+	1: "Flate",
+}
+
+var ChangeCompression_value = map[string]int32{
+	"Uncompressed": 0,
+	"Flate":        1,
+}
+
+func (x ChangeCompression) String() string {
+	return proto.EnumName(ChangeCompression_name, int32(x))
+}
+
+func (ChangeCompression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{0}
+}
+
 type ErrorCodes int32
 
 const (
@@ -50,7 +77,7 @@ func (x ErrorCodes) String() string {
 }
 
 func (ErrorCodes) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{0}
+	return fileDescriptor_5033f0301ef9b772, []int{1}
 }
 
 // RootChange is a root of a tree
@@ -71,6 +98,8 @@ type RootChange struct {
 	ChangePayload []byte `protobuf:"bytes,7,opt,name=changePayload,proto3" json:"changePayload,omitempty"`
 	// IsDerived tells if the tree is derived
 	IsDerived bool `protobuf:"varint,8,opt,name=isDerived,proto3" json:"isDerived,omitempty"`
+	// TreeKey is set if the tree is encrypted with its own key instead of the space read key
+	TreeKey *TreeKey `protobuf:"bytes,9,opt,name=treeKey,proto3" json:"treeKey,omitempty"`
 }
 
 func (m *RootChange) Reset()         { *m = RootChange{} }
@@ -162,6 +191,205 @@ func (m *RootChange) GetIsDerived() bool {
 	return false
 }
 
+func (m *RootChange) GetTreeKey() *TreeKey {
+	if m != nil {
+		return m.TreeKey
+	}
+	return nil
+}
+
+// TreeKey is a key of a single tree encrypted with the key derived from the space read key
+type TreeKey struct {
+	// ReadKeyId is the id of the space read key which encrypts the tree key
+	ReadKeyId string `protobuf:"bytes,1,opt,name=readKeyId,proto3" json:"readKeyId,omitempty"`
+	// EncryptedKey is the encrypted tree key
+	EncryptedKey []byte `protobuf:"bytes,2,opt,name=encryptedKey,proto3" json:"encryptedKey,omitempty"`
+}
+
+func (m *TreeKey) Reset()         { *m = TreeKey{} }
+func (m *TreeKey) String() string { return proto.CompactTextString(m) }
+func (*TreeKey) ProtoMessage()    {}
+func (*TreeKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{1}
+}
+func (m *TreeKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TreeKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TreeKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TreeKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeKey.Merge(m, src)
+}
+func (m *TreeKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *TreeKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TreeKey proto.InternalMessageInfo
+
+func (m *TreeKey) GetReadKeyId() string {
+	if m != nil {
+		return m.ReadKeyId
+	}
+	return ""
+}
+
+func (m *TreeKey) GetEncryptedKey() []byte {
+	if m != nil {
+		return m.EncryptedKey
+	}
+	return nil
+}
+
+// TreeKeyShare is a key of a single tree encrypted for the identity which is not a member of the space
+type TreeKeyShare struct {
+	// TreeId is the id of the tree
+	TreeId string `protobuf:"bytes,1,opt,name=treeId,proto3" json:"treeId,omitempty"`
+	// Identity is a public key of the identity which can decrypt the tree key
+	Identity []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	// EncryptedKey is the tree key encrypted with the identity public key
+	EncryptedKey []byte `protobuf:"bytes,3,opt,name=encryptedKey,proto3" json:"encryptedKey,omitempty"`
+	// KeyId is the id of the generation of the tree key, the shares without it contain the key from the root of the tree
+	KeyId string `protobuf:"bytes,4,opt,name=keyId,proto3" json:"keyId,omitempty"`
+}
+
+func (m *TreeKeyShare) Reset()         { *m = TreeKeyShare{} }
+func (m *TreeKeyShare) String() string { return proto.CompactTextString(m) }
+func (*TreeKeyShare) ProtoMessage()    {}
+func (*TreeKeyShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{2}
+}
+func (m *TreeKeyShare) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TreeKeyShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TreeKeyShare.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TreeKeyShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeKeyShare.Merge(m, src)
+}
+func (m *TreeKeyShare) XXX_Size() int {
+	return m.Size()
+}
+func (m *TreeKeyShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeKeyShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TreeKeyShare proto.InternalMessageInfo
+
+func (m *TreeKeyShare) GetTreeId() string {
+	if m != nil {
+		return m.TreeId
+	}
+	return ""
+}
+
+func (m *TreeKeyShare) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *TreeKeyShare) GetEncryptedKey() []byte {
+	if m != nil {
+		return m.EncryptedKey
+	}
+	return nil
+}
+
+func (m *TreeKeyShare) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+// TreeForkInfo is stored in the change payload of the root of the tree created from a version of another tree
+type TreeForkInfo struct {
+	// SourceTreeId is the id of the tree which was forked
+	SourceTreeId string `protobuf:"bytes,1,opt,name=sourceTreeId,proto3" json:"sourceTreeId,omitempty"`
+	// SourceChangeId is the id of the last change of the source tree included in the fork
+	SourceChangeId string `protobuf:"bytes,2,opt,name=sourceChangeId,proto3" json:"sourceChangeId,omitempty"`
+	// ChangePayload is a payload related to ChangeType of the forked tree
+	ChangePayload []byte `protobuf:"bytes,3,opt,name=changePayload,proto3" json:"changePayload,omitempty"`
+}
+
+func (m *TreeForkInfo) Reset()         { *m = TreeForkInfo{} }
+func (m *TreeForkInfo) String() string { return proto.CompactTextString(m) }
+func (*TreeForkInfo) ProtoMessage()    {}
+func (*TreeForkInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5033f0301ef9b772, []int{3}
+}
+func (m *TreeForkInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TreeForkInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TreeForkInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TreeForkInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TreeForkInfo.Merge(m, src)
+}
+func (m *TreeForkInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *TreeForkInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TreeForkInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TreeForkInfo proto.InternalMessageInfo
+
+func (m *TreeForkInfo) GetSourceTreeId() string {
+	if m != nil {
+		return m.SourceTreeId
+	}
+	return ""
+}
+
+func (m *TreeForkInfo) GetSourceChangeId() string {
+	if m != nil {
+		return m.SourceChangeId
+	}
+	return ""
+}
+
+func (m *TreeForkInfo) GetChangePayload() []byte {
+	if m != nil {
+		return m.ChangePayload
+	}
+	return nil
+}
+
 // TreeChange is a change of a tree
 type TreeChange struct {
 	// TreeHeadIds are previous ids for this TreeChange
@@ -182,13 +410,21 @@ type TreeChange struct {
 	IsSnapshot bool `protobuf:"varint,8,opt,name=isSnapshot,proto3" json:"isSnapshot,omitempty"`
 	// DataType indicates some special parameters of data for the client
 	DataType string `protobuf:"bytes,9,opt,name=dataType,proto3" json:"dataType,omitempty"`
+	// Compression is the algorithm which compressed CompressedChangesData before the encryption
+	Compression ChangeCompression `protobuf:"varint,10,opt,name=compression,proto3,enum=treechange.ChangeCompression" json:"compression,omitempty"`
+	// TreeKey is the generation of the own key of the tree which encrypts this change, it is set in the change
+	// rotating the key after the space read key was changed and in the snapshots
+	TreeKey *TreeKey `protobuf:"bytes,11,opt,name=treeKey,proto3" json:"treeKey,omitempty"`
+	// CompressedChangesData is the payload of the compressed change instead of ChangesData,
+	// so the clients which don't know the compression don't read it as the uncompressed payload
+	CompressedChangesData []byte `protobuf:"bytes,12,opt,name=compressedChangesData,proto3" json:"compressedChangesData,omitempty"`
 }
 
 func (m *TreeChange) Reset()         { *m = TreeChange{} }
 func (m *TreeChange) String() string { return proto.CompactTextString(m) }
 func (*TreeChange) ProtoMessage()    {}
 func (*TreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{1}
+	return fileDescriptor_5033f0301ef9b772, []int{4}
 }
 func (m *TreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -280,6 +516,27 @@ func (m *TreeChange) GetDataType() string {
 	return ""
 }
 
+func (m *TreeChange) GetCompression() ChangeCompression {
+	if m != nil {
+		return m.Compression
+	}
+	return ChangeCompression_Uncompressed
+}
+
+func (m *TreeChange) GetTreeKey() *TreeKey {
+	if m != nil {
+		return m.TreeKey
+	}
+	return nil
+}
+
+func (m *TreeChange) GetCompressedChangesData() []byte {
+	if m != nil {
+		return m.CompressedChangesData
+	}
+	return nil
+}
+
 // RawTreeChange is a marshalled TreeChange (or RootChange) payload and a signature of this payload
 type RawTreeChange struct {
 	// Payload is a byte payload containing TreeChange
@@ -292,7 +549,7 @@ func (m *RawTreeChange) Reset()         { *m = RawTreeChange{} }
 func (m *RawTreeChange) String() string { return proto.CompactTextString(m) }
 func (*RawTreeChange) ProtoMessage()    {}
 func (*RawTreeChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{2}
+	return fileDescriptor_5033f0301ef9b772, []int{5}
 }
 func (m *RawTreeChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -347,7 +604,7 @@ func (m *RawTreeChangeWithId) Reset()         { *m = RawTreeChangeWithId{} }
 func (m *RawTreeChangeWithId) String() string { return proto.CompactTextString(m) }
 func (*RawTreeChangeWithId) ProtoMessage()    {}
 func (*RawTreeChangeWithId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{3}
+	return fileDescriptor_5033f0301ef9b772, []int{6}
 }
 func (m *RawTreeChangeWithId) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -399,7 +656,7 @@ func (m *TreeSyncMessage) Reset()         { *m = TreeSyncMessage{} }
 func (m *TreeSyncMessage) String() string { return proto.CompactTextString(m) }
 func (*TreeSyncMessage) ProtoMessage()    {}
 func (*TreeSyncMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{4}
+	return fileDescriptor_5033f0301ef9b772, []int{7}
 }
 func (m *TreeSyncMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -445,7 +702,6 @@ func (m *TreeSyncMessage) GetRootChange() *RawTreeChangeWithId {
 // TreeSyncContentValue provides different types for tree sync
 type TreeSyncContentValue struct {
 	// Types that are valid to be assigned to Value:
-	//
 	//	*TreeSyncContentValue_HeadUpdate
 	//	*TreeSyncContentValue_FullSyncRequest
 	//	*TreeSyncContentValue_FullSyncResponse
@@ -457,7 +713,7 @@ func (m *TreeSyncContentValue) Reset()         { *m = TreeSyncContentValue{} }
 func (m *TreeSyncContentValue) String() string { return proto.CompactTextString(m) }
 func (*TreeSyncContentValue) ProtoMessage()    {}
 func (*TreeSyncContentValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{5}
+	return fileDescriptor_5033f0301ef9b772, []int{8}
 }
 func (m *TreeSyncContentValue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -566,7 +822,7 @@ func (m *TreeHeadUpdate) Reset()         { *m = TreeHeadUpdate{} }
 func (m *TreeHeadUpdate) String() string { return proto.CompactTextString(m) }
 func (*TreeHeadUpdate) ProtoMessage()    {}
 func (*TreeHeadUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{6}
+	return fileDescriptor_5033f0301ef9b772, []int{9}
 }
 func (m *TreeHeadUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -627,7 +883,7 @@ func (m *TreeFullSyncRequest) Reset()         { *m = TreeFullSyncRequest{} }
 func (m *TreeFullSyncRequest) String() string { return proto.CompactTextString(m) }
 func (*TreeFullSyncRequest) ProtoMessage()    {}
 func (*TreeFullSyncRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{7}
+	return fileDescriptor_5033f0301ef9b772, []int{10}
 }
 func (m *TreeFullSyncRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -688,7 +944,7 @@ func (m *TreeFullSyncResponse) Reset()         { *m = TreeFullSyncResponse{} }
 func (m *TreeFullSyncResponse) String() string { return proto.CompactTextString(m) }
 func (*TreeFullSyncResponse) ProtoMessage()    {}
 func (*TreeFullSyncResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{8}
+	return fileDescriptor_5033f0301ef9b772, []int{11}
 }
 func (m *TreeFullSyncResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -748,7 +1004,7 @@ func (m *TreeErrorResponse) Reset()         { *m = TreeErrorResponse{} }
 func (m *TreeErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*TreeErrorResponse) ProtoMessage()    {}
 func (*TreeErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{9}
+	return fileDescriptor_5033f0301ef9b772, []int{12}
 }
 func (m *TreeErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -801,7 +1057,7 @@ func (m *TreeChangeInfo) Reset()         { *m = TreeChangeInfo{} }
 func (m *TreeChangeInfo) String() string { return proto.CompactTextString(m) }
 func (*TreeChangeInfo) ProtoMessage()    {}
 func (*TreeChangeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5033f0301ef9b772, []int{10}
+	return fileDescriptor_5033f0301ef9b772, []int{13}
 }
 func (m *TreeChangeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -845,8 +1101,12 @@ func (m *TreeChangeInfo) GetChangePayload() []byte {
 }
 
 func init() {
+	proto.RegisterEnum("treechange.ChangeCompression", ChangeCompression_name, ChangeCompression_value)
 	proto.RegisterEnum("treechange.ErrorCodes", ErrorCodes_name, ErrorCodes_value)
 	proto.RegisterType((*RootChange)(nil), "treechange.RootChange")
+	proto.RegisterType((*TreeKey)(nil), "treechange.TreeKey")
+	proto.RegisterType((*TreeKeyShare)(nil), "treechange.TreeKeyShare")
+	proto.RegisterType((*TreeForkInfo)(nil), "treechange.TreeForkInfo")
 	proto.RegisterType((*TreeChange)(nil), "treechange.TreeChange")
 	proto.RegisterType((*RawTreeChange)(nil), "treechange.RawTreeChange")
 	proto.RegisterType((*RawTreeChangeWithId)(nil), "treechange.RawTreeChangeWithId")
@@ -864,56 +1124,67 @@ func init() {
 }
 
 var fileDescriptor_5033f0301ef9b772 = []byte{
-	// 769 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0xbd, 0x6e, 0xe3, 0x46,
-	0x10, 0xe6, 0x52, 0xb2, 0x65, 0x8d, 0x64, 0x9d, 0xb2, 0xe7, 0x82, 0x38, 0x24, 0x0c, 0x41, 0x04,
-	0x89, 0x90, 0xe2, 0x0c, 0x5c, 0xaa, 0x04, 0x01, 0x0e, 0xb1, 0xee, 0x47, 0xc2, 0x21, 0xc9, 0x61,
-	0xef, 0x27, 0x80, 0xbb, 0x35, 0x39, 0xb2, 0x18, 0x48, 0x24, 0xc3, 0x5d, 0xd9, 0xd1, 0x03, 0xa4,
-	0x49, 0x80, 0xc0, 0xcf, 0x91, 0xb7, 0x48, 0x97, 0xd2, 0x65, 0xca, 0xc0, 0x7e, 0x87, 0xd4, 0x87,
-	0xdd, 0x25, 0xc5, 0x1f, 0xa9, 0x70, 0xe7, 0x46, 0xe2, 0x7c, 0x9c, 0xf9, 0xe6, 0xe3, 0x37, 0x1c,
-	0x2e, 0x3c, 0x0d, 0x92, 0xe5, 0x32, 0x89, 0x45, 0xca, 0x03, 0x3c, 0x4e, 0xce, 0x7e, 0xc6, 0x40,
-	0x1e, 0xcb, 0x0c, 0x51, 0xff, 0x04, 0x73, 0x1e, 0x9f, 0x63, 0x9a, 0x25, 0x32, 0x39, 0xd6, 0xbf,
-	0xa2, 0x02, 0x3f, 0xd6, 0x08, 0x85, 0x12, 0xf1, 0xff, 0x27, 0x00, 0x2c, 0x49, 0xe4, 0x58, 0x87,
-	0xf4, 0x63, 0xe8, 0xf2, 0x60, 0x31, 0x41, 0x1e, 0x4e, 0x43, 0x87, 0x78, 0x64, 0xd4, 0x65, 0x25,
-	0x40, 0x1d, 0xe8, 0xe8, 0xae, 0xd3, 0xd0, 0xb1, 0xf5, 0xbd, 0x22, 0xa4, 0x2e, 0x80, 0x21, 0x7c,
-	0xbb, 0x4e, 0xd1, 0x69, 0xe9, 0x9b, 0x15, 0x44, 0xf1, 0xca, 0x68, 0x89, 0x42, 0xf2, 0x65, 0xea,
-	0xb4, 0x3d, 0x32, 0x6a, 0xb1, 0x12, 0xa0, 0x14, 0xda, 0x02, 0x31, 0x74, 0xf6, 0x3c, 0x32, 0xea,
-	0x33, 0x7d, 0x4d, 0x1f, 0xc1, 0x41, 0x14, 0x62, 0x2c, 0x23, 0xb9, 0x76, 0xf6, 0x35, 0xbe, 0x89,
-	0xe9, 0x67, 0x70, 0x68, 0xb8, 0x5f, 0xf3, 0xf5, 0x22, 0xe1, 0xa1, 0xd3, 0xd1, 0x09, 0x75, 0x50,
-	0xf5, 0x8c, 0xc4, 0x33, 0xcc, 0xa2, 0x0b, 0x0c, 0x9d, 0x03, 0x8f, 0x8c, 0x0e, 0x58, 0x09, 0xf8,
-	0x7f, 0xd9, 0x00, 0x6f, 0x33, 0xc4, 0xfc, 0xc1, 0x3d, 0xe8, 0x29, 0x57, 0xcc, 0x83, 0x0a, 0x87,
-	0x78, 0xad, 0x51, 0x97, 0x55, 0xa1, 0xba, 0x35, 0x76, 0xd3, 0x9a, 0xcf, 0x61, 0x20, 0x62, 0x9e,
-	0x8a, 0x79, 0x22, 0x4f, 0xb8, 0x50, 0x0e, 0x19, 0x13, 0x1a, 0xa8, 0xea, 0x63, 0x54, 0x8a, 0x67,
-	0x5c, 0x72, 0x6d, 0x45, 0x9f, 0x55, 0x21, 0xd5, 0x27, 0x43, 0x1e, 0xbe, 0xc2, 0xf5, 0xd4, 0x38,
-	0xd2, 0x65, 0x25, 0x50, 0x37, 0x72, 0xbf, 0x69, 0x64, 0xd5, 0xb4, 0x4e, 0xc3, 0x34, 0x17, 0x20,
-	0x12, 0x6f, 0x72, 0x35, 0xb9, 0x1f, 0x15, 0x44, 0xd5, 0x86, 0x5c, 0x72, 0x3d, 0xc0, 0xae, 0x6e,
-	0xbb, 0x89, 0xfd, 0x97, 0x70, 0xc8, 0xf8, 0x65, 0xc5, 0x2e, 0x07, 0x3a, 0x69, 0xee, 0x3d, 0xd1,
-	0x7d, 0x8a, 0x50, 0x09, 0x14, 0xd1, 0x79, 0xcc, 0xe5, 0x2a, 0x43, 0x6d, 0x53, 0x9f, 0x95, 0x80,
-	0x3f, 0x86, 0x87, 0x35, 0xa2, 0x9f, 0x22, 0x39, 0x37, 0x4f, 0x95, 0xf1, 0x4b, 0x03, 0xe5, 0x84,
-	0x25, 0x40, 0x07, 0x60, 0x47, 0x85, 0xe5, 0x76, 0x14, 0xfa, 0x7f, 0x12, 0x78, 0xa0, 0x28, 0xde,
-	0xac, 0xe3, 0xe0, 0x7b, 0x14, 0x82, 0x9f, 0x23, 0xfd, 0x06, 0x3a, 0x41, 0x12, 0x4b, 0x8c, 0xa5,
-	0xae, 0xef, 0x3d, 0xf1, 0x1e, 0x57, 0xde, 0xfb, 0x22, 0x7b, 0x6c, 0x52, 0xde, 0xf3, 0xc5, 0x0a,
-	0x59, 0x51, 0x40, 0x9f, 0x02, 0x64, 0x9b, 0x15, 0xd0, 0x7d, 0x7a, 0x4f, 0x3e, 0xad, 0x96, 0xef,
-	0x90, 0xcc, 0x2a, 0x25, 0xfe, 0xdf, 0x36, 0x1c, 0xed, 0x6a, 0x41, 0xbf, 0x05, 0x98, 0x23, 0x0f,
-	0xdf, 0xa5, 0x21, 0x97, 0x98, 0x0b, 0x7b, 0xd4, 0x14, 0x36, 0xd9, 0x64, 0x4c, 0x2c, 0x56, 0xc9,
-	0xa7, 0xaf, 0xe0, 0xc1, 0x6c, 0xb5, 0x58, 0x28, 0x56, 0x86, 0xbf, 0xac, 0x50, 0xc8, 0x5d, 0xe2,
-	0x14, 0xc5, 0x8b, 0x7a, 0xda, 0xc4, 0x62, 0xcd, 0x4a, 0xfa, 0x03, 0x0c, 0x4b, 0x48, 0xa4, 0x49,
-	0x2c, 0xcc, 0x9e, 0xee, 0x70, 0xea, 0x45, 0x23, 0x6f, 0x62, 0xb1, 0xad, 0x5a, 0xfa, 0x1c, 0x0e,
-	0x31, 0xcb, 0x92, 0x6c, 0x43, 0xd6, 0xd6, 0x64, 0x9f, 0x34, 0xc9, 0x9e, 0x57, 0x93, 0x26, 0x16,
-	0xab, 0x57, 0x9d, 0x74, 0x60, 0xef, 0x42, 0x59, 0xe5, 0xff, 0x46, 0x60, 0x50, 0x77, 0x83, 0x1e,
-	0xc1, 0x9e, 0x72, 0xa3, 0xd8, 0x46, 0x13, 0xd0, 0xaf, 0xa1, 0x93, 0xaf, 0x8b, 0x63, 0x7b, 0xad,
-	0xbb, 0x8c, 0xaa, 0xc8, 0xa7, 0x3e, 0xf4, 0x8b, 0x75, 0x7c, 0xcd, 0xe5, 0xdc, 0x69, 0x69, 0xde,
-	0x1a, 0xe6, 0xff, 0x4e, 0xe0, 0xe1, 0x0e, 0x4b, 0xef, 0x47, 0xcc, 0x1f, 0xc4, 0xbc, 0x58, 0xcd,
-	0x89, 0xdc, 0x8f, 0x9a, 0x31, 0x7c, 0xb4, 0x35, 0x51, 0xa5, 0x44, 0x4f, 0x34, 0x3f, 0x2d, 0x4c,
-	0xa0, 0xbe, 0x0f, 0x98, 0x65, 0xe3, 0x24, 0x34, 0xfb, 0xd4, 0x66, 0x45, 0xe8, 0xbf, 0x37, 0x63,
-	0x36, 0x2a, 0xa6, 0xf1, 0x2c, 0x69, 0x9c, 0x1d, 0x64, 0xeb, 0xec, 0xd8, 0xfa, 0xda, 0xdb, 0x3b,
-	0xbe, 0xf6, 0x5f, 0x9e, 0x02, 0x68, 0x61, 0xaa, 0x89, 0xa0, 0x03, 0x80, 0x77, 0x31, 0xfe, 0x9a,
-	0x62, 0x20, 0x31, 0x1c, 0x5a, 0x74, 0x08, 0xfd, 0x97, 0x28, 0x37, 0xea, 0x87, 0x84, 0x3a, 0x70,
-	0xd4, 0x18, 0xb1, 0xb9, 0x63, 0xd3, 0x21, 0xf4, 0xf4, 0xe5, 0x8f, 0xb3, 0x99, 0x40, 0x39, 0xbc,
-	0x6a, 0x9d, 0x7c, 0xf7, 0xcf, 0x8d, 0x4b, 0xae, 0x6f, 0x5c, 0xf2, 0xdf, 0x8d, 0x4b, 0xae, 0x6e,
-	0x5d, 0xeb, 0xfa, 0xd6, 0xb5, 0xfe, 0xbd, 0x75, 0xad, 0xd3, 0x2f, 0xee, 0x78, 0x16, 0x9f, 0xed,
-	0xeb, 0xbf, 0xaf, 0x3e, 0x04, 0x00, 0x00, 0xff, 0xff, 0xa1, 0x34, 0xb3, 0x76, 0xbd, 0x07, 0x00,
-	0x00,
+	// 960 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcf, 0x6f, 0x1b, 0xc5,
+	0x17, 0xf7, 0xac, 0xed, 0x38, 0x7e, 0x76, 0x5c, 0x77, 0x92, 0xef, 0x57, 0xab, 0x8a, 0x9a, 0xd5,
+	0x0a, 0x81, 0x55, 0x89, 0x06, 0x05, 0x2e, 0x20, 0xa4, 0x88, 0xb8, 0x4d, 0x1d, 0x45, 0x40, 0x35,
+	0x49, 0x8a, 0xd4, 0xdb, 0x74, 0xf7, 0x25, 0x5e, 0x6a, 0xef, 0x2c, 0x3b, 0xe3, 0x16, 0x4b, 0x1c,
+	0xb8, 0x70, 0x01, 0x09, 0xf5, 0x9f, 0xe0, 0x0f, 0xe1, 0xc6, 0x31, 0x47, 0x8e, 0x28, 0xf9, 0x47,
+	0xd0, 0xcc, 0xac, 0xbd, 0x3f, 0x6c, 0x89, 0xde, 0x7a, 0xb1, 0xf7, 0x7d, 0xe6, 0xbd, 0xcf, 0x7b,
+	0xfb, 0x79, 0x6f, 0x66, 0x16, 0x0e, 0x03, 0x31, 0x9b, 0x89, 0x58, 0x26, 0x3c, 0xc0, 0x7d, 0xf1,
+	0xe2, 0x7b, 0x0c, 0xd4, 0xbe, 0x4a, 0x11, 0xcd, 0x4f, 0x30, 0xe1, 0xf1, 0x15, 0x26, 0xa9, 0x50,
+	0x62, 0xdf, 0xfc, 0xca, 0x02, 0xfc, 0xd0, 0x20, 0x14, 0x72, 0xc4, 0xff, 0xc3, 0x01, 0x60, 0x42,
+	0xa8, 0x91, 0x31, 0xe9, 0x7b, 0xd0, 0xe6, 0xc1, 0x74, 0x8c, 0x3c, 0x3c, 0x09, 0x5d, 0xe2, 0x91,
+	0x61, 0x9b, 0xe5, 0x00, 0x75, 0xa1, 0x65, 0xb2, 0x9e, 0x84, 0xae, 0x63, 0xd6, 0x96, 0x26, 0x1d,
+	0x00, 0x58, 0xc2, 0xf3, 0x45, 0x82, 0x6e, 0xdd, 0x2c, 0x16, 0x10, 0xcd, 0xab, 0xa2, 0x19, 0x4a,
+	0xc5, 0x67, 0x89, 0xdb, 0xf0, 0xc8, 0xb0, 0xce, 0x72, 0x80, 0x52, 0x68, 0x48, 0xc4, 0xd0, 0x6d,
+	0x7a, 0x64, 0xd8, 0x65, 0xe6, 0x99, 0xde, 0x83, 0xed, 0x28, 0xc4, 0x58, 0x45, 0x6a, 0xe1, 0x6e,
+	0x19, 0x7c, 0x65, 0xd3, 0x0f, 0x60, 0xc7, 0x72, 0x3f, 0xe5, 0x8b, 0xa9, 0xe0, 0xa1, 0xdb, 0x32,
+	0x0e, 0x65, 0x50, 0xe7, 0x8c, 0xe4, 0x23, 0x4c, 0xa3, 0x57, 0x18, 0xba, 0xdb, 0x1e, 0x19, 0x6e,
+	0xb3, 0x1c, 0xa0, 0x1f, 0x43, 0x4b, 0xcb, 0x70, 0x8a, 0x0b, 0xb7, 0xed, 0x91, 0x61, 0xe7, 0x60,
+	0xf7, 0x61, 0x41, 0xa8, 0x73, 0xbb, 0xc4, 0x96, 0x3e, 0xfe, 0x29, 0xb4, 0x32, 0x4c, 0xf3, 0xa6,
+	0xc8, 0xc3, 0x53, 0x5c, 0xe4, 0x1a, 0xad, 0x00, 0xea, 0x43, 0x17, 0xe3, 0x20, 0x5d, 0x24, 0x0a,
+	0x35, 0x62, 0x84, 0xea, 0xb2, 0x12, 0xe6, 0xff, 0x04, 0xdd, 0x8c, 0xec, 0x6c, 0xc2, 0x53, 0xa4,
+	0xff, 0x87, 0x2d, 0x9d, 0x67, 0x45, 0x97, 0x59, 0x25, 0x0d, 0x9c, 0x8a, 0x06, 0xd5, 0x3c, 0xf5,
+	0xf5, 0x3c, 0x74, 0x0f, 0x9a, 0x2f, 0x4d, 0x95, 0x0d, 0x43, 0x6b, 0x0d, 0xff, 0x67, 0x62, 0xd3,
+	0x1f, 0x8b, 0xf4, 0xe5, 0x49, 0x7c, 0x29, 0x34, 0x95, 0x14, 0xf3, 0x34, 0xc0, 0xf3, 0x62, 0x11,
+	0x25, 0x8c, 0x7e, 0x08, 0x3d, 0x6b, 0xdb, 0x41, 0x59, 0x4d, 0x40, 0x05, 0x5d, 0x6f, 0x4d, 0x7d,
+	0x43, 0x6b, 0xfc, 0xeb, 0x3a, 0x80, 0x26, 0xce, 0xa6, 0xce, 0x83, 0x8e, 0x7e, 0x63, 0x3b, 0x65,
+	0xd2, 0x25, 0x5e, 0x7d, 0xd8, 0x66, 0x45, 0xa8, 0x3c, 0x97, 0x4e, 0x75, 0x2e, 0x75, 0x71, 0x31,
+	0x4f, 0xe4, 0x44, 0xa8, 0x23, 0x2e, 0xf1, 0xc4, 0x66, 0x6d, 0xb3, 0x0a, 0xaa, 0xf3, 0xd8, 0x3a,
+	0xe4, 0x23, 0xae, 0xb8, 0x51, 0xa5, 0xcb, 0x8a, 0x50, 0xb9, 0xb7, 0xcd, 0x6a, 0x6f, 0x4b, 0x53,
+	0xbc, 0x55, 0x9d, 0xe2, 0x62, 0xb7, 0x5a, 0x95, 0x6e, 0x0d, 0x00, 0x22, 0x79, 0x96, 0x55, 0x93,
+	0x0d, 0x63, 0x01, 0xd1, 0xb1, 0x21, 0x57, 0xdc, 0xec, 0x9e, 0xb6, 0x49, 0xbb, 0xb2, 0xe9, 0x21,
+	0x74, 0x02, 0x31, 0x4b, 0x52, 0x94, 0x32, 0x12, 0xb1, 0x0b, 0x1e, 0x19, 0xf6, 0x0e, 0xee, 0x17,
+	0xa7, 0xd5, 0xca, 0x38, 0xca, 0x9d, 0x58, 0x31, 0xa2, 0x38, 0xea, 0x9d, 0xff, 0x1e, 0x75, 0xfa,
+	0x19, 0xfc, 0x6f, 0x19, 0x8d, 0xe1, 0xa8, 0xa0, 0x57, 0xd7, 0xbc, 0xd4, 0xe6, 0x45, 0xff, 0x09,
+	0xec, 0x30, 0xfe, 0xba, 0xd0, 0x54, 0x17, 0x5a, 0x49, 0x36, 0x03, 0xc4, 0x04, 0x2e, 0x4d, 0x2d,
+	0xa3, 0x8c, 0xae, 0x62, 0xae, 0xe6, 0x29, 0x66, 0x73, 0x9d, 0x03, 0xfe, 0x08, 0x76, 0x4b, 0x44,
+	0xdf, 0x45, 0x6a, 0x62, 0xb5, 0x4f, 0xf9, 0x6b, 0x0b, 0x65, 0x84, 0x39, 0x40, 0x7b, 0xe0, 0x44,
+	0xcb, 0xc1, 0x70, 0xa2, 0xd0, 0xff, 0x9d, 0xc0, 0x1d, 0x4d, 0x71, 0xb6, 0x88, 0x83, 0xaf, 0x51,
+	0x4a, 0x7e, 0x85, 0xf4, 0x0b, 0x68, 0x05, 0x22, 0x56, 0x18, 0x2b, 0x13, 0xdf, 0x39, 0xf0, 0xaa,
+	0x32, 0x68, 0xef, 0x91, 0x75, 0x79, 0xc6, 0xa7, 0x73, 0x64, 0xcb, 0x00, 0x7a, 0x08, 0x90, 0xae,
+	0x4e, 0x49, 0x93, 0xa7, 0x73, 0xf0, 0x7e, 0x31, 0x7c, 0x43, 0xc9, 0xac, 0x10, 0xe2, 0xff, 0xe9,
+	0xc0, 0xde, 0xa6, 0x14, 0xf4, 0x4b, 0x80, 0x09, 0xf2, 0xf0, 0x22, 0x09, 0xb9, 0xc2, 0xac, 0xb0,
+	0x7b, 0xd5, 0xc2, 0xc6, 0x2b, 0x8f, 0x71, 0x8d, 0x15, 0xfc, 0xe9, 0x29, 0xdc, 0xb9, 0x9c, 0x4f,
+	0xa7, 0x9a, 0x95, 0xe1, 0x0f, 0x73, 0x94, 0x6a, 0x53, 0x71, 0x66, 0xb7, 0x97, 0xdd, 0xc6, 0x35,
+	0x56, 0x8d, 0xa4, 0xdf, 0x40, 0x3f, 0x87, 0x64, 0x22, 0x62, 0x69, 0x8f, 0xf2, 0x0d, 0x4a, 0x1d,
+	0x57, 0xfc, 0xc6, 0x35, 0xb6, 0x16, 0x4b, 0x1f, 0xc3, 0x0e, 0xa6, 0xa9, 0x48, 0x57, 0x64, 0x0d,
+	0x43, 0x76, 0xbf, 0x4a, 0xf6, 0xb8, 0xe8, 0x34, 0xae, 0xb1, 0x72, 0xd4, 0x51, 0x0b, 0x9a, 0xaf,
+	0xb4, 0x54, 0xfe, 0x2f, 0x04, 0x7a, 0x65, 0x35, 0xf4, 0x09, 0xa7, 0xd5, 0x58, 0x9e, 0x19, 0xd6,
+	0xa0, 0x9f, 0x43, 0x2b, 0xdb, 0xd4, 0xae, 0xe3, 0xd5, 0xdf, 0xa6, 0x55, 0x4b, 0x7f, 0x73, 0x16,
+	0x66, 0x9b, 0xf2, 0x29, 0x57, 0x13, 0xb7, 0x6e, 0x78, 0x4b, 0x98, 0xff, 0x2b, 0x81, 0xdd, 0x0d,
+	0x92, 0xbe, 0x9b, 0x62, 0x7e, 0x23, 0xb0, 0x57, 0x2e, 0x26, 0x53, 0xff, 0x9d, 0x54, 0x33, 0x82,
+	0xbb, 0x6b, 0x1d, 0xd5, 0x95, 0x98, 0x8e, 0x66, 0x17, 0x8b, 0x35, 0xf4, 0xf9, 0x80, 0x69, 0x3a,
+	0x12, 0xa1, 0xdd, 0x4f, 0x0d, 0xb6, 0x34, 0xfd, 0x67, 0xd0, 0xcb, 0xab, 0x30, 0x37, 0x54, 0xf9,
+	0xf3, 0x82, 0xac, 0x7d, 0x5e, 0xac, 0xdd, 0x3a, 0xce, 0x86, 0x5b, 0xe7, 0xc1, 0x27, 0x70, 0x77,
+	0xed, 0xa4, 0xa4, 0x7d, 0xe8, 0x5e, 0xc4, 0xf9, 0x91, 0xd6, 0xaf, 0xd1, 0x36, 0x34, 0x8f, 0xa7,
+	0x5c, 0x61, 0x9f, 0x3c, 0x78, 0x0e, 0x60, 0x5e, 0x45, 0x97, 0x25, 0x69, 0x0f, 0xe0, 0x22, 0xc6,
+	0x1f, 0x13, 0x0c, 0x94, 0x71, 0xec, 0x43, 0xf7, 0x09, 0xaa, 0xd5, 0xfb, 0xf6, 0x09, 0x75, 0x61,
+	0xaf, 0x32, 0x14, 0x76, 0xc5, 0xa1, 0x7d, 0xe8, 0x98, 0xc7, 0x6f, 0x2f, 0x2f, 0x25, 0xaa, 0xfe,
+	0x9b, 0xfa, 0xd1, 0x57, 0x7f, 0xdd, 0x0c, 0xc8, 0xf5, 0xcd, 0x80, 0xfc, 0x73, 0x33, 0x20, 0x6f,
+	0x6e, 0x07, 0xb5, 0xeb, 0xdb, 0x41, 0xed, 0xef, 0xdb, 0x41, 0xed, 0xf9, 0x47, 0x6f, 0xf9, 0x81,
+	0xf7, 0x62, 0xcb, 0xfc, 0x7d, 0xfa, 0xef, 0x00, 0xfb, 0xc4, 0x6b, 0xcf, 0x12, 0x0a, 0x00, 0x00,
 }
 
 func (m *RootChange) Marshal() (dAtA []byte, err error) {
@@ -936,6 +1207,18 @@ func (m *RootChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TreeKey != nil {
+		{
+			size, err := m.TreeKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTreechange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	if m.IsDerived {
 		i--
 		if m.IsDerived {
@@ -996,7 +1279,7 @@ func (m *RootChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *TreeChange) Marshal() (dAtA []byte, err error) {
+func (m *TreeKey) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1006,86 +1289,85 @@ func (m *TreeChange) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TreeChange) MarshalTo(dAtA []byte) (int, error) {
+func (m *TreeKey) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TreeChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *TreeKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.DataType) > 0 {
-		i -= len(m.DataType)
-		copy(dAtA[i:], m.DataType)
-		i = encodeVarintTreechange(dAtA, i, uint64(len(m.DataType)))
-		i--
-		dAtA[i] = 0x4a
-	}
-	if m.IsSnapshot {
-		i--
-		if m.IsSnapshot {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+	if len(m.EncryptedKey) > 0 {
+		i -= len(m.EncryptedKey)
+		copy(dAtA[i:], m.EncryptedKey)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.EncryptedKey)))
 		i--
-		dAtA[i] = 0x40
-	}
-	if len(m.Identity) > 0 {
-		i -= len(m.Identity)
-		copy(dAtA[i:], m.Identity)
-		i = encodeVarintTreechange(dAtA, i, uint64(len(m.Identity)))
-		i--
-		dAtA[i] = 0x3a
-	}
-	if m.Timestamp != 0 {
-		i = encodeVarintTreechange(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x30
+		dAtA[i] = 0x12
 	}
 	if len(m.ReadKeyId) > 0 {
 		i -= len(m.ReadKeyId)
 		copy(dAtA[i:], m.ReadKeyId)
 		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ReadKeyId)))
 		i--
-		dAtA[i] = 0x2a
+		dAtA[i] = 0xa
 	}
-	if len(m.ChangesData) > 0 {
-		i -= len(m.ChangesData)
-		copy(dAtA[i:], m.ChangesData)
-		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ChangesData)))
+	return len(dAtA) - i, nil
+}
+
+func (m *TreeKeyShare) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TreeKeyShare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TreeKeyShare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyId) > 0 {
+		i -= len(m.KeyId)
+		copy(dAtA[i:], m.KeyId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.KeyId)))
 		i--
 		dAtA[i] = 0x22
 	}
-	if len(m.SnapshotBaseId) > 0 {
-		i -= len(m.SnapshotBaseId)
-		copy(dAtA[i:], m.SnapshotBaseId)
-		i = encodeVarintTreechange(dAtA, i, uint64(len(m.SnapshotBaseId)))
+	if len(m.EncryptedKey) > 0 {
+		i -= len(m.EncryptedKey)
+		copy(dAtA[i:], m.EncryptedKey)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.EncryptedKey)))
 		i--
 		dAtA[i] = 0x1a
 	}
-	if len(m.AclHeadId) > 0 {
-		i -= len(m.AclHeadId)
-		copy(dAtA[i:], m.AclHeadId)
-		i = encodeVarintTreechange(dAtA, i, uint64(len(m.AclHeadId)))
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.Identity)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.TreeHeadIds) > 0 {
-		for iNdEx := len(m.TreeHeadIds) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.TreeHeadIds[iNdEx])
-			copy(dAtA[i:], m.TreeHeadIds[iNdEx])
-			i = encodeVarintTreechange(dAtA, i, uint64(len(m.TreeHeadIds[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+	if len(m.TreeId) > 0 {
+		i -= len(m.TreeId)
+		copy(dAtA[i:], m.TreeId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.TreeId)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RawTreeChange) Marshal() (dAtA []byte, err error) {
+func (m *TreeForkInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1095,7 +1377,164 @@ func (m *RawTreeChange) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RawTreeChange) MarshalTo(dAtA []byte) (int, error) {
+func (m *TreeForkInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TreeForkInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChangePayload) > 0 {
+		i -= len(m.ChangePayload)
+		copy(dAtA[i:], m.ChangePayload)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ChangePayload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SourceChangeId) > 0 {
+		i -= len(m.SourceChangeId)
+		copy(dAtA[i:], m.SourceChangeId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.SourceChangeId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SourceTreeId) > 0 {
+		i -= len(m.SourceTreeId)
+		copy(dAtA[i:], m.SourceTreeId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.SourceTreeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TreeChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TreeChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TreeChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CompressedChangesData) > 0 {
+		i -= len(m.CompressedChangesData)
+		copy(dAtA[i:], m.CompressedChangesData)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.CompressedChangesData)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.TreeKey != nil {
+		{
+			size, err := m.TreeKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTreechange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Compression != 0 {
+		i = encodeVarintTreechange(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.DataType) > 0 {
+		i -= len(m.DataType)
+		copy(dAtA[i:], m.DataType)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.DataType)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.IsSnapshot {
+		i--
+		if m.IsSnapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintTreechange(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.ReadKeyId) > 0 {
+		i -= len(m.ReadKeyId)
+		copy(dAtA[i:], m.ReadKeyId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ReadKeyId)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ChangesData) > 0 {
+		i -= len(m.ChangesData)
+		copy(dAtA[i:], m.ChangesData)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.ChangesData)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.SnapshotBaseId) > 0 {
+		i -= len(m.SnapshotBaseId)
+		copy(dAtA[i:], m.SnapshotBaseId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.SnapshotBaseId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.AclHeadId) > 0 {
+		i -= len(m.AclHeadId)
+		copy(dAtA[i:], m.AclHeadId)
+		i = encodeVarintTreechange(dAtA, i, uint64(len(m.AclHeadId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TreeHeadIds) > 0 {
+		for iNdEx := len(m.TreeHeadIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TreeHeadIds[iNdEx])
+			copy(dAtA[i:], m.TreeHeadIds[iNdEx])
+			i = encodeVarintTreechange(dAtA, i, uint64(len(m.TreeHeadIds[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RawTreeChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RawTreeChange) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
@@ -1606,6 +2045,73 @@ func (m *RootChange) Size() (n int) {
 	if m.IsDerived {
 		n += 2
 	}
+	if m.TreeKey != nil {
+		l = m.TreeKey.Size()
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	return n
+}
+
+func (m *TreeKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ReadKeyId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.EncryptedKey)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	return n
+}
+
+func (m *TreeKeyShare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TreeId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.EncryptedKey)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.KeyId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	return n
+}
+
+func (m *TreeForkInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SourceTreeId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.SourceChangeId)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.ChangePayload)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
 	return n
 }
 
@@ -1651,6 +2157,17 @@ func (m *TreeChange) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTreechange(uint64(l))
 	}
+	if m.Compression != 0 {
+		n += 1 + sovTreechange(uint64(m.Compression))
+	}
+	if m.TreeKey != nil {
+		l = m.TreeKey.Size()
+		n += 1 + l + sovTreechange(uint64(l))
+	}
+	l = len(m.CompressedChangesData)
+	if l > 0 {
+		n += 1 + l + sovTreechange(uint64(l))
+	}
 	return n
 }
 
@@ -2151,6 +2668,42 @@ func (m *RootChange) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.IsDerived = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TreeKey == nil {
+				m.TreeKey = &TreeKey{}
+			}
+			if err := m.TreeKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
@@ -2172,7 +2725,7 @@ func (m *RootChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TreeChange) Unmarshal(dAtA []byte) error {
+func (m *TreeKey) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2195,15 +2748,15 @@ func (m *TreeChange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TreeChange: wiretype end group for non-group")
+			return fmt.Errorf("proto: TreeKey: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TreeChange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TreeKey: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TreeHeadIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2231,13 +2784,13 @@ func (m *TreeChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TreeHeadIds = append(m.TreeHeadIds, string(dAtA[iNdEx:postIndex]))
+			m.ReadKeyId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AclHeadId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedKey", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTreechange
@@ -2247,31 +2800,477 @@ func (m *TreeChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTreechange
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTreechange
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AclHeadId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotBaseId", wireType)
+			m.EncryptedKey = append(m.EncryptedKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedKey == nil {
+				m.EncryptedKey = []byte{}
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTreechange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TreeKeyShare) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTreechange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TreeKeyShare: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TreeKeyShare: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TreeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedKey = append(m.EncryptedKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedKey == nil {
+				m.EncryptedKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTreechange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TreeForkInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTreechange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TreeForkInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TreeForkInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceTreeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceTreeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SourceChangeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SourceChangeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePayload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangePayload = append(m.ChangePayload[:0], dAtA[iNdEx:postIndex]...)
+			if m.ChangePayload == nil {
+				m.ChangePayload = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTreechange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TreeChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTreechange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TreeChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TreeChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeHeadIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TreeHeadIds = append(m.TreeHeadIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AclHeadId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AclHeadId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotBaseId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
 					return ErrIntOverflowTreechange
 				}
 				if iNdEx >= l {
@@ -2468,6 +3467,95 @@ func (m *TreeChange) Unmarshal(dAtA []byte) error {
 			}
 			m.DataType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= ChangeCompression(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TreeKey == nil {
+				m.TreeKey = &TreeKey{}
+			}
+			if err := m.TreeKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedChangesData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTreechange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTreechange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedChangesData = append(m.CompressedChangesData[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedChangesData == nil {
+				m.CompressedChangesData = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTreechange(dAtA[iNdEx:])
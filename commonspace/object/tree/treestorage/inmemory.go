@@ -15,6 +15,8 @@ type InMemoryTreeStorage struct {
 	Changes map[string]*treechangeproto.RawTreeChangeWithId
 	addErr  error
 
+	compactedSnapshotId string
+
 	sync.RWMutex
 }
 
@@ -110,6 +112,22 @@ func (t *InMemoryTreeStorage) GetRawChange(ctx context.Context, changeId string)
 	return nil, fmt.Errorf("could not get change with id: %s", changeId)
 }
 
+func (t *InMemoryTreeStorage) Compact(snapshotId string, removedIds []string) error {
+	t.Lock()
+	defer t.Unlock()
+	for _, id := range removedIds {
+		delete(t.Changes, id)
+	}
+	t.compactedSnapshotId = snapshotId
+	return nil
+}
+
+func (t *InMemoryTreeStorage) CompactedSnapshotId() (string, error) {
+	t.RLock()
+	defer t.RUnlock()
+	return t.compactedSnapshotId, nil
+}
+
 func (t *InMemoryTreeStorage) Delete() error {
 	return nil
 }
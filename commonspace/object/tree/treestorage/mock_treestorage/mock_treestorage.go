@@ -5,6 +5,7 @@
 //
 //	mockgen -destination mock_treestorage/mock_treestorage.go github.com/anyproto/any-sync/commonspace/object/tree/treestorage TreeStorage
 //
+
 // Package mock_treestorage is a generated GoMock package.
 package mock_treestorage
 
@@ -67,6 +68,35 @@ func (mr *MockTreeStorageMockRecorder) AddRawChangesSetHeads(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRawChangesSetHeads", reflect.TypeOf((*MockTreeStorage)(nil).AddRawChangesSetHeads), arg0, arg1)
 }
 
+// Compact mocks base method.
+func (m *MockTreeStorage) Compact(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockTreeStorageMockRecorder) Compact(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockTreeStorage)(nil).Compact), arg0, arg1)
+}
+
+// CompactedSnapshotId mocks base method.
+func (m *MockTreeStorage) CompactedSnapshotId() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactedSnapshotId")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompactedSnapshotId indicates an expected call of CompactedSnapshotId.
+func (mr *MockTreeStorageMockRecorder) CompactedSnapshotId() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactedSnapshotId", reflect.TypeOf((*MockTreeStorage)(nil).CompactedSnapshotId))
+}
+
 // Delete mocks base method.
 func (m *MockTreeStorage) Delete() error {
 	m.ctrl.T.Helper()
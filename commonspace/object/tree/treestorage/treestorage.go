@@ -35,5 +35,10 @@ type TreeStorage interface {
 
 	GetRawChange(ctx context.Context, id string) (*treechangeproto.RawTreeChangeWithId, error)
 	HasChange(ctx context.Context, id string) (bool, error)
+	// Compact removes the changes preceding the snapshot, the snapshot becomes the oldest one
+	// from which the tree can be fully loaded
+	Compact(snapshotId string, removedIds []string) error
+	// CompactedSnapshotId returns the snapshot passed to the last Compact call or empty string if the tree was never compacted
+	CompactedSnapshotId() (string, error)
 	Delete() error
 }
@@ -5,6 +5,7 @@
 //
 //	mockgen -destination mock_objecttreebuilder/mock_objecttreebuilder.go github.com/anyproto/any-sync/commonspace/objecttreebuilder TreeBuilder
 //
+
 // Package mock_objecttreebuilder is a generated GoMock package.
 package mock_objecttreebuilder
 
@@ -102,6 +103,21 @@ func (mr *MockTreeBuilderMockRecorder) DeriveTree(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeriveTree", reflect.TypeOf((*MockTreeBuilder)(nil).DeriveTree), arg0, arg1)
 }
 
+// ForkTree mocks base method.
+func (m *MockTreeBuilder) ForkTree(arg0 context.Context, arg1 string, arg2 objecttree.ObjectTreeForkPayload) (treestorage.TreeStorageCreatePayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkTree", arg0, arg1, arg2)
+	ret0, _ := ret[0].(treestorage.TreeStorageCreatePayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkTree indicates an expected call of ForkTree.
+func (mr *MockTreeBuilderMockRecorder) ForkTree(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkTree", reflect.TypeOf((*MockTreeBuilder)(nil).ForkTree), arg0, arg1, arg2)
+}
+
 // PutTree mocks base method.
 func (m *MockTreeBuilder) PutTree(arg0 context.Context, arg1 treestorage.TreeStorageCreatePayload, arg2 updatelistener.UpdateListener) (objecttree.ObjectTree, error) {
 	m.ctrl.T.Helper()
@@ -116,3 +132,18 @@ func (mr *MockTreeBuilderMockRecorder) PutTree(arg0, arg1, arg2 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTree", reflect.TypeOf((*MockTreeBuilder)(nil).PutTree), arg0, arg1, arg2)
 }
+
+// PutTreeWithOpts mocks base method.
+func (m *MockTreeBuilder) PutTreeWithOpts(arg0 context.Context, arg1 treestorage.TreeStorageCreatePayload, arg2 objecttreebuilder.BuildTreeOpts) (objecttree.ObjectTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTreeWithOpts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(objecttree.ObjectTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutTreeWithOpts indicates an expected call of PutTreeWithOpts.
+func (mr *MockTreeBuilderMockRecorder) PutTreeWithOpts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTreeWithOpts", reflect.TypeOf((*MockTreeBuilder)(nil).PutTreeWithOpts), arg0, arg1, arg2)
+}
@@ -29,6 +29,12 @@ import (
 type BuildTreeOpts struct {
 	Listener    updatelistener.UpdateListener
 	TreeBuilder objecttree.BuildObjectTreeFunc
+	// Ordering defines the order of the concurrent changes for the type of the tree, by default they are ordered by id
+	Ordering objecttree.ChangeOrdering
+	// ContentValidators check the content of the changes before they are added to the tree
+	ContentValidators *objecttree.ContentValidators
+	// InvalidContentReporter is notified when the peer sends the changes with invalid content
+	InvalidContentReporter synctree.InvalidContentReporter
 }
 
 const CName = "common.commonspace.objecttreebuilder"
@@ -40,6 +46,10 @@ type HistoryTreeOpts struct {
 	BeforeId      string
 	Include       bool
 	BuildFullTree bool
+	// BuildFromRoot builds the tree from the root ending with BeforeId
+	BuildFromRoot bool
+	// Ordering defines the order of the concurrent changes, it should be the same as in BuildTreeOpts of the tree
+	Ordering objecttree.ChangeOrdering
 }
 
 type TreeBuilder interface {
@@ -47,7 +57,10 @@ type TreeBuilder interface {
 	BuildHistoryTree(ctx context.Context, id string, opts HistoryTreeOpts) (t objecttree.HistoryTree, err error)
 	CreateTree(ctx context.Context, payload objecttree.ObjectTreeCreatePayload) (res treestorage.TreeStorageCreatePayload, err error)
 	DeriveTree(ctx context.Context, payload objecttree.ObjectTreeDerivePayload) (res treestorage.TreeStorageCreatePayload, err error)
+	ForkTree(ctx context.Context, id string, payload objecttree.ObjectTreeForkPayload) (res treestorage.TreeStorageCreatePayload, err error)
 	PutTree(ctx context.Context, payload treestorage.TreeStorageCreatePayload, listener updatelistener.UpdateListener) (t objecttree.ObjectTree, err error)
+	// PutTreeWithOpts puts the tree built with the options, the content of the changes is validated before the tree is saved
+	PutTreeWithOpts(ctx context.Context, payload treestorage.TreeStorageCreatePayload, opts BuildTreeOpts) (t objecttree.ObjectTree, err error)
 }
 
 type TreeBuilderComponent interface {
@@ -71,6 +84,8 @@ type treeBuilder struct {
 
 	log       logger.CtxLogger
 	builder   objecttree.BuildObjectTreeFunc
+	limits    objecttree.TreeLimits
+	counter   synctree.ChangeCounter
 	spaceId   string
 	aclList   list.AclList
 	treesUsed *atomic.Int32
@@ -83,6 +98,10 @@ func (t *treeBuilder) Init(a *app.App) (err error) {
 	t.isClosed = state.SpaceIsClosed
 	t.treesUsed = state.TreesUsed
 	t.builder = state.TreeBuilderFunc
+	t.limits = state.TreeLimits
+	if state.ChangeCounter != nil {
+		t.counter = state.ChangeCounter
+	}
 	t.aclList = a.MustComponent(syncacl.CName).(syncacl.SyncAcl)
 	t.spaceStorage = a.MustComponent(spacestorage.CName).(spacestorage.SpaceStorage)
 	t.configuration = a.MustComponent(nodeconf.CName).(nodeconf.NodeConf)
@@ -106,23 +125,7 @@ func (t *treeBuilder) BuildTree(ctx context.Context, id string, opts BuildTreeOp
 		err = ErrSpaceClosed
 		return
 	}
-	treeBuilder := opts.TreeBuilder
-	if treeBuilder == nil {
-		treeBuilder = t.builder
-	}
-	deps := synctree.BuildDeps{
-		SpaceId:         t.spaceId,
-		SyncClient:      t.syncClient,
-		Configuration:   t.configuration,
-		HeadNotifiable:  t.headsNotifiable,
-		Listener:        opts.Listener,
-		AclList:         t.aclList,
-		SpaceStorage:    t.spaceStorage,
-		OnClose:         t.onClose,
-		SyncStatus:      t.syncStatus,
-		PeerGetter:      t.peerManager,
-		BuildObjectTree: treeBuilder,
-	}
+	deps := t.buildDeps(opts)
 	t.treesUsed.Add(1)
 	t.log.Debug("incrementing counter", zap.String("id", id), zap.Int32("trees", t.treesUsed.Load()))
 	if ot, err = synctree.BuildSyncTreeOrGetRemote(ctx, id, deps); err != nil {
@@ -133,6 +136,39 @@ func (t *treeBuilder) BuildTree(ctx context.Context, id string, opts BuildTreeOp
 	return
 }
 
+// buildDeps returns the dependencies of the sync tree built with the options
+func (t *treeBuilder) buildDeps(opts BuildTreeOpts) synctree.BuildDeps {
+	treeBuilder := opts.TreeBuilder
+	if treeBuilder == nil {
+		treeBuilder = t.builder
+	}
+	if opts.Ordering != nil {
+		treeBuilder = objecttree.WithOrdering(treeBuilder, opts.Ordering)
+	}
+	if opts.ContentValidators != nil {
+		treeBuilder = objecttree.WithContentValidators(treeBuilder, opts.ContentValidators)
+	}
+	if !t.limits.IsEmpty() {
+		treeBuilder = objecttree.WithLimits(treeBuilder, t.limits)
+	}
+	return synctree.BuildDeps{
+		SpaceId:                t.spaceId,
+		SyncClient:             t.syncClient,
+		Configuration:          t.configuration,
+		HeadNotifiable:         t.headsNotifiable,
+		Listener:               opts.Listener,
+		AclList:                t.aclList,
+		SpaceStorage:           t.spaceStorage,
+		OnClose:                t.onClose,
+		SyncStatus:             t.syncStatus,
+		PeerGetter:             t.peerManager,
+		BuildObjectTree:        treeBuilder,
+		InvalidContentReporter: opts.InvalidContentReporter,
+		Limits:                 t.limits,
+		ChangeCounter:          t.counter,
+	}
+}
+
 func (t *treeBuilder) BuildHistoryTree(ctx context.Context, id string, opts HistoryTreeOpts) (ot objecttree.HistoryTree, err error) {
 	if t.isClosed.Load() {
 		// TODO: change to real error
@@ -145,6 +181,8 @@ func (t *treeBuilder) BuildHistoryTree(ctx context.Context, id string, opts Hist
 		BeforeId:        opts.BeforeId,
 		IncludeBeforeId: opts.Include,
 		BuildFullTree:   opts.BuildFullTree,
+		BuildFromRoot:   opts.BuildFromRoot,
+		Ordering:        opts.Ordering,
 	}
 	params.TreeStorage, err = t.spaceStorage.TreeStorage(id)
 	if err != nil {
@@ -189,23 +227,44 @@ func (t *treeBuilder) DeriveTree(ctx context.Context, payload objecttree.ObjectT
 	return
 }
 
+// ForkTree creates the new tree with the state of the tree at the change from the payload,
+// the result should be put to the space with PutTree the same way as the result of CreateTree
+func (t *treeBuilder) ForkTree(ctx context.Context, id string, payload objecttree.ObjectTreeForkPayload) (res treestorage.TreeStorageCreatePayload, err error) {
+	version, err := t.BuildHistoryTree(ctx, id, HistoryTreeOpts{
+		BeforeId:      payload.ChangeId,
+		Include:       true,
+		BuildFromRoot: payload.WithHistory,
+		Ordering:      payload.Ordering,
+	})
+	if err != nil {
+		return
+	}
+	createPayload, err := objecttree.ForkCreatePayload(id, payload)
+	if err != nil {
+		return
+	}
+	created, err := t.CreateTree(ctx, createPayload)
+	if err != nil {
+		return
+	}
+	return objecttree.ForkObjectTree(version, created.RootRawChange, payload, t.aclList)
+}
+
 func (t *treeBuilder) PutTree(ctx context.Context, payload treestorage.TreeStorageCreatePayload, listener updatelistener.UpdateListener) (ot objecttree.ObjectTree, err error) {
+	return t.PutTreeWithOpts(ctx, payload, BuildTreeOpts{Listener: listener})
+}
+
+func (t *treeBuilder) PutTreeWithOpts(ctx context.Context, payload treestorage.TreeStorageCreatePayload, opts BuildTreeOpts) (ot objecttree.ObjectTree, err error) {
 	if t.isClosed.Load() {
 		err = ErrSpaceClosed
 		return
 	}
-	deps := synctree.BuildDeps{
-		SpaceId:         t.spaceId,
-		SyncClient:      t.syncClient,
-		Configuration:   t.configuration,
-		HeadNotifiable:  t.headsNotifiable,
-		Listener:        listener,
-		AclList:         t.aclList,
-		SpaceStorage:    t.spaceStorage,
-		OnClose:         t.onClose,
-		SyncStatus:      t.syncStatus,
-		PeerGetter:      t.peerManager,
-		BuildObjectTree: t.builder,
+	deps := t.buildDeps(opts)
+	// the content is validated before the tree is saved, the root is not validated, so only the changes after it are checked
+	if opts.ContentValidators != nil && len(payload.Changes) > 1 {
+		if err = objecttree.ValidateRawTreeBuildFunc(payload, deps.BuildObjectTree, t.aclList); err != nil {
+			return
+		}
 	}
 	ot, err = synctree.PutSyncTree(ctx, payload, deps)
 	if err != nil {
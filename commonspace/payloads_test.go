@@ -438,7 +438,7 @@ func TestSuccessSameIds(t *testing.T) {
 		SpaceHeaderWithId:   rawHeaderWithId,
 		SpaceSettingsWithId: rawSettingsPayload,
 	}
-	err = validateSpaceStorageCreatePayload(spacePayload)
+	err = ValidateSpaceStorageCreatePayload(spacePayload)
 	require.NoError(t, err)
 }
 
@@ -455,7 +455,7 @@ func TestFailWithAclWrongSpaceId(t *testing.T) {
 		SpaceHeaderWithId:   rawHeaderWithId,
 		SpaceSettingsWithId: rawSettingsPayload,
 	}
-	err = validateSpaceStorageCreatePayload(spacePayload)
+	err = ValidateSpaceStorageCreatePayload(spacePayload)
 	assert.EqualErrorf(t, err, spacestorage.ErrIncorrectSpaceHeader.Error(), "Error should be: %v, got: %v", spacestorage.ErrIncorrectSpaceHeader, err)
 }
 
@@ -472,7 +472,7 @@ func TestFailWithSettingsWrongSpaceId(t *testing.T) {
 		SpaceHeaderWithId:   rawHeaderWithId,
 		SpaceSettingsWithId: rawSettingsPayload,
 	}
-	err = validateSpaceStorageCreatePayload(spacePayload)
+	err = ValidateSpaceStorageCreatePayload(spacePayload)
 	assert.EqualErrorf(t, err, spacestorage.ErrIncorrectSpaceHeader.Error(), "Error should be: %v, got: %v", spacestorage.ErrIncorrectSpaceHeader, err)
 }
 
@@ -489,7 +489,7 @@ func TestFailWithWrongAclHeadIdInSettingsPayload(t *testing.T) {
 		SpaceHeaderWithId:   rawHeaderWithId,
 		SpaceSettingsWithId: rawSettingsPayload,
 	}
-	err = validateSpaceStorageCreatePayload(spacePayload)
+	err = ValidateSpaceStorageCreatePayload(spacePayload)
 	assert.EqualErrorf(t, err, spacestorage.ErrIncorrectSpaceHeader.Error(), "Error should be: %v, got: %v", spacestorage.ErrIncorrectSpaceHeader, err)
 }
 
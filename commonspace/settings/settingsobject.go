@@ -9,6 +9,8 @@ import (
 
 	"github.com/anyproto/any-sync/accountservice"
 	"github.com/anyproto/any-sync/app/logger"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree/updatelistener"
@@ -28,6 +30,8 @@ type SettingsObject interface {
 	synctree.SyncTree
 	Init(ctx context.Context) (err error)
 	DeleteObject(id string) (err error)
+	SetReadKeyRotationPolicy(policy *spacesyncproto.ReadKeyRotationPolicy) (err error)
+	ReadKeyRotationPolicy() *spacesyncproto.ReadKeyRotationPolicy
 }
 
 var (
@@ -36,6 +40,7 @@ var (
 	ErrObjDoesNotExist         = errors.New("the object does not exist")
 	ErrCantDeleteDerivedObject = errors.New("can't delete derived object")
 	ErrCantDeleteSpace         = errors.New("not able to delete space")
+	ErrIncorrectPolicy         = errors.New("incorrect read key rotation policy")
 )
 
 var (
@@ -204,6 +209,40 @@ func (s *settingsObject) DeleteObject(id string) (err error) {
 	return s.addContent(res, isSnapshot)
 }
 
+func (s *settingsObject) SetReadKeyRotationPolicy(policy *spacesyncproto.ReadKeyRotationPolicy) (err error) {
+	if policy == nil || policy.IntervalSec < 0 {
+		return ErrIncorrectPolicy
+	}
+	if !s.canChangeReadKey() {
+		return list.ErrInsufficientPermissions
+	}
+	s.Lock()
+	defer s.Unlock()
+	isSnapshot := DoSnapshot(s.Len())
+	res, err := s.changeFactory.CreateReadKeyRotationPolicyChange(policy, s.state, isSnapshot)
+	if err != nil {
+		return
+	}
+	return s.addContent(res, isSnapshot)
+}
+
+func (s *settingsObject) ReadKeyRotationPolicy() *spacesyncproto.ReadKeyRotationPolicy {
+	s.RLock()
+	defer s.RUnlock()
+	if s.state == nil {
+		return nil
+	}
+	return s.state.ReadKeyRotationPolicy
+}
+
+func (s *settingsObject) canChangeReadKey() bool {
+	aclList := s.AclList()
+	aclList.RLock()
+	defer aclList.RUnlock()
+	identity := s.account.Account().SignKey.GetPublic()
+	return aclList.AclState().Capabilities(identity).Has(aclrecordproto.AclCapability_CapabilityChangeReadKey)
+}
+
 func (s *settingsObject) addContent(data []byte, isSnapshot bool) (err error) {
 	accountData := s.account.Account()
 	res, err := s.AddContent(context.Background(), objecttree.SignableChangeContent{
@@ -5,6 +5,7 @@ import (
 	"github.com/anyproto/any-sync/accountservice/mock_accountservice"
 	"github.com/anyproto/any-sync/commonspace/deletionmanager/mock_deletionmanager"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree/mock_objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/synctree"
@@ -16,6 +17,7 @@ import (
 	"github.com/anyproto/any-sync/commonspace/settings/settingsstate"
 	"github.com/anyproto/any-sync/commonspace/settings/settingsstate/mock_settingsstate"
 	"github.com/anyproto/any-sync/commonspace/spacestorage/mock_spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"sync"
@@ -205,6 +207,49 @@ func TestSettingsObject_DeleteObject_WithSnapshot(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSettingsObject_SetReadKeyRotationPolicy(t *testing.T) {
+	fx := newSettingsFixture(t)
+	defer fx.stop(t)
+	fx.init(t)
+	DoSnapshot = func(len int) bool {
+		return false
+	}
+
+	accountData, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	aclList, err := list.NewTestDerivedAcl(fx.spaceId, accountData)
+	require.NoError(t, err)
+	policy := &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60}
+	res := []byte("settingsData")
+	fx.doc.state = &settingsstate.State{LastIteratedId: "someId"}
+
+	fx.syncTree.EXPECT().AclList().Return(aclList)
+	fx.syncTree.EXPECT().Len().Return(10)
+	fx.account.EXPECT().Account().Return(accountData).Times(2)
+	fx.changeFactory.EXPECT().CreateReadKeyRotationPolicyChange(policy, fx.doc.state, false).Return(res, nil)
+	fx.syncTree.EXPECT().AddContent(gomock.Any(), objecttree.SignableChangeContent{
+		Data:        res,
+		Key:         accountData.SignKey,
+		IsSnapshot:  false,
+		IsEncrypted: false,
+	}).Return(objecttree.AddResult{}, nil)
+	fx.stateBuilder.EXPECT().Build(fx.doc, fx.doc.state).Return(fx.doc.state, nil)
+	fx.deletionManager.EXPECT().UpdateState(gomock.Any(), fx.doc.state).Return(nil)
+	err = fx.doc.SetReadKeyRotationPolicy(policy)
+	require.NoError(t, err)
+
+	// only the accounts which can change the read key can set the policy
+	otherData, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	fx.syncTree.EXPECT().AclList().Return(aclList)
+	fx.account.EXPECT().Account().Return(otherData)
+	err = fx.doc.SetReadKeyRotationPolicy(policy)
+	require.Equal(t, list.ErrInsufficientPermissions, err)
+
+	err = fx.doc.SetReadKeyRotationPolicy(&spacesyncproto.ReadKeyRotationPolicy{IntervalSec: -1})
+	require.Equal(t, ErrIncorrectPolicy, err)
+}
+
 func TestSettingsObject_DeleteDerivedObject(t *testing.T) {
 	isDerivedRoot = func(root *treechangeproto.RawTreeChangeWithId) (derived bool, err error) {
 		return true, nil
@@ -6,6 +6,7 @@ import (
 
 type ChangeFactory interface {
 	CreateObjectDeleteChange(id string, state *State, isSnapshot bool) (res []byte, err error)
+	CreateReadKeyRotationPolicyChange(policy *spacesyncproto.ReadKeyRotationPolicy, state *State, isSnapshot bool) (res []byte, err error)
 }
 
 func NewChangeFactory() ChangeFactory {
@@ -25,13 +26,29 @@ func (c *changeFactory) CreateObjectDeleteChange(id string, state *State, isSnap
 		},
 	}
 	if isSnapshot {
-		change.Snapshot = c.makeSnapshot(state, id)
+		change.Snapshot = c.makeSnapshot(state, id, state.ReadKeyRotationPolicy)
 	}
 	res, err = change.Marshal()
 	return
 }
 
-func (c *changeFactory) makeSnapshot(state *State, objectId string) *spacesyncproto.SpaceSettingsSnapshot {
+func (c *changeFactory) CreateReadKeyRotationPolicyChange(policy *spacesyncproto.ReadKeyRotationPolicy, state *State, isSnapshot bool) (res []byte, err error) {
+	content := &spacesyncproto.SpaceSettingsContent_ReadKeyRotationPolicy{
+		ReadKeyRotationPolicy: policy,
+	}
+	change := &spacesyncproto.SettingsData{
+		Content: []*spacesyncproto.SpaceSettingsContent{
+			{Value: content},
+		},
+	}
+	if isSnapshot {
+		change.Snapshot = c.makeSnapshot(state, "", policy)
+	}
+	res, err = change.Marshal()
+	return
+}
+
+func (c *changeFactory) makeSnapshot(state *State, objectId string, policy *spacesyncproto.ReadKeyRotationPolicy) *spacesyncproto.SpaceSettingsSnapshot {
 	var (
 		deletedIds = make([]string, 0, len(state.DeletedIds)+1)
 	)
@@ -42,6 +59,7 @@ func (c *changeFactory) makeSnapshot(state *State, objectId string) *spacesyncpr
 		deletedIds = append(deletedIds, id)
 	}
 	return &spacesyncproto.SpaceSettingsSnapshot{
-		DeletedIds: deletedIds,
+		DeletedIds:            deletedIds,
+		ReadKeyRotationPolicy: policy,
 	}
 }
@@ -32,3 +32,22 @@ func TestChangeFactory_CreateObjectDeleteChange(t *testing.T) {
 	}, data.Snapshot)
 	require.Equal(t, "3", data.Content[0].Value.(*spacesyncproto.SpaceSettingsContent_ObjectDelete).ObjectDelete.Id)
 }
+
+func TestChangeFactory_CreateReadKeyRotationPolicyChange(t *testing.T) {
+	factory := NewChangeFactory()
+	state := &State{
+		DeletedIds:            map[string]struct{}{"1": {}},
+		ReadKeyRotationPolicy: &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60},
+	}
+	policy := &spacesyncproto.ReadKeyRotationPolicy{MaxAclRecords: 10}
+	marshalled, err := factory.CreateReadKeyRotationPolicyChange(policy, state, true)
+	require.NoError(t, err)
+	data := &spacesyncproto.SettingsData{}
+	err = proto.Unmarshal(marshalled, data)
+	require.NoError(t, err)
+	require.Equal(t, &spacesyncproto.SpaceSettingsSnapshot{
+		DeletedIds:            []string{"1"},
+		ReadKeyRotationPolicy: policy,
+	}, data.Snapshot)
+	require.Equal(t, policy, data.Content[0].GetReadKeyRotationPolicy())
+}
@@ -5,6 +5,7 @@
 //
 //	mockgen -destination mock_settingsstate/mock_settingsstate.go github.com/anyproto/any-sync/commonspace/settings/settingsstate StateBuilder,ChangeFactory
 //
+
 // Package mock_settingsstate is a generated GoMock package.
 package mock_settingsstate
 
@@ -13,6 +14,7 @@ import (
 
 	objecttree "github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	settingsstate "github.com/anyproto/any-sync/commonspace/settings/settingsstate"
+	spacesyncproto "github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -91,3 +93,18 @@ func (mr *MockChangeFactoryMockRecorder) CreateObjectDeleteChange(arg0, arg1, ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObjectDeleteChange", reflect.TypeOf((*MockChangeFactory)(nil).CreateObjectDeleteChange), arg0, arg1, arg2)
 }
+
+// CreateReadKeyRotationPolicyChange mocks base method.
+func (m *MockChangeFactory) CreateReadKeyRotationPolicyChange(arg0 *spacesyncproto.ReadKeyRotationPolicy, arg1 *settingsstate.State, arg2 bool) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReadKeyRotationPolicyChange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReadKeyRotationPolicyChange indicates an expected call of CreateReadKeyRotationPolicyChange.
+func (mr *MockChangeFactoryMockRecorder) CreateReadKeyRotationPolicyChange(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReadKeyRotationPolicyChange", reflect.TypeOf((*MockChangeFactory)(nil).CreateReadKeyRotationPolicyChange), arg0, arg1, arg2)
+}
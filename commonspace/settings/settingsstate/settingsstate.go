@@ -6,6 +6,8 @@ import "github.com/anyproto/any-sync/commonspace/spacesyncproto"
 type State struct {
 	DeletedIds     map[string]struct{}
 	LastIteratedId string
+	// ReadKeyRotationPolicy is the last policy set by the account which can change the read key, nil if it was not set
+	ReadKeyRotationPolicy *spacesyncproto.ReadKeyRotationPolicy
 }
 
 func NewState() *State {
@@ -17,6 +19,7 @@ func NewStateFromSnapshot(snapshot *spacesyncproto.SpaceSettingsSnapshot, lastIt
 	for _, id := range snapshot.DeletedIds {
 		st.DeletedIds[id] = struct{}{}
 	}
+	st.ReadKeyRotationPolicy = snapshot.ReadKeyRotationPolicy
 	st.LastIteratedId = lastIteratedId
 	return st
 }
@@ -1,6 +1,8 @@
 package settingsstate
 
 import (
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
 	"github.com/gogo/protobuf/proto"
@@ -30,7 +32,7 @@ func (s *stateBuilder) Build(tr objecttree.ReadableObjectTree, oldState *State)
 	}
 
 	process := func(change *objecttree.Change) bool {
-		state = s.processChange(change, rootId, state)
+		state = s.processChange(change, rootId, state, tr.AclList())
 		state.LastIteratedId = change.Id
 		return true
 	}
@@ -46,7 +48,7 @@ func (s *stateBuilder) Build(tr objecttree.ReadableObjectTree, oldState *State)
 	return
 }
 
-func (s *stateBuilder) processChange(change *objecttree.Change, rootId string, state *State) *State {
+func (s *stateBuilder) processChange(change *objecttree.Change, rootId string, state *State, aclList list.AclList) *State {
 	// ignoring root change which has empty model or startId change
 	if len(change.PreviousIds) == 0 || state.LastIteratedId == change.Id {
 		return state
@@ -64,7 +66,25 @@ func (s *stateBuilder) processChange(change *objecttree.Change, rootId string, s
 		switch {
 		case cnt.GetObjectDelete() != nil:
 			state.DeletedIds[cnt.GetObjectDelete().GetId()] = struct{}{}
+		case cnt.GetReadKeyRotationPolicy() != nil:
+			if canChangeReadKey(aclList, change) {
+				state.ReadKeyRotationPolicy = cnt.GetReadKeyRotationPolicy()
+			}
 		}
 	}
 	return state
 }
+
+// canChangeReadKey checks that the author of the change could change the read key at the time of the change
+func canChangeReadKey(aclList list.AclList, change *objecttree.Change) bool {
+	if aclList == nil {
+		return false
+	}
+	aclList.RLock()
+	defer aclList.RUnlock()
+	accState, err := aclList.AclState().StateAtRecord(change.AclHeadId, change.Identity)
+	if err != nil {
+		return false
+	}
+	return accState.Capabilities.Has(aclrecordproto.AclCapability_CapabilityChangeReadKey)
+}
@@ -3,9 +3,11 @@ package settingsstate
 import (
 	"fmt"
 	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree/mock_objecttree"
 	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/util/crypto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
@@ -20,7 +22,7 @@ func TestStateBuilder_ProcessChange(t *testing.T) {
 		ch := &objecttree.Change{}
 		newSt := sb.processChange(ch, rootId, &State{
 			DeletedIds: map[string]struct{}{deletedId: struct{}{}},
-		})
+		}, nil)
 		require.Equal(t, map[string]struct{}{deletedId: struct{}{}}, newSt.DeletedIds)
 	})
 
@@ -40,7 +42,7 @@ func TestStateBuilder_ProcessChange(t *testing.T) {
 		ch.Id = "someId"
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		newSt := sb.processChange(ch, rootId, NewState())
+		newSt := sb.processChange(ch, rootId, NewState(), nil)
 		fmt.Println(newSt)
 	})
 
@@ -58,7 +60,7 @@ func TestStateBuilder_ProcessChange(t *testing.T) {
 		newSt := sb.processChange(ch, rootId, &State{
 			DeletedIds:     map[string]struct{}{deletedId: struct{}{}},
 			LastIteratedId: startId,
-		})
+		}, nil)
 		require.Equal(t, map[string]struct{}{deletedId: struct{}{}}, newSt.DeletedIds)
 	})
 
@@ -71,7 +73,7 @@ func TestStateBuilder_ProcessChange(t *testing.T) {
 			},
 		}
 		ch.Id = "rootId"
-		newSt := sb.processChange(ch, rootId, NewState())
+		newSt := sb.processChange(ch, rootId, NewState(), nil)
 		require.Equal(t, map[string]struct{}{"id1": struct{}{}, "id2": struct{}{}}, newSt.DeletedIds)
 	})
 
@@ -86,9 +88,37 @@ func TestStateBuilder_ProcessChange(t *testing.T) {
 			},
 		}
 		ch.Id = "someId"
-		newSt := sb.processChange(ch, rootId, NewState())
+		newSt := sb.processChange(ch, rootId, NewState(), nil)
 		require.Equal(t, map[string]struct{}{deletedId: struct{}{}}, newSt.DeletedIds)
 	})
+
+	t.Run("read key rotation policy", func(t *testing.T) {
+		ownerKeys, _ := accountdata.NewRandom()
+		otherKeys, _ := accountdata.NewRandom()
+		aclList, err := list.NewTestDerivedAcl("spaceId", ownerKeys)
+		require.NoError(t, err)
+		policy := &spacesyncproto.ReadKeyRotationPolicy{IntervalSec: 60, MaxAclRecords: 10}
+		newChange := func(identity crypto.PubKey) *objecttree.Change {
+			ch := &objecttree.Change{
+				Id:          "someId",
+				PreviousIds: []string{"prevId"},
+				AclHeadId:   aclList.Head().Id,
+				Identity:    identity,
+			}
+			ch.Model = &spacesyncproto.SettingsData{
+				Content: []*spacesyncproto.SpaceSettingsContent{
+					{Value: &spacesyncproto.SpaceSettingsContent_ReadKeyRotationPolicy{
+						ReadKeyRotationPolicy: policy,
+					}},
+				},
+			}
+			return ch
+		}
+		newSt := sb.processChange(newChange(otherKeys.SignKey.GetPublic()), rootId, NewState(), aclList)
+		require.Nil(t, newSt.ReadKeyRotationPolicy)
+		newSt = sb.processChange(newChange(ownerKeys.SignKey.GetPublic()), rootId, NewState(), aclList)
+		require.Equal(t, policy, newSt.ReadKeyRotationPolicy)
+	})
 }
 
 func TestStateBuilder_Build(t *testing.T) {
@@ -0,0 +1,1186 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: commonspace/spacearchive/archiveproto/protos/archive.proto
+
+package archiveproto
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ArchiveEntryType is a type of the archive entry
+type ArchiveEntryType int32
+
+const (
+	ArchiveEntryType_SpaceHeader ArchiveEntryType = 0
+	ArchiveEntryType_AclRecord   ArchiveEntryType = 1
+	ArchiveEntryType_TreeChange  ArchiveEntryType = 2
+	ArchiveEntryType_TreeHeads   ArchiveEntryType = 3
+)
+
+var ArchiveEntryType_name = map[int32]string{
+	0: "SpaceHeader",
+	1: "AclRecord",
+	2: "TreeChange",
+	3: "TreeHeads",
+}
+
+var ArchiveEntryType_value = map[string]int32{
+	"SpaceHeader": 0,
+	"AclRecord":   1,
+	"TreeChange":  2,
+	"TreeHeads":   3,
+}
+
+func (x ArchiveEntryType) String() string {
+	return proto.EnumName(ArchiveEntryType_name, int32(x))
+}
+
+func (ArchiveEntryType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2faf3254f109f75e, []int{0}
+}
+
+// ArchiveHeader is the first entry of the space archive
+type ArchiveHeader struct {
+	// Version is the version of the archive format
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// SpaceId is an id of the archived space
+	SpaceId string `protobuf:"bytes,2,opt,name=spaceId,proto3" json:"spaceId,omitempty"`
+	// Identity is the identity of the account which exported and signed the archive
+	Identity []byte `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Timestamp is the time of the export
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// IsDecrypted tells if the entries contain the decrypted data of the changes
+	IsDecrypted bool `protobuf:"varint,5,opt,name=isDecrypted,proto3" json:"isDecrypted,omitempty"`
+	// SpaceSettingsId is an id of the settings tree of the space
+	SpaceSettingsId string `protobuf:"bytes,6,opt,name=spaceSettingsId,proto3" json:"spaceSettingsId,omitempty"`
+}
+
+func (m *ArchiveHeader) Reset()         { *m = ArchiveHeader{} }
+func (m *ArchiveHeader) String() string { return proto.CompactTextString(m) }
+func (*ArchiveHeader) ProtoMessage()    {}
+func (*ArchiveHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2faf3254f109f75e, []int{0}
+}
+func (m *ArchiveHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ArchiveHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ArchiveHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ArchiveHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveHeader.Merge(m, src)
+}
+func (m *ArchiveHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *ArchiveHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveHeader proto.InternalMessageInfo
+
+func (m *ArchiveHeader) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ArchiveHeader) GetSpaceId() string {
+	if m != nil {
+		return m.SpaceId
+	}
+	return ""
+}
+
+func (m *ArchiveHeader) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *ArchiveHeader) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ArchiveHeader) GetIsDecrypted() bool {
+	if m != nil {
+		return m.IsDecrypted
+	}
+	return false
+}
+
+func (m *ArchiveHeader) GetSpaceSettingsId() string {
+	if m != nil {
+		return m.SpaceSettingsId
+	}
+	return ""
+}
+
+// ArchiveEntry is an entry of the space archive following the header
+type ArchiveEntry struct {
+	// Type is a type of the payload
+	Type ArchiveEntryType `protobuf:"varint,1,opt,name=type,proto3,enum=archive.ArchiveEntryType" json:"type,omitempty"`
+	// TreeId is an id of the tree for the tree entries
+	TreeId string `protobuf:"bytes,2,opt,name=treeId,proto3" json:"treeId,omitempty"`
+	// Payload is the marshalled raw space header, acl record or tree change
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// DecryptedData is the decrypted data of the tree change if the archive is decrypted
+	DecryptedData []byte `protobuf:"bytes,4,opt,name=decryptedData,proto3" json:"decryptedData,omitempty"`
+	// Heads are the heads of the tree for the tree heads entry
+	Heads []string `protobuf:"bytes,5,rep,name=heads,proto3" json:"heads,omitempty"`
+	// CompactedSnapshotId is the snapshot from which the compacted tree is stored for the tree heads entry,
+	// the changes before it are not archived except the root and the snapshots preceding it
+	CompactedSnapshotId string `protobuf:"bytes,6,opt,name=compactedSnapshotId,proto3" json:"compactedSnapshotId,omitempty"`
+}
+
+func (m *ArchiveEntry) Reset()         { *m = ArchiveEntry{} }
+func (m *ArchiveEntry) String() string { return proto.CompactTextString(m) }
+func (*ArchiveEntry) ProtoMessage()    {}
+func (*ArchiveEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2faf3254f109f75e, []int{1}
+}
+func (m *ArchiveEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ArchiveEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ArchiveEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ArchiveEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveEntry.Merge(m, src)
+}
+func (m *ArchiveEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *ArchiveEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveEntry proto.InternalMessageInfo
+
+func (m *ArchiveEntry) GetType() ArchiveEntryType {
+	if m != nil {
+		return m.Type
+	}
+	return ArchiveEntryType_SpaceHeader
+}
+
+func (m *ArchiveEntry) GetTreeId() string {
+	if m != nil {
+		return m.TreeId
+	}
+	return ""
+}
+
+func (m *ArchiveEntry) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ArchiveEntry) GetDecryptedData() []byte {
+	if m != nil {
+		return m.DecryptedData
+	}
+	return nil
+}
+
+func (m *ArchiveEntry) GetHeads() []string {
+	if m != nil {
+		return m.Heads
+	}
+	return nil
+}
+
+func (m *ArchiveEntry) GetCompactedSnapshotId() string {
+	if m != nil {
+		return m.CompactedSnapshotId
+	}
+	return ""
+}
+
+// ArchiveSignature is the last entry of the space archive
+type ArchiveSignature struct {
+	// Signature is the signature of the hash of all previous entries made by the identity from the header
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ArchiveSignature) Reset()         { *m = ArchiveSignature{} }
+func (m *ArchiveSignature) String() string { return proto.CompactTextString(m) }
+func (*ArchiveSignature) ProtoMessage()    {}
+func (*ArchiveSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2faf3254f109f75e, []int{2}
+}
+func (m *ArchiveSignature) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ArchiveSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ArchiveSignature.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ArchiveSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveSignature.Merge(m, src)
+}
+func (m *ArchiveSignature) XXX_Size() int {
+	return m.Size()
+}
+func (m *ArchiveSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveSignature proto.InternalMessageInfo
+
+func (m *ArchiveSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("archive.ArchiveEntryType", ArchiveEntryType_name, ArchiveEntryType_value)
+	proto.RegisterType((*ArchiveHeader)(nil), "archive.ArchiveHeader")
+	proto.RegisterType((*ArchiveEntry)(nil), "archive.ArchiveEntry")
+	proto.RegisterType((*ArchiveSignature)(nil), "archive.ArchiveSignature")
+}
+
+func init() {
+	proto.RegisterFile("commonspace/spacearchive/archiveproto/protos/archive.proto", fileDescriptor_2faf3254f109f75e)
+}
+
+var fileDescriptor_2faf3254f109f75e = []byte{
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x6b, 0x13, 0x41,
+	0x14, 0xc7, 0x33, 0x4d, 0x93, 0x36, 0xaf, 0x49, 0xbb, 0x8c, 0x22, 0xa3, 0xc8, 0xb2, 0x04, 0x85,
+	0x45, 0xb0, 0x29, 0x7a, 0xf3, 0x22, 0xd5, 0x0a, 0xf6, 0xe8, 0xa4, 0x27, 0x6f, 0xe3, 0xcc, 0x23,
+	0x19, 0xe8, 0xce, 0x0c, 0x33, 0x63, 0x61, 0xbf, 0x85, 0x1f, 0x4b, 0x6f, 0x3d, 0x7a, 0x11, 0x24,
+	0xf9, 0x22, 0xb2, 0x93, 0xdd, 0xa6, 0x06, 0x0f, 0x5e, 0x66, 0xf2, 0xfb, 0xbf, 0x07, 0x6f, 0x7e,
+	0x2f, 0x0b, 0x6f, 0xa4, 0xad, 0x2a, 0x6b, 0x82, 0x13, 0x12, 0x67, 0xe9, 0x14, 0x5e, 0x2e, 0xf5,
+	0x0d, 0xce, 0xda, 0xdb, 0x79, 0x1b, 0xed, 0x2c, 0x9d, 0xa1, 0xcb, 0x4e, 0x13, 0xd2, 0x83, 0x16,
+	0xa7, 0x3f, 0x08, 0x4c, 0xce, 0x37, 0xbf, 0x3f, 0xa2, 0x50, 0xe8, 0x29, 0x83, 0x83, 0x1b, 0xf4,
+	0x41, 0x5b, 0xc3, 0x48, 0x41, 0xca, 0x09, 0xef, 0xb0, 0xa9, 0xa4, 0x31, 0x97, 0x8a, 0xed, 0x15,
+	0xa4, 0x1c, 0xf1, 0x0e, 0xe9, 0x13, 0x38, 0xd4, 0x0a, 0x4d, 0xd4, 0xb1, 0x66, 0xfd, 0x82, 0x94,
+	0x63, 0x7e, 0xc7, 0xf4, 0x29, 0x8c, 0xa2, 0xae, 0x30, 0x44, 0x51, 0x39, 0xb6, 0x5f, 0x90, 0xb2,
+	0xcf, 0xb7, 0x01, 0x2d, 0xe0, 0x48, 0x87, 0x0b, 0x94, 0xbe, 0x76, 0x11, 0x15, 0x1b, 0x14, 0xa4,
+	0x3c, 0xe4, 0xf7, 0x23, 0x5a, 0xc2, 0x49, 0x1a, 0x33, 0xc7, 0x18, 0xb5, 0x59, 0x84, 0x4b, 0xc5,
+	0x86, 0x69, 0xfa, 0x6e, 0x3c, 0xfd, 0x45, 0x60, 0xdc, 0xba, 0x7c, 0x30, 0xd1, 0xd7, 0xf4, 0x25,
+	0xec, 0xc7, 0xda, 0x61, 0xf2, 0x38, 0x7e, 0xf5, 0xf8, 0xb4, 0xdb, 0xc1, 0xfd, 0xa6, 0xab, 0xda,
+	0x21, 0x4f, 0x6d, 0xf4, 0x11, 0x0c, 0xa3, 0xc7, 0xad, 0x5e, 0x4b, 0x8d, 0xb7, 0x13, 0xf5, 0xb5,
+	0x15, 0xaa, 0x95, 0xeb, 0x90, 0x3e, 0x83, 0x89, 0xea, 0x1e, 0x7a, 0x21, 0xa2, 0x48, 0x7e, 0x63,
+	0xfe, 0x77, 0x48, 0x1f, 0xc2, 0x60, 0x89, 0x42, 0x05, 0x36, 0x28, 0xfa, 0xe5, 0x88, 0x6f, 0x80,
+	0x9e, 0xc1, 0x03, 0x69, 0x2b, 0x27, 0x64, 0x44, 0x35, 0x37, 0xc2, 0x85, 0xa5, 0x8d, 0x77, 0x6e,
+	0xff, 0x2a, 0x4d, 0xcf, 0x20, 0x6b, 0x5f, 0x3e, 0xd7, 0x0b, 0x23, 0xe2, 0x57, 0x8f, 0xcd, 0x76,
+	0x43, 0x07, 0xc9, 0x73, 0xcc, 0xb7, 0xc1, 0x8b, 0x4f, 0x90, 0xed, 0xba, 0xd2, 0x13, 0x38, 0x9a,
+	0x37, 0x8b, 0xdb, 0xfc, 0xdd, 0x59, 0x8f, 0x4e, 0x60, 0x74, 0x2e, 0xaf, 0x39, 0x4a, 0xeb, 0x55,
+	0x46, 0xe8, 0x31, 0xc0, 0x95, 0x47, 0x7c, 0xbf, 0x14, 0x66, 0x81, 0xd9, 0x5e, 0x53, 0x6e, 0xb8,
+	0x69, 0x0f, 0x59, 0xff, 0xdd, 0xdb, 0xef, 0xab, 0x9c, 0xdc, 0xae, 0x72, 0xf2, 0x7b, 0x95, 0x93,
+	0x6f, 0xeb, 0xbc, 0x77, 0xbb, 0xce, 0x7b, 0x3f, 0xd7, 0x79, 0xef, 0xf3, 0xf3, 0xff, 0xfa, 0x1e,
+	0xbf, 0x0c, 0xd3, 0xf5, 0xfa, 0xcf, 0x00, 0x75, 0x6e, 0x56, 0x8f, 0xbf, 0x02, 0x00, 0x00,
+}
+
+func (m *ArchiveHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ArchiveHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ArchiveHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SpaceSettingsId) > 0 {
+		i -= len(m.SpaceSettingsId)
+		copy(dAtA[i:], m.SpaceSettingsId)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.SpaceSettingsId)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.IsDecrypted {
+		i--
+		if m.IsDecrypted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintArchive(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Identity) > 0 {
+		i -= len(m.Identity)
+		copy(dAtA[i:], m.Identity)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.Identity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.SpaceId) > 0 {
+		i -= len(m.SpaceId)
+		copy(dAtA[i:], m.SpaceId)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.SpaceId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintArchive(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ArchiveEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ArchiveEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ArchiveEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.CompactedSnapshotId) > 0 {
+		i -= len(m.CompactedSnapshotId)
+		copy(dAtA[i:], m.CompactedSnapshotId)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.CompactedSnapshotId)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Heads) > 0 {
+		for iNdEx := len(m.Heads) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Heads[iNdEx])
+			copy(dAtA[i:], m.Heads[iNdEx])
+			i = encodeVarintArchive(dAtA, i, uint64(len(m.Heads[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.DecryptedData) > 0 {
+		i -= len(m.DecryptedData)
+		copy(dAtA[i:], m.DecryptedData)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.DecryptedData)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TreeId) > 0 {
+		i -= len(m.TreeId)
+		copy(dAtA[i:], m.TreeId)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.TreeId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintArchive(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ArchiveSignature) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ArchiveSignature) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ArchiveSignature) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintArchive(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintArchive(dAtA []byte, offset int, v uint64) int {
+	offset -= sovArchive(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ArchiveHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovArchive(uint64(m.Version))
+	}
+	l = len(m.SpaceId)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	l = len(m.Identity)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovArchive(uint64(m.Timestamp))
+	}
+	if m.IsDecrypted {
+		n += 2
+	}
+	l = len(m.SpaceSettingsId)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	return n
+}
+
+func (m *ArchiveEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovArchive(uint64(m.Type))
+	}
+	l = len(m.TreeId)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	l = len(m.DecryptedData)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	if len(m.Heads) > 0 {
+		for _, s := range m.Heads {
+			l = len(s)
+			n += 1 + l + sovArchive(uint64(l))
+		}
+	}
+	l = len(m.CompactedSnapshotId)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	return n
+}
+
+func (m *ArchiveSignature) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovArchive(uint64(l))
+	}
+	return n
+}
+
+func sovArchive(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozArchive(x uint64) (n int) {
+	return sovArchive(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ArchiveHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ArchiveHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ArchiveHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpaceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpaceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identity", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Identity = append(m.Identity[:0], dAtA[iNdEx:postIndex]...)
+			if m.Identity == nil {
+				m.Identity = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsDecrypted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsDecrypted = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpaceSettingsId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpaceSettingsId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ArchiveEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ArchiveEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ArchiveEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ArchiveEntryType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TreeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecryptedData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DecryptedData = append(m.DecryptedData[:0], dAtA[iNdEx:postIndex]...)
+			if m.DecryptedData == nil {
+				m.DecryptedData = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heads", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Heads = append(m.Heads, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactedSnapshotId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompactedSnapshotId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ArchiveSignature) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ArchiveSignature: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ArchiveSignature: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthArchive
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipArchive(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowArchive
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowArchive
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthArchive
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupArchive
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthArchive
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthArchive        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowArchive          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupArchive = fmt.Errorf("proto: unexpected end of group")
+)
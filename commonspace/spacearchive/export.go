@@ -0,0 +1,206 @@
+package spacearchive
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/spacearchive/archiveproto"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+)
+
+const exportPageSize = 100
+
+// ExportParams is a payload to be passed when we are exporting the space
+type ExportParams struct {
+	// Storage is the storage of the exported space
+	Storage spacestorage.SpaceStorage
+	// Keys are the keys of the account which signs the archive,
+	// the changes are decrypted with the read keys available to this account
+	Keys *accountdata.AccountKeys
+	// Decrypted tells if the decrypted data of the changes is added to the archive,
+	// the raw changes are always kept encrypted, so the archive can be verified and imported
+	Decrypted bool
+}
+
+// Export writes the archive of the space containing the space header, all acl records and all stored trees.
+// The changes removed from the trees by compaction are not archived, such trees start from their compacted snapshots
+func Export(ctx context.Context, w io.Writer, params ExportParams) (err error) {
+	st := params.Storage
+	header, err := st.SpaceHeader()
+	if err != nil {
+		return
+	}
+	aclStorage, err := st.AclStorage()
+	if err != nil {
+		return
+	}
+	aclList, err := list.BuildAclListWithIdentity(params.Keys, aclStorage, list.NoOpAcceptorVerifier{})
+	if err != nil {
+		return
+	}
+	if params.Decrypted && !canDecrypt(aclList.AclState(), params.Keys) {
+		return ErrDecryptionForbidden
+	}
+	identity, err := params.Keys.SignKey.GetPublic().Marshall()
+	if err != nil {
+		return
+	}
+
+	ew := newEntryWriter(w)
+	err = ew.write(&archiveproto.ArchiveHeader{
+		Version:         Version,
+		SpaceId:         st.Id(),
+		Identity:        identity,
+		Timestamp:       time.Now().Unix(),
+		IsDecrypted:     params.Decrypted,
+		SpaceSettingsId: st.SpaceSettingsId(),
+	})
+	if err != nil {
+		return
+	}
+	rawHeader, err := header.Marshal()
+	if err != nil {
+		return
+	}
+	if err = ew.write(&archiveproto.ArchiveEntry{Type: archiveproto.ArchiveEntryType_SpaceHeader, Payload: rawHeader}); err != nil {
+		return
+	}
+	records, err := aclList.RecordsBefore(ctx, "")
+	if err != nil {
+		return
+	}
+	for _, rec := range records {
+		rawRec, err := rec.Marshal()
+		if err != nil {
+			return err
+		}
+		if err = ew.write(&archiveproto.ArchiveEntry{Type: archiveproto.ArchiveEntryType_AclRecord, Payload: rawRec}); err != nil {
+			return err
+		}
+	}
+
+	ids, err := st.StoredIds()
+	if err != nil {
+		return
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		status, err := st.TreeDeletedStatus(id)
+		if err != nil {
+			return err
+		}
+		if status == spacestorage.TreeDeletedStatusDeleted {
+			continue
+		}
+		if err = exportTree(ctx, ew, st, id, aclList, params.Decrypted); err != nil {
+			return err
+		}
+	}
+
+	signature, err := params.Keys.SignKey.Sign(ew.sum())
+	if err != nil {
+		return
+	}
+	return ew.write(&archiveproto.ArchiveSignature{Signature: signature})
+}
+
+func canDecrypt(state *list.AclState, keys *accountdata.AccountKeys) bool {
+	return !state.Permissions(keys.SignKey.GetPublic()).NoPermissions() || state.IsPublicReader()
+}
+
+func exportTree(ctx context.Context, ew *entryWriter, st spacestorage.SpaceStorage, id string, aclList list.AclList, decrypted bool) (err error) {
+	treeStorage, err := st.TreeStorage(id)
+	if err != nil {
+		return
+	}
+	tree, err := objecttree.BuildObjectTree(treeStorage, aclList)
+	if err != nil {
+		return
+	}
+	// the changes before the compacted snapshot are not in the storage except the root and the snapshots
+	compactedId, err := treeStorage.CompactedSnapshotId()
+	if err != nil {
+		return
+	}
+	fromId := compactedId
+	if fromId == "" {
+		fromId = id
+	}
+	iter, err := tree.NewPagedIterator(fromId)
+	if err != nil {
+		return
+	}
+	writeRaw := func(raw *treechangeproto.RawTreeChangeWithId, unpack func() ([]byte, error)) (err error) {
+		entry := &archiveproto.ArchiveEntry{Type: archiveproto.ArchiveEntryType_TreeChange, TreeId: id}
+		if entry.Payload, err = raw.Marshal(); err != nil {
+			return
+		}
+		if decrypted && raw.Id != id {
+			if entry.DecryptedData, err = unpack(); err != nil {
+				return
+			}
+		}
+		return ew.write(entry)
+	}
+	writeChange := func(change *objecttree.Change) (err error) {
+		raw, err := treeStorage.GetRawChange(ctx, change.Id)
+		if err != nil {
+			return
+		}
+		return writeRaw(raw, func() ([]byte, error) {
+			return iter.Unpack(change)
+		})
+	}
+	if compactedId != "" {
+		// the root and the snapshots preceding the compacted snapshot starting from the oldest one
+		path := tree.SnapshotPath()
+		compactedIdx := slices.Index(path, compactedId)
+		if compactedIdx == -1 {
+			return ErrIncorrectTree
+		}
+		for i := len(path) - 1; i > compactedIdx; i-- {
+			raw, err := treeStorage.GetRawChange(ctx, path[i])
+			if err != nil {
+				return err
+			}
+			err = writeRaw(raw, func() ([]byte, error) {
+				return tree.UnpackChange(raw)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for {
+		changes, err := iter.NextPage(ctx, exportPageSize)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			break
+		}
+		for _, change := range changes {
+			if err = writeChange(change); err != nil {
+				return err
+			}
+		}
+	}
+	heads, err := treeStorage.Heads()
+	if err != nil {
+		return
+	}
+	return ew.write(&archiveproto.ArchiveEntry{
+		Type:                archiveproto.ArchiveEntryType_TreeHeads,
+		TreeId:              id,
+		Heads:               heads,
+		CompactedSnapshotId: compactedId,
+	})
+}
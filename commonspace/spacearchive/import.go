@@ -0,0 +1,308 @@
+package spacearchive
+
+import (
+	"context"
+	"io"
+
+	"github.com/anyproto/any-sync/commonspace"
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/commonspace/spacearchive/archiveproto"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// Read reads the archive and verifies its signature, the space header, the acl records
+// and the ids and the signatures of all changes. If identity is not nil, the archive must be signed by it
+func Read(r io.Reader, identity crypto.PubKey) (archive *Archive, err error) {
+	er := newEntryReader(r)
+	data, err := er.read()
+	if err != nil {
+		return nil, ErrIncorrectArchive
+	}
+	header := &archiveproto.ArchiveHeader{}
+	if err = header.Unmarshal(data); err != nil {
+		return nil, ErrIncorrectArchive
+	}
+	if header.Version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	archive = &Archive{
+		SpaceId:     header.SpaceId,
+		Timestamp:   header.Timestamp,
+		IsDecrypted: header.IsDecrypted,
+	}
+	if archive.Identity, err = crypto.UnmarshalEd25519PublicKeyProto(header.Identity); err != nil {
+		return nil, ErrIncorrectArchive
+	}
+	if identity != nil && !identity.Equals(archive.Identity) {
+		return nil, ErrIncorrectIdentity
+	}
+
+	var (
+		entries []*archiveproto.ArchiveEntry
+		last    []byte
+	)
+	for {
+		data, err = er.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the previous entry isn't the last one, so it is not the signature
+		if last != nil {
+			entry := &archiveproto.ArchiveEntry{}
+			if err = entry.Unmarshal(last); err != nil {
+				return nil, ErrIncorrectArchive
+			}
+			entries = append(entries, entry)
+		}
+		last = data
+	}
+	signature := &archiveproto.ArchiveSignature{}
+	if last == nil || signature.Unmarshal(last) != nil {
+		return nil, ErrIncorrectSignature
+	}
+	ok, err := archive.Identity.Verify(er.sum(), signature.Signature)
+	if err != nil || !ok {
+		return nil, ErrIncorrectSignature
+	}
+	if err = archive.fill(header.SpaceSettingsId, entries); err != nil {
+		return nil, err
+	}
+	if err = archive.verify(); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Import creates the storage of the space from the archive, the archive should be verified by Read
+func Import(ctx context.Context, provider spacestorage.SpaceStorageProvider, archive *Archive) (st spacestorage.SpaceStorage, err error) {
+	if provider.SpaceExists(archive.SpaceId) {
+		return nil, spacestorage.ErrSpaceStorageExists
+	}
+	st, err = provider.CreateSpaceStorage(archive.Space)
+	if err != nil {
+		return
+	}
+	aclStorage, err := st.AclStorage()
+	if err != nil {
+		return
+	}
+	for _, rec := range archive.AclRecords[1:] {
+		if err = aclStorage.AddRawRecord(ctx, rec); err != nil {
+			return
+		}
+	}
+	if err = aclStorage.SetHead(archive.AclRecords[len(archive.AclRecords)-1].Id); err != nil {
+		return
+	}
+	for _, tree := range archive.Trees {
+		if tree.RootRawChange.Id == archive.Space.SpaceSettingsWithId.Id {
+			settingsStorage, err := st.TreeStorage(tree.RootRawChange.Id)
+			if err != nil {
+				return nil, err
+			}
+			if err = settingsStorage.AddRawChangesSetHeads(tree.Changes, tree.Heads); err != nil {
+				return nil, err
+			}
+			if err = compactImported(settingsStorage, tree); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		treeStorage, err := st.CreateTreeStorage(tree.TreeStorageCreatePayload)
+		if err != nil {
+			return nil, err
+		}
+		if err = compactImported(treeStorage, tree); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// compactImported marks the imported tree as compacted, so it is loaded from the compacted snapshot
+func compactImported(treeStorage treestorage.TreeStorage, tree Tree) error {
+	if tree.CompactedSnapshotId == "" {
+		return nil
+	}
+	return treeStorage.Compact(tree.CompactedSnapshotId, nil)
+}
+
+// fill collects the entries of the archive into the space payload, the acl records and the trees
+func (a *Archive) fill(settingsId string, entries []*archiveproto.ArchiveEntry) (err error) {
+	treeIdx := map[string]int{}
+	for _, entry := range entries {
+		switch entry.Type {
+		case archiveproto.ArchiveEntryType_SpaceHeader:
+			if a.Space.SpaceHeaderWithId != nil {
+				return ErrIncorrectArchive
+			}
+			a.Space.SpaceHeaderWithId = &spacesyncproto.RawSpaceHeaderWithId{}
+			if err = a.Space.SpaceHeaderWithId.Unmarshal(entry.Payload); err != nil {
+				return ErrIncorrectArchive
+			}
+		case archiveproto.ArchiveEntryType_AclRecord:
+			rec := &consensusproto.RawRecordWithId{}
+			if err = rec.Unmarshal(entry.Payload); err != nil {
+				return ErrIncorrectArchive
+			}
+			a.AclRecords = append(a.AclRecords, rec)
+		case archiveproto.ArchiveEntryType_TreeChange:
+			change := &treechangeproto.RawTreeChangeWithId{}
+			if err = change.Unmarshal(entry.Payload); err != nil {
+				return ErrIncorrectArchive
+			}
+			idx, exists := treeIdx[entry.TreeId]
+			if !exists {
+				// the root is always the first change of the tree
+				if change.Id != entry.TreeId {
+					return ErrIncorrectTree
+				}
+				idx = len(a.Trees)
+				treeIdx[entry.TreeId] = idx
+				a.Trees = append(a.Trees, Tree{})
+				a.Trees[idx].RootRawChange = change
+				if a.IsDecrypted {
+					a.Trees[idx].DecryptedData = map[string][]byte{}
+				}
+			}
+			tree := &a.Trees[idx]
+			tree.Changes = append(tree.Changes, change)
+			if a.IsDecrypted && change.Id != entry.TreeId {
+				tree.DecryptedData[change.Id] = entry.DecryptedData
+			}
+		case archiveproto.ArchiveEntryType_TreeHeads:
+			idx, exists := treeIdx[entry.TreeId]
+			if !exists {
+				return ErrIncorrectTree
+			}
+			a.Trees[idx].Heads = entry.Heads
+			a.Trees[idx].CompactedSnapshotId = entry.CompactedSnapshotId
+		default:
+			return ErrIncorrectArchive
+		}
+	}
+	if a.Space.SpaceHeaderWithId == nil || len(a.AclRecords) == 0 {
+		return ErrIncorrectArchive
+	}
+	settingsIdx, exists := treeIdx[settingsId]
+	if !exists {
+		return ErrIncorrectArchive
+	}
+	a.Space.AclWithId = a.AclRecords[0]
+	a.Space.SpaceSettingsWithId = a.Trees[settingsIdx].RootRawChange
+	return
+}
+
+// verify checks the space payload, the acl and the changes of all trees
+func (a *Archive) verify() (err error) {
+	if err = commonspace.ValidateSpaceStorageCreatePayload(a.Space); err != nil {
+		return
+	}
+	if a.Space.SpaceHeaderWithId.Id != a.SpaceId {
+		return ErrIncorrectArchive
+	}
+	aclStorage, err := liststorage.NewInMemoryAclListStorage(a.Space.AclWithId.Id, a.AclRecords)
+	if err != nil {
+		return
+	}
+	// building the list verifies the ids and the signatures of the records and the validity of the acl state
+	aclList, err := list.BuildAclList(aclStorage, list.NoOpAcceptorVerifier{})
+	if err != nil {
+		return
+	}
+	if len(aclList.Records()) != len(a.AclRecords) {
+		return ErrIncorrectArchive
+	}
+	for _, tree := range a.Trees {
+		if err = verifyTree(a.SpaceId, tree, aclList); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// verifyTree checks the signatures of the changes, the graph of the tree
+// and the permission of the authors to write at the acl records of the changes
+func verifyTree(spaceId string, tree Tree, aclList list.AclList) (err error) {
+	root := tree.RootRawChange
+	rootChange := &treechangeproto.RawTreeChange{}
+	if err = rootChange.Unmarshal(root.RawChange); err != nil {
+		return ErrIncorrectTree
+	}
+	rootPayload := &treechangeproto.RootChange{}
+	if err = rootPayload.Unmarshal(rootChange.Payload); err != nil {
+		return ErrIncorrectTree
+	}
+	if rootPayload.SpaceId != spaceId {
+		return ErrIncorrectTree
+	}
+	var (
+		changeBuilder = objecttree.NewChangeBuilder(crypto.NewKeyStorage(), root)
+		changes       = make(map[string]*objecttree.Change, len(tree.Changes))
+		state         = aclList.AclState()
+	)
+	for _, raw := range tree.Changes {
+		ch, err := changeBuilder.Unmarshall(raw, true)
+		if err != nil {
+			return err
+		}
+		if !ch.IsDerived {
+			accountState, err := state.StateAtRecord(ch.AclHeadId, ch.Identity)
+			if err != nil {
+				return err
+			}
+			if !accountState.Capabilities.Has(aclrecordproto.AclCapability_CapabilityWrite) {
+				return list.ErrInsufficientPermissions
+			}
+		}
+		changes[raw.Id] = ch
+	}
+
+	// the previous changes of the compacted snapshot and the snapshots preceding it were removed
+	compacted := map[string]struct{}{}
+	for snapshotId := tree.CompactedSnapshotId; snapshotId != ""; {
+		ch, exists := changes[snapshotId]
+		if !exists || !ch.IsSnapshot && snapshotId != root.Id {
+			return ErrIncorrectTree
+		}
+		compacted[snapshotId] = struct{}{}
+		snapshotId = ch.SnapshotId
+	}
+	for id, ch := range changes {
+		if id == root.Id {
+			continue
+		}
+		if _, exists := changes[ch.SnapshotId]; !exists {
+			return ErrIncorrectTree
+		}
+		if _, exists := compacted[id]; exists {
+			continue
+		}
+		for _, prevId := range ch.PreviousIds {
+			if _, exists := changes[prevId]; !exists {
+				return ErrIncorrectTree
+			}
+		}
+	}
+
+	if len(tree.Heads) == 0 {
+		return ErrIncorrectTree
+	}
+	for _, head := range tree.Heads {
+		if _, exists := changes[head]; !exists {
+			return ErrIncorrectTree
+		}
+	}
+	return
+}
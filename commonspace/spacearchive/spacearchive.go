@@ -0,0 +1,128 @@
+// Package spacearchive provides the portable signed archive of the whole space,
+// which can be used for backups and migrations between storages
+package spacearchive
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// Version is the current version of the archive format
+const Version = 1
+
+// maxEntrySize limits the size of a single entry to protect from corrupted length prefixes
+const maxEntrySize = 64 << 20
+
+var (
+	ErrUnsupportedVersion  = errors.New("unsupported archive version")
+	ErrIncorrectArchive    = errors.New("incorrect archive")
+	ErrIncorrectSignature  = errors.New("incorrect archive signature")
+	ErrIncorrectIdentity   = errors.New("archive is signed by another identity")
+	ErrIncorrectTree       = errors.New("incorrect tree in archive")
+	ErrEntryTooLarge       = errors.New("archive entry is too large")
+	ErrDecryptionForbidden = errors.New("account can't decrypt the space")
+)
+
+// Archive is the verified content of the space archive
+type Archive struct {
+	// SpaceId is an id of the archived space
+	SpaceId string
+	// Identity is the identity of the account which exported and signed the archive
+	Identity crypto.PubKey
+	// Timestamp is the time of the export
+	Timestamp int64
+	// IsDecrypted tells if the trees contain the decrypted data of the changes
+	IsDecrypted bool
+	// Space contains the space header, the acl root and the settings root
+	Space spacestorage.SpaceStorageCreatePayload
+	// AclRecords are all records of the acl starting from the root
+	AclRecords []*consensusproto.RawRecordWithId
+	// Trees are all trees of the space including the settings tree
+	Trees []Tree
+}
+
+// Tree is the archived tree
+type Tree struct {
+	treestorage.TreeStorageCreatePayload
+	// CompactedSnapshotId is the snapshot from which the compacted tree is stored, empty if the tree is not compacted
+	CompactedSnapshotId string
+	// DecryptedData contains the decrypted data of the changes by their ids if the archive is decrypted
+	DecryptedData map[string][]byte
+}
+
+// entryWriter writes the length-prefixed entries and hashes them for the signature
+type entryWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	buf  []byte
+}
+
+func newEntryWriter(w io.Writer) *entryWriter {
+	return &entryWriter{w: w, hash: sha256.New()}
+}
+
+func (e *entryWriter) write(msg interface{ Marshal() ([]byte, error) }) (err error) {
+	data, err := msg.Marshal()
+	if err != nil {
+		return
+	}
+	e.buf = binary.AppendUvarint(e.buf[:0], uint64(len(data)))
+	e.buf = append(e.buf, data...)
+	e.hash.Write(e.buf)
+	_, err = e.w.Write(e.buf)
+	return
+}
+
+// sum returns the hash of all written entries
+func (e *entryWriter) sum() []byte {
+	return e.hash.Sum(nil)
+}
+
+// entryReader reads the length-prefixed entries and hashes them for the signature check
+type entryReader struct {
+	r    *bufio.Reader
+	hash hash.Hash
+	last []byte
+}
+
+func newEntryReader(r io.Reader) *entryReader {
+	return &entryReader{r: bufio.NewReader(r), hash: sha256.New()}
+}
+
+// read returns the next entry, io.EOF means the end of the archive.
+// The entry is hashed only when the next one is read, so the signature entry is never hashed
+func (e *entryReader) read() (data []byte, err error) {
+	size, err := binary.ReadUvarint(e.r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, ErrIncorrectArchive
+	}
+	e.hash.Write(e.last)
+	if size > maxEntrySize {
+		return nil, ErrEntryTooLarge
+	}
+	entry := binary.AppendUvarint(nil, size)
+	prefixLen := len(entry)
+	entry = append(entry, make([]byte, size)...)
+	if _, err = io.ReadFull(e.r, entry[prefixLen:]); err != nil {
+		return nil, ErrIncorrectArchive
+	}
+	e.last = entry
+	return entry[prefixLen:], nil
+}
+
+// sum returns the hash of all entries except the last read one
+func (e *entryReader) sum() []byte {
+	return e.hash.Sum(nil)
+}
@@ -0,0 +1,259 @@
+package spacearchive
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anyproto/any-sync/commonspace"
+	"github.com/anyproto/any-sync/commonspace/object/accountdata"
+	"github.com/anyproto/any-sync/commonspace/object/acl/list"
+	"github.com/anyproto/any-sync/commonspace/object/acl/liststorage"
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
+	"github.com/anyproto/any-sync/commonspace/object/tree/treestorage"
+	"github.com/anyproto/any-sync/commonspace/spacestorage"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var ctx = context.Background()
+
+type fixture struct {
+	keys    *accountdata.AccountKeys
+	storage spacestorage.SpaceStorage
+	treeId  string
+	heads   []string
+}
+
+func newFixture(t *testing.T) *fixture {
+	keys, err := accountdata.NewRandom()
+	require.NoError(t, err)
+	metadataKey, _, err := crypto.GenerateRandomEd25519KeyPair()
+	require.NoError(t, err)
+	payload, err := commonspace.StoragePayloadForSpaceCreate(commonspace.SpaceCreatePayload{
+		SigningKey:     keys.SignKey,
+		SpaceType:      "type",
+		ReadKey:        crypto.NewAES(),
+		MetadataKey:    metadataKey,
+		ReplicationKey: 10,
+		MasterKey:      keys.PeerKey,
+	})
+	require.NoError(t, err)
+	st, err := spacestorage.NewInMemorySpaceStorage(payload)
+	require.NoError(t, err)
+	aclStorage, err := st.AclStorage()
+	require.NoError(t, err)
+	aclList, err := list.BuildAclListWithIdentity(keys, aclStorage, list.NoOpAcceptorVerifier{})
+	require.NoError(t, err)
+
+	root, err := objecttree.CreateObjectTreeRoot(objecttree.ObjectTreeCreatePayload{
+		PrivKey:     keys.SignKey,
+		ChangeType:  "changeType",
+		SpaceId:     st.Id(),
+		IsEncrypted: true,
+		Timestamp:   time.Now().Unix(),
+	}, aclList)
+	require.NoError(t, err)
+	treeStorage, err := st.CreateTreeStorage(treestorage.TreeStorageCreatePayload{
+		RootRawChange: root,
+		Changes:       []*treechangeproto.RawTreeChangeWithId{root},
+		Heads:         []string{root.Id},
+	})
+	require.NoError(t, err)
+	tree, err := objecttree.BuildObjectTree(treeStorage, aclList)
+	require.NoError(t, err)
+	for _, data := range []string{"a", "b"} {
+		_, err = tree.AddContent(ctx, objecttree.SignableChangeContent{
+			Data:        []byte(data),
+			Key:         keys.SignKey,
+			IsEncrypted: true,
+		})
+		require.NoError(t, err)
+	}
+	return &fixture{keys: keys, storage: st, treeId: root.Id, heads: tree.Heads()}
+}
+
+func (fx *fixture) export(t *testing.T, decrypted bool) []byte {
+	buf := bytes.NewBuffer(nil)
+	err := Export(ctx, buf, ExportParams{
+		Storage:   fx.storage,
+		Keys:      fx.keys,
+		Decrypted: decrypted,
+	})
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestArchive(t *testing.T) {
+	t.Run("export and import", func(t *testing.T) {
+		fx := newFixture(t)
+		archive, err := Read(bytes.NewReader(fx.export(t, false)), fx.keys.SignKey.GetPublic())
+		require.NoError(t, err)
+		require.Equal(t, fx.storage.Id(), archive.SpaceId)
+		require.False(t, archive.IsDecrypted)
+		require.Len(t, archive.Trees, 2)
+
+		provider := spacestorage.NewInMemorySpaceStorageProvider()
+		st, err := Import(ctx, provider, archive)
+		require.NoError(t, err)
+		require.Equal(t, fx.storage.SpaceSettingsId(), st.SpaceSettingsId())
+		treeStorage, err := st.TreeStorage(fx.treeId)
+		require.NoError(t, err)
+		heads, err := treeStorage.Heads()
+		require.NoError(t, err)
+		require.Equal(t, fx.heads, heads)
+		for _, head := range heads {
+			has, err := treeStorage.HasChange(ctx, head)
+			require.NoError(t, err)
+			require.True(t, has)
+		}
+
+		_, err = Import(ctx, provider, archive)
+		require.ErrorIs(t, err, spacestorage.ErrSpaceStorageExists)
+	})
+	t.Run("decrypted export", func(t *testing.T) {
+		fx := newFixture(t)
+		archive, err := Read(bytes.NewReader(fx.export(t, true)), nil)
+		require.NoError(t, err)
+		require.True(t, archive.IsDecrypted)
+		var decrypted []string
+		for _, tree := range archive.Trees {
+			if tree.RootRawChange.Id != fx.treeId {
+				continue
+			}
+			for _, change := range tree.Changes[1:] {
+				decrypted = append(decrypted, string(tree.DecryptedData[change.Id]))
+			}
+		}
+		require.Equal(t, []string{"a", "b"}, decrypted)
+	})
+	t.Run("compacted tree", func(t *testing.T) {
+		fx := newFixture(t)
+		aclStorage, err := fx.storage.AclStorage()
+		require.NoError(t, err)
+		aclList, err := list.BuildAclListWithIdentity(fx.keys, aclStorage, list.NoOpAcceptorVerifier{})
+		require.NoError(t, err)
+		treeStorage, err := fx.storage.TreeStorage(fx.treeId)
+		require.NoError(t, err)
+		tree, err := objecttree.BuildObjectTree(treeStorage, aclList)
+		require.NoError(t, err)
+		for _, data := range []string{"c", "d", "e", "f"} {
+			_, err = tree.AddContent(ctx, objecttree.SignableChangeContent{
+				Data:        []byte(data),
+				Key:         fx.keys.SignKey,
+				IsSnapshot:  data == "c" || data == "e",
+				IsEncrypted: true,
+			})
+			require.NoError(t, err)
+		}
+		path := tree.SnapshotPath()
+		require.Len(t, path, 3)
+		tree.AckSnapshotPath("peer", path)
+		removed, err := tree.Compact(objecttree.CompactionPolicy{KeepSnapshots: 1, AckPeers: []string{"peer"}})
+		require.NoError(t, err)
+		require.Equal(t, 3, removed)
+
+		archive, err := Read(bytes.NewReader(fx.export(t, true)), nil)
+		require.NoError(t, err)
+		var archived Tree
+		for _, tr := range archive.Trees {
+			if tr.RootRawChange.Id == fx.treeId {
+				archived = tr
+			}
+		}
+		require.Equal(t, path[0], archived.CompactedSnapshotId)
+		var ids []string
+		for _, ch := range archived.Changes {
+			ids = append(ids, ch.Id)
+		}
+		require.Equal(t, fx.treeId, ids[0])
+		require.Equal(t, path[1], ids[1])
+		require.Equal(t, path[0], ids[2])
+		require.Len(t, ids, 4)
+		require.Equal(t, "c", string(archived.DecryptedData[path[1]]))
+
+		st, err := Import(ctx, spacestorage.NewInMemorySpaceStorageProvider(), archive)
+		require.NoError(t, err)
+		imported, err := st.TreeStorage(fx.treeId)
+		require.NoError(t, err)
+		compactedId, err := imported.CompactedSnapshotId()
+		require.NoError(t, err)
+		require.Equal(t, path[0], compactedId)
+		importedTree, err := objecttree.BuildObjectTree(imported, aclList)
+		require.NoError(t, err)
+		require.Equal(t, tree.Heads(), importedTree.Heads())
+		require.Equal(t, path, importedTree.SnapshotPath())
+	})
+	t.Run("incorrect graph", func(t *testing.T) {
+		fx := newFixture(t)
+		archive, err := Read(bytes.NewReader(fx.export(t, false)), nil)
+		require.NoError(t, err)
+		aclList, err := list.BuildAclList(mustAclStorage(t, archive), list.NoOpAcceptorVerifier{})
+		require.NoError(t, err)
+		for _, tree := range archive.Trees {
+			if tree.RootRawChange.Id != fx.treeId {
+				continue
+			}
+			require.NoError(t, verifyTree(archive.SpaceId, tree, aclList))
+			// the first change after the root is missing
+			tree.Changes = append([]*treechangeproto.RawTreeChangeWithId{tree.Changes[0]}, tree.Changes[2:]...)
+			require.Equal(t, ErrIncorrectTree, verifyTree(archive.SpaceId, tree, aclList))
+		}
+	})
+	t.Run("change without write permission", func(t *testing.T) {
+		fx := newFixture(t)
+		archive, err := Read(bytes.NewReader(fx.export(t, false)), nil)
+		require.NoError(t, err)
+		aclList, err := list.BuildAclList(mustAclStorage(t, archive), list.NoOpAcceptorVerifier{})
+		require.NoError(t, err)
+		outsider, _, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		for _, tree := range archive.Trees {
+			if tree.RootRawChange.Id != fx.treeId {
+				continue
+			}
+			changeBuilder := objecttree.NewChangeBuilder(crypto.NewKeyStorage(), tree.RootRawChange)
+			_, raw, err := changeBuilder.Build(objecttree.BuilderContent{
+				TreeHeadIds:    tree.Heads,
+				AclHeadId:      aclList.Head().Id,
+				SnapshotBaseId: tree.RootRawChange.Id,
+				PrivKey:        outsider,
+				Content:        []byte("data"),
+				Timestamp:      time.Now().Unix(),
+			})
+			require.NoError(t, err)
+			tree.Changes = append(tree.Changes, raw)
+			tree.Heads = []string{raw.Id}
+			require.ErrorIs(t, verifyTree(archive.SpaceId, tree, aclList), list.ErrNoSuchAccount)
+		}
+	})
+	t.Run("another identity", func(t *testing.T) {
+		fx := newFixture(t)
+		_, pubKey, err := crypto.GenerateRandomEd25519KeyPair()
+		require.NoError(t, err)
+		_, err = Read(bytes.NewReader(fx.export(t, false)), pubKey)
+		require.ErrorIs(t, err, ErrIncorrectIdentity)
+	})
+	t.Run("tampered archive", func(t *testing.T) {
+		fx := newFixture(t)
+		data := fx.export(t, false)
+		data[len(data)/2] ^= 0xff
+		_, err := Read(bytes.NewReader(data), nil)
+		require.Error(t, err)
+	})
+	t.Run("truncated archive", func(t *testing.T) {
+		fx := newFixture(t)
+		data := fx.export(t, false)
+		_, err := Read(bytes.NewReader(data[:len(data)-10]), nil)
+		require.Error(t, err)
+	})
+}
+
+func mustAclStorage(t *testing.T, archive *Archive) liststorage.ListStorage {
+	aclStorage, err := liststorage.NewInMemoryAclListStorage(archive.Space.AclWithId.Id, archive.AclRecords)
+	require.NoError(t, err)
+	return aclStorage
+}
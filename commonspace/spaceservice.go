@@ -4,6 +4,7 @@ package commonspace
 import (
 	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,7 +24,9 @@ import (
 	"github.com/anyproto/any-sync/commonspace/credentialprovider"
 	"github.com/anyproto/any-sync/commonspace/deletionstate"
 	"github.com/anyproto/any-sync/commonspace/headsync"
+	"github.com/anyproto/any-sync/commonspace/keyrotation"
 	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl"
+	"github.com/anyproto/any-sync/commonspace/object/acl/syncacl/pendingacl"
 	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
 	"github.com/anyproto/any-sync/commonspace/object/tree/treechangeproto"
 	"github.com/anyproto/any-sync/commonspace/object/treemanager"
@@ -66,6 +69,9 @@ type SpaceService interface {
 
 type Deps struct {
 	TreeSyncer treesyncer.TreeSyncer
+	// AclRecordSender sends the acl records made by the space itself,
+	// the read key rotation policy is not enforced if it is nil
+	AclRecordSender pendingacl.RecordSender
 }
 
 type spaceService struct {
@@ -80,6 +86,9 @@ type spaceService struct {
 	pool                  pool.Pool
 	metric                metric.Metric
 	app                   *app.App
+
+	limitMetricOnce sync.Once
+	limitMetric     objecttree.LimitMetric
 }
 
 func (s *spaceService) Init(a *app.App) (err error) {
@@ -100,8 +109,32 @@ func (s *spaceService) Name() (name string) {
 	return CName
 }
 
+// treeLimits returns the limits of the trees from the config, the rejected changes are counted by the metric if it is present
+func (s *spaceService) treeLimits() objecttree.TreeLimits {
+	cfg := s.config.TreeLimits
+	limits := objecttree.TreeLimits{
+		MaxChangeSize:      cfg.MaxChangeSize,
+		MaxChanges:         cfg.MaxChanges,
+		MaxIdentityChanges: cfg.MaxIdentityChanges,
+		IdentityWindow:     time.Duration(cfg.IdentityWindowSec) * time.Second,
+		MaxPeerChanges:     cfg.MaxPeerChanges,
+		PeerWindow:         time.Duration(cfg.PeerWindowSec) * time.Second,
+	}
+	if limits.IsEmpty() || s.metric == nil || s.metric.Registry() == nil {
+		return limits
+	}
+	s.limitMetricOnce.Do(func() {
+		var err error
+		if s.limitMetric, err = objecttree.NewLimitMetric(s.metric.Registry()); err != nil {
+			log.Warn("can't register tree limits metric", zap.Error(err))
+		}
+	})
+	limits.Metric = s.limitMetric
+	return limits
+}
+
 func (s *spaceService) CreateSpace(ctx context.Context, payload SpaceCreatePayload) (id string, err error) {
-	storageCreate, err := storagePayloadForSpaceCreate(payload)
+	storageCreate, err := StoragePayloadForSpaceCreate(payload)
 	if err != nil {
 		return
 	}
@@ -165,12 +198,14 @@ func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Spac
 		SpaceId:       st.Id(),
 		SpaceIsClosed: spaceIsClosed,
 		TreesUsed:     &atomic.Int32{},
+		ChangeCounter: spacestate.NewChangeCounter(),
 	}
 	if s.config.KeepTreeDataInMemory {
 		state.TreeBuilderFunc = objecttree.BuildObjectTree
 	} else {
 		state.TreeBuilderFunc = objecttree.BuildEmptyDataObjectTree
 	}
+	state.TreeLimits = s.treeLimits()
 	peerManager, err := s.peerManagerProvider.NewPeerManager(ctx, id)
 	if err != nil {
 		return nil, err
@@ -191,6 +226,9 @@ func (s *spaceService) NewSpace(ctx context.Context, id string, deps Deps) (Spac
 		Register(objecttreebuilder.New()).
 		Register(objectsync.New()).
 		Register(headsync.New())
+	if deps.AclRecordSender != nil {
+		spaceApp.Register(keyrotation.New(deps.AclRecordSender))
+	}
 
 	sp := &space{
 		state:   state,
@@ -290,7 +328,7 @@ func (s *spaceService) spacePullWithPeer(ctx context.Context, p peer.Peer, id st
 }
 
 func (s *spaceService) createSpaceStorage(payload spacestorage.SpaceStorageCreatePayload) (spacestorage.SpaceStorage, error) {
-	err := validateSpaceStorageCreatePayload(payload)
+	err := ValidateSpaceStorageCreatePayload(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,37 @@
+package spacestate
+
+import (
+	"sync"
+
+	"github.com/anyproto/any-sync/commonspace/object/tree/objecttree"
+)
+
+// ChangeCounter counts the changes added to the loaded trees of the space by the read keys they are encrypted with.
+// The counts are kept in memory, so the changes added before the space is loaded are not counted
+type ChangeCounter struct {
+	mx     sync.Mutex
+	counts map[string]uint32
+}
+
+func NewChangeCounter() *ChangeCounter {
+	return &ChangeCounter{counts: map[string]uint32{}}
+}
+
+func (c *ChangeCounter) ChangesAdded(changes []*objecttree.Change) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	for _, ch := range changes {
+		// the changes which are not encrypted don't need the rotation
+		if ch.ReadKeyId == "" {
+			continue
+		}
+		c.counts[ch.ReadKeyId]++
+	}
+}
+
+// Count returns the number of the changes encrypted with the read key
+func (c *ChangeCounter) Count(readKeyId string) uint32 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.counts[readKeyId]
+}
@@ -13,6 +13,8 @@ type SpaceState struct {
 	SpaceIsClosed   *atomic.Bool
 	TreesUsed       *atomic.Int32
 	TreeBuilderFunc objecttree.BuildObjectTreeFunc
+	TreeLimits      objecttree.TreeLimits
+	ChangeCounter   *ChangeCounter
 }
 
 func (s *SpaceState) Init(a *app.App) (err error) {
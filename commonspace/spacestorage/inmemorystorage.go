@@ -103,6 +103,22 @@ func (i *InMemorySpaceStorage) AclStorage() (liststorage.ListStorage, error) {
 	return i.aclStorage, nil
 }
 
+func (i *InMemorySpaceStorage) AclSnapshotStorage() (liststorage.SnapshotStorage, error) {
+	snapshotStorage, ok := i.aclStorage.(liststorage.SnapshotStorage)
+	if !ok {
+		return nil, ErrAclStorageNotSupported
+	}
+	return snapshotStorage, nil
+}
+
+func (i *InMemorySpaceStorage) AclPendingStorage() (liststorage.PendingStorage, error) {
+	pendingStorage, ok := i.aclStorage.(liststorage.PendingStorage)
+	if !ok {
+		return nil, ErrAclStorageNotSupported
+	}
+	return pendingStorage, nil
+}
+
 func (i *InMemorySpaceStorage) SpaceHeader() (*spacesyncproto.RawSpaceHeaderWithId, error) {
 	return i.spaceHeader, nil
 }
@@ -19,6 +19,8 @@ var (
 	ErrSpaceStorageExists   = errors.New("space storage exists")
 	ErrSpaceStorageMissing  = errors.New("space storage missing")
 	ErrIncorrectSpaceHeader = errors.New("incorrect space header")
+	// ErrAclStorageNotSupported is returned by the optional acl storages if the acl storage can't keep the data
+	ErrAclStorageNotSupported = errors.New("acl storage is not supported")
 
 	ErrTreeStorageAlreadyDeleted = errors.New("tree storage already deleted")
 )
@@ -49,6 +51,18 @@ type SpaceStorage interface {
 	ReadOldSpaceHash() (hash string, err error)
 }
 
+// AclSnapshotStorageProvider can be implemented by the space storage to keep the encrypted snapshot of the acl state
+// of the local account, the acl is built from all records if the storage doesn't implement it
+type AclSnapshotStorageProvider interface {
+	AclSnapshotStorage() (liststorage.SnapshotStorage, error)
+}
+
+// AclPendingStorageProvider can be implemented by the space storage to keep the acl records of the local account
+// which wait to be sent to the consensus node, the queue is kept only in memory if the storage doesn't implement it
+type AclPendingStorageProvider interface {
+	AclPendingStorage() (liststorage.PendingStorage, error)
+}
+
 type SpaceStorageCreatePayload struct {
 	AclWithId           *consensusproto.RawRecordWithId
 	SpaceHeaderWithId   *spacesyncproto.RawSpaceHeaderWithId
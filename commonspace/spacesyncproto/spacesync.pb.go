@@ -945,9 +945,9 @@ func (m *RawSpaceHeaderWithId) GetId() string {
 // SpaceSettingsContent is a payload for a space settings object
 type SpaceSettingsContent struct {
 	// Types that are valid to be assigned to Value:
-	//
 	//	*SpaceSettingsContent_ObjectDelete
 	//	*SpaceSettingsContent_SpaceDelete
+	//	*SpaceSettingsContent_ReadKeyRotationPolicy
 	Value isSpaceSettingsContent_Value `protobuf_oneof:"value"`
 }
 
@@ -996,9 +996,13 @@ type SpaceSettingsContent_ObjectDelete struct {
 type SpaceSettingsContent_SpaceDelete struct {
 	SpaceDelete *SpaceDelete `protobuf:"bytes,2,opt,name=spaceDelete,proto3,oneof" json:"spaceDelete,omitempty"`
 }
+type SpaceSettingsContent_ReadKeyRotationPolicy struct {
+	ReadKeyRotationPolicy *ReadKeyRotationPolicy `protobuf:"bytes,3,opt,name=readKeyRotationPolicy,proto3,oneof" json:"readKeyRotationPolicy,omitempty"`
+}
 
-func (*SpaceSettingsContent_ObjectDelete) isSpaceSettingsContent_Value() {}
-func (*SpaceSettingsContent_SpaceDelete) isSpaceSettingsContent_Value()  {}
+func (*SpaceSettingsContent_ObjectDelete) isSpaceSettingsContent_Value()          {}
+func (*SpaceSettingsContent_SpaceDelete) isSpaceSettingsContent_Value()           {}
+func (*SpaceSettingsContent_ReadKeyRotationPolicy) isSpaceSettingsContent_Value() {}
 
 func (m *SpaceSettingsContent) GetValue() isSpaceSettingsContent_Value {
 	if m != nil {
@@ -1021,11 +1025,19 @@ func (m *SpaceSettingsContent) GetSpaceDelete() *SpaceDelete {
 	return nil
 }
 
+func (m *SpaceSettingsContent) GetReadKeyRotationPolicy() *ReadKeyRotationPolicy {
+	if x, ok := m.GetValue().(*SpaceSettingsContent_ReadKeyRotationPolicy); ok {
+		return x.ReadKeyRotationPolicy
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SpaceSettingsContent) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*SpaceSettingsContent_ObjectDelete)(nil),
 		(*SpaceSettingsContent_SpaceDelete)(nil),
+		(*SpaceSettingsContent_ReadKeyRotationPolicy)(nil),
 	}
 }
 
@@ -1119,17 +1131,84 @@ func (m *SpaceDelete) GetDeleterPeerId() string {
 	return ""
 }
 
+// ReadKeyRotationPolicy is a message containing the conditions when the read key of the space should be changed
+type ReadKeyRotationPolicy struct {
+	// IntervalSec is the maximum time between the read key changes, zero means it is not limited
+	IntervalSec int64 `protobuf:"varint,1,opt,name=intervalSec,proto3" json:"intervalSec,omitempty"`
+	// MaxAclRecords is the maximum number of acl records (e.g. the membership and permission changes)
+	// after the read key change, zero means it is not limited
+	MaxAclRecords uint32 `protobuf:"varint,2,opt,name=maxAclRecords,proto3" json:"maxAclRecords,omitempty"`
+	// MaxChanges is the maximum number of the tree changes encrypted with the current read key,
+	// zero means it is not limited
+	MaxChanges uint32 `protobuf:"varint,3,opt,name=maxChanges,proto3" json:"maxChanges,omitempty"`
+}
+
+func (m *ReadKeyRotationPolicy) Reset()         { *m = ReadKeyRotationPolicy{} }
+func (m *ReadKeyRotationPolicy) String() string { return proto.CompactTextString(m) }
+func (*ReadKeyRotationPolicy) ProtoMessage()    {}
+func (*ReadKeyRotationPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80e49f1f4ac27799, []int{17}
+}
+func (m *ReadKeyRotationPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadKeyRotationPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadKeyRotationPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadKeyRotationPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadKeyRotationPolicy.Merge(m, src)
+}
+func (m *ReadKeyRotationPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadKeyRotationPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadKeyRotationPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadKeyRotationPolicy proto.InternalMessageInfo
+
+func (m *ReadKeyRotationPolicy) GetIntervalSec() int64 {
+	if m != nil {
+		return m.IntervalSec
+	}
+	return 0
+}
+
+func (m *ReadKeyRotationPolicy) GetMaxAclRecords() uint32 {
+	if m != nil {
+		return m.MaxAclRecords
+	}
+	return 0
+}
+
+func (m *ReadKeyRotationPolicy) GetMaxChanges() uint32 {
+	if m != nil {
+		return m.MaxChanges
+	}
+	return 0
+}
+
 // SpaceSettingsSnapshot contains all the deleted ids in a snapshot
 type SpaceSettingsSnapshot struct {
-	DeletedIds    []string `protobuf:"bytes,1,rep,name=deletedIds,proto3" json:"deletedIds,omitempty"`
-	DeleterPeerId string   `protobuf:"bytes,2,opt,name=deleterPeerId,proto3" json:"deleterPeerId,omitempty"`
+	DeletedIds            []string               `protobuf:"bytes,1,rep,name=deletedIds,proto3" json:"deletedIds,omitempty"`
+	DeleterPeerId         string                 `protobuf:"bytes,2,opt,name=deleterPeerId,proto3" json:"deleterPeerId,omitempty"`
+	ReadKeyRotationPolicy *ReadKeyRotationPolicy `protobuf:"bytes,3,opt,name=readKeyRotationPolicy,proto3" json:"readKeyRotationPolicy,omitempty"`
 }
 
 func (m *SpaceSettingsSnapshot) Reset()         { *m = SpaceSettingsSnapshot{} }
 func (m *SpaceSettingsSnapshot) String() string { return proto.CompactTextString(m) }
 func (*SpaceSettingsSnapshot) ProtoMessage()    {}
 func (*SpaceSettingsSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{17}
+	return fileDescriptor_80e49f1f4ac27799, []int{18}
 }
 func (m *SpaceSettingsSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1172,6 +1251,13 @@ func (m *SpaceSettingsSnapshot) GetDeleterPeerId() string {
 	return ""
 }
 
+func (m *SpaceSettingsSnapshot) GetReadKeyRotationPolicy() *ReadKeyRotationPolicy {
+	if m != nil {
+		return m.ReadKeyRotationPolicy
+	}
+	return nil
+}
+
 // SettingsData contains ObjectTree change payload
 type SettingsData struct {
 	Content  []*SpaceSettingsContent `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
@@ -1182,7 +1268,7 @@ func (m *SettingsData) Reset()         { *m = SettingsData{} }
 func (m *SettingsData) String() string { return proto.CompactTextString(m) }
 func (*SettingsData) ProtoMessage()    {}
 func (*SettingsData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{18}
+	return fileDescriptor_80e49f1f4ac27799, []int{19}
 }
 func (m *SettingsData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1234,7 +1320,7 @@ func (m *SpaceSubscription) Reset()         { *m = SpaceSubscription{} }
 func (m *SpaceSubscription) String() string { return proto.CompactTextString(m) }
 func (*SpaceSubscription) ProtoMessage()    {}
 func (*SpaceSubscription) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{19}
+	return fileDescriptor_80e49f1f4ac27799, []int{20}
 }
 func (m *SpaceSubscription) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1287,7 +1373,7 @@ func (m *AclAddRecordRequest) Reset()         { *m = AclAddRecordRequest{} }
 func (m *AclAddRecordRequest) String() string { return proto.CompactTextString(m) }
 func (*AclAddRecordRequest) ProtoMessage()    {}
 func (*AclAddRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{20}
+	return fileDescriptor_80e49f1f4ac27799, []int{21}
 }
 func (m *AclAddRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1340,7 +1426,7 @@ func (m *AclAddRecordResponse) Reset()         { *m = AclAddRecordResponse{} }
 func (m *AclAddRecordResponse) String() string { return proto.CompactTextString(m) }
 func (*AclAddRecordResponse) ProtoMessage()    {}
 func (*AclAddRecordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{21}
+	return fileDescriptor_80e49f1f4ac27799, []int{22}
 }
 func (m *AclAddRecordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1394,7 +1480,7 @@ func (m *AclGetRecordsRequest) Reset()         { *m = AclGetRecordsRequest{} }
 func (m *AclGetRecordsRequest) String() string { return proto.CompactTextString(m) }
 func (*AclGetRecordsRequest) ProtoMessage()    {}
 func (*AclGetRecordsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{22}
+	return fileDescriptor_80e49f1f4ac27799, []int{23}
 }
 func (m *AclGetRecordsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1446,7 +1532,7 @@ func (m *AclGetRecordsResponse) Reset()         { *m = AclGetRecordsResponse{} }
 func (m *AclGetRecordsResponse) String() string { return proto.CompactTextString(m) }
 func (*AclGetRecordsResponse) ProtoMessage()    {}
 func (*AclGetRecordsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_80e49f1f4ac27799, []int{23}
+	return fileDescriptor_80e49f1f4ac27799, []int{24}
 }
 func (m *AclGetRecordsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1503,6 +1589,7 @@ func init() {
 	proto.RegisterType((*SpaceSettingsContent)(nil), "spacesync.SpaceSettingsContent")
 	proto.RegisterType((*ObjectDelete)(nil), "spacesync.ObjectDelete")
 	proto.RegisterType((*SpaceDelete)(nil), "spacesync.SpaceDelete")
+	proto.RegisterType((*ReadKeyRotationPolicy)(nil), "spacesync.ReadKeyRotationPolicy")
 	proto.RegisterType((*SpaceSettingsSnapshot)(nil), "spacesync.SpaceSettingsSnapshot")
 	proto.RegisterType((*SettingsData)(nil), "spacesync.SettingsData")
 	proto.RegisterType((*SpaceSubscription)(nil), "spacesync.SpaceSubscription")
@@ -1517,86 +1604,92 @@ func init() {
 }
 
 var fileDescriptor_80e49f1f4ac27799 = []byte{
-	// 1261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4d, 0x6f, 0x1b, 0xc5,
-	0x1b, 0xf7, 0x6e, 0x9c, 0xd8, 0x7e, 0xb2, 0x76, 0xb7, 0x13, 0xa7, 0xf5, 0xdf, 0x8d, 0x1c, 0x6b,
-	0xf5, 0x17, 0x8a, 0x72, 0x48, 0x9a, 0x04, 0x21, 0xb5, 0xc0, 0x21, 0x4d, 0x52, 0xb2, 0x40, 0x9b,
-	0x68, 0x4c, 0x85, 0x84, 0xc4, 0x61, 0xb2, 0x3b, 0x89, 0x17, 0xd6, 0xbb, 0x66, 0x67, 0xdc, 0xc6,
-	0x47, 0x4e, 0xdc, 0x10, 0x67, 0x38, 0xf2, 0x31, 0xf8, 0x02, 0x1c, 0xcb, 0x8d, 0x23, 0x4a, 0xbe,
-	0x08, 0x9a, 0xd9, 0xd9, 0x37, 0x7b, 0x1d, 0x8a, 0xb8, 0x38, 0xfb, 0xbc, 0xfd, 0x9e, 0xd7, 0x79,
-	0x66, 0x02, 0x7b, 0x4e, 0x38, 0x1a, 0x85, 0x01, 0x1b, 0x13, 0x87, 0xee, 0xca, 0x5f, 0x36, 0x0d,
-	0x9c, 0x71, 0x14, 0xf2, 0x70, 0x57, 0xfe, 0xb2, 0x8c, 0xbb, 0x23, 0x19, 0xa8, 0x91, 0x32, 0x2c,
-	0x0a, 0xcd, 0x53, 0x4a, 0xdc, 0xc1, 0x34, 0x70, 0x30, 0x09, 0xae, 0x28, 0x42, 0x50, 0xbd, 0x8c,
-	0xc2, 0x51, 0x47, 0xeb, 0x6b, 0x5b, 0x55, 0x2c, 0xbf, 0x51, 0x0b, 0x74, 0x1e, 0x76, 0x74, 0xc9,
-	0xd1, 0x79, 0x88, 0xda, 0xb0, 0xec, 0x7b, 0x23, 0x8f, 0x77, 0x96, 0xfa, 0xda, 0x56, 0x13, 0xc7,
-	0x04, 0xea, 0x42, 0x9d, 0xfa, 0x74, 0x44, 0x03, 0xce, 0x3a, 0xd5, 0xbe, 0xb6, 0x55, 0xc7, 0x29,
-	0x6d, 0x5d, 0x43, 0x2b, 0x75, 0x43, 0xd9, 0xc4, 0xe7, 0xc2, 0xcf, 0x90, 0xb0, 0xa1, 0xf4, 0x63,
-	0x60, 0xf9, 0x8d, 0x3e, 0xca, 0x21, 0xe8, 0xfd, 0xa5, 0xad, 0xd5, 0xfd, 0xfe, 0x4e, 0x16, 0x7b,
-	0x11, 0xe0, 0x24, 0x56, 0xcc, 0x7c, 0x88, 0xa8, 0x9c, 0x70, 0x12, 0xa4, 0x51, 0x49, 0xc2, 0xfa,
-	0x10, 0xd6, 0x4b, 0x0d, 0x45, 0x52, 0x9e, 0x2b, 0xdd, 0x37, 0xb0, 0xee, 0xb9, 0x32, 0x20, 0x4a,
-	0x5c, 0x99, 0x66, 0x03, 0xcb, 0x6f, 0xeb, 0x47, 0x0d, 0xee, 0x65, 0xd6, 0xdf, 0x4d, 0x28, 0xe3,
-	0xa8, 0x03, 0x35, 0x19, 0x93, 0x9d, 0x18, 0x27, 0x24, 0x7a, 0x0c, 0x2b, 0x91, 0xa8, 0x61, 0x12,
-	0x7c, 0xa7, 0x2c, 0x78, 0xa1, 0x80, 0x95, 0x1e, 0xda, 0x85, 0xba, 0xeb, 0x5d, 0x5e, 0x7e, 0x31,
-	0x1d, 0x53, 0x19, 0x75, 0x6b, 0x7f, 0x2d, 0x67, 0x73, 0xac, 0x44, 0x38, 0x55, 0xb2, 0xae, 0xc1,
-	0xcc, 0x65, 0x33, 0x0e, 0x03, 0x46, 0xd1, 0x01, 0xd4, 0x22, 0x99, 0x19, 0xeb, 0x68, 0xd2, 0xef,
-	0xff, 0x16, 0x16, 0x0d, 0x27, 0x9a, 0x05, 0xcf, 0xfa, 0xbb, 0x78, 0xfe, 0x45, 0x83, 0xfb, 0x67,
-	0x17, 0xdf, 0x50, 0x87, 0x0b, 0xb8, 0x17, 0x94, 0x31, 0x72, 0x45, 0xef, 0x28, 0xc6, 0x06, 0x34,
-	0xa2, 0xb8, 0x62, 0x76, 0x52, 0xd3, 0x8c, 0x21, 0xec, 0x22, 0x3a, 0xf6, 0xa7, 0xb6, 0x2b, 0xf3,
-	0x6e, 0xe0, 0x84, 0x14, 0x92, 0x31, 0x99, 0xfa, 0x21, 0x71, 0xe5, 0x10, 0x19, 0x38, 0x21, 0xc5,
-	0x7c, 0x85, 0x32, 0x00, 0xdb, 0xed, 0x2c, 0x4b, 0xa3, 0x94, 0xb6, 0x28, 0x98, 0x03, 0xe1, 0xf8,
-	0x7c, 0xc2, 0x86, 0x49, 0xa3, 0xf6, 0x32, 0x24, 0x11, 0xdb, 0xea, 0xfe, 0xc3, 0x5c, 0x86, 0xb1,
-	0x76, 0x2c, 0xce, 0x5c, 0xf4, 0x00, 0x8e, 0x22, 0xea, 0xd2, 0x80, 0x7b, 0xc4, 0x97, 0x51, 0x1b,
-	0x38, 0xc7, 0xb1, 0xd6, 0xe0, 0x7e, 0xce, 0x4d, 0x5c, 0x7f, 0xcb, 0x4a, 0x7d, 0xfb, 0x7e, 0xe2,
-	0x7b, 0x66, 0xb8, 0xac, 0xe7, 0xa9, 0xa1, 0xd0, 0x51, 0x8d, 0xfb, 0xf7, 0x01, 0x5a, 0xdf, 0xeb,
-	0x60, 0xe4, 0x25, 0xe8, 0x10, 0x56, 0xa5, 0x8d, 0xe8, 0x33, 0x8d, 0x14, 0xce, 0x66, 0x0e, 0x07,
-	0x93, 0x37, 0x83, 0x4c, 0xe1, 0x4b, 0x8f, 0x0f, 0x6d, 0x17, 0xe7, 0x6d, 0x44, 0xd2, 0xc4, 0xf1,
-	0x15, 0x60, 0x92, 0x74, 0xc6, 0x41, 0x16, 0x18, 0x19, 0x95, 0x36, 0xac, 0xc0, 0x43, 0xfb, 0xd0,
-	0x96, 0x90, 0x03, 0xca, 0xb9, 0x17, 0x5c, 0xb1, 0xf3, 0x42, 0x0b, 0x4b, 0x65, 0xe8, 0x03, 0x78,
-	0x50, 0xc6, 0x4f, 0xbb, 0xbb, 0x40, 0x6a, 0xfd, 0xa1, 0xc1, 0x6a, 0x2e, 0x25, 0x31, 0x17, 0x9e,
-	0x6c, 0x10, 0x9f, 0xaa, 0x6d, 0x92, 0xd2, 0x62, 0x0a, 0xb9, 0x37, 0xa2, 0x8c, 0x93, 0xd1, 0x58,
-	0xa6, 0xb6, 0x84, 0x33, 0x86, 0x90, 0x4a, 0x1f, 0xe9, 0xf9, 0x6b, 0xe0, 0x8c, 0x81, 0xde, 0x83,
-	0x96, 0x18, 0x4a, 0xcf, 0x21, 0xdc, 0x0b, 0x83, 0xcf, 0xe8, 0x54, 0x66, 0x53, 0xc5, 0x33, 0x5c,
-	0xb1, 0x38, 0x18, 0xa5, 0x71, 0xd4, 0x06, 0x96, 0xdf, 0x68, 0x07, 0x50, 0xae, 0xc4, 0x49, 0x35,
-	0x56, 0xa4, 0x46, 0x89, 0xc4, 0x3a, 0x87, 0x56, 0xb1, 0x51, 0xa8, 0x3f, 0xdf, 0x58, 0xa3, 0xd8,
-	0x37, 0x11, 0xbd, 0x77, 0x15, 0x10, 0x3e, 0x89, 0xa8, 0x6a, 0x5b, 0xc6, 0xb0, 0x8e, 0xa1, 0x5d,
-	0xd6, 0x7a, 0x79, 0x2e, 0xc9, 0x9b, 0x02, 0x6a, 0xc6, 0x50, 0x73, 0xab, 0xa7, 0x73, 0xfb, 0xb3,
-	0x06, 0xed, 0x41, 0xbe, 0x0d, 0x47, 0x61, 0xc0, 0xc5, 0xf6, 0xfc, 0x18, 0x8c, 0xf8, 0xf0, 0x1d,
-	0x53, 0x9f, 0x72, 0x5a, 0x32, 0xc0, 0x67, 0x39, 0xf1, 0x69, 0x05, 0x17, 0xd4, 0xd1, 0x53, 0x95,
-	0x9d, 0xb2, 0xd6, 0xa5, 0xf5, 0x83, 0xd9, 0xf1, 0x4f, 0x8d, 0xf3, 0xca, 0xcf, 0x6a, 0xb0, 0xfc,
-	0x9a, 0xf8, 0x13, 0x6a, 0xf5, 0xc0, 0xc8, 0x3b, 0x99, 0x3b, 0x74, 0x07, 0x6a, 0x4e, 0x94, 0xf8,
-	0xff, 0xd0, 0x74, 0xe5, 0x57, 0x74, 0x4e, 0x69, 0x94, 0x6e, 0xac, 0x22, 0xd3, 0xfa, 0x1a, 0xd6,
-	0x0b, 0x09, 0x0f, 0x02, 0x32, 0x66, 0xc3, 0x90, 0x8b, 0x63, 0x12, 0x6b, 0xba, 0xb6, 0x1b, 0x6f,
-	0xda, 0x06, 0xce, 0x71, 0xe6, 0xe1, 0xf5, 0x32, 0xf8, 0x1f, 0x34, 0x30, 0x12, 0xe8, 0x63, 0xc2,
-	0x09, 0x7a, 0x02, 0x35, 0x27, 0xae, 0xa9, 0xda, 0xde, 0x9b, 0xb3, 0x55, 0x98, 0x29, 0x3d, 0x4e,
-	0xf4, 0xc5, 0x75, 0xc9, 0x54, 0x74, 0xaa, 0x82, 0xfd, 0x45, 0xb6, 0x49, 0x16, 0x38, 0xb5, 0xb0,
-	0xbe, 0x55, 0x2b, 0x69, 0x30, 0xb9, 0x60, 0x4e, 0xe4, 0x8d, 0xc5, 0x38, 0x8b, 0xb3, 0xa4, 0x16,
-	0x78, 0x92, 0x62, 0x4a, 0xa3, 0xa7, 0xb0, 0x42, 0x1c, 0xa1, 0xa5, 0x2e, 0x0c, 0x6b, 0xce, 0x59,
-	0x0e, 0xe9, 0x50, 0x6a, 0x62, 0x65, 0x61, 0xd9, 0xb0, 0x76, 0xe8, 0xf8, 0x87, 0xae, 0x8b, 0xa9,
-	0x13, 0x46, 0xee, 0x3f, 0xdf, 0xa5, 0xb9, 0x6b, 0x40, 0x2f, 0x5c, 0x03, 0xd6, 0xe7, 0xd0, 0x2e,
-	0x42, 0xa9, 0x6d, 0xda, 0x85, 0x7a, 0x24, 0x39, 0x29, 0x58, 0x4a, 0xdf, 0x81, 0xf6, 0xa9, 0x44,
-	0xfb, 0x84, 0xf2, 0x18, 0x8d, 0xbd, 0x53, 0x64, 0xc4, 0xf1, 0x4f, 0xb3, 0xa7, 0x42, 0x42, 0x5a,
-	0x7b, 0xb0, 0x3e, 0x83, 0xa5, 0x42, 0x93, 0xb7, 0x9d, 0x64, 0xc9, 0xa2, 0x1a, 0x38, 0x21, 0xb7,
-	0x7f, 0xd3, 0xa0, 0x7e, 0x12, 0x45, 0x47, 0xa1, 0x4b, 0x19, 0x6a, 0x01, 0xbc, 0x0a, 0xe8, 0xf5,
-	0x98, 0x3a, 0x9c, 0xba, 0x66, 0x05, 0x99, 0x6a, 0xd7, 0xbf, 0xf0, 0x18, 0xf3, 0x82, 0x2b, 0x53,
-	0x43, 0xf7, 0xd4, 0x44, 0x9f, 0x5c, 0x7b, 0x8c, 0x33, 0x53, 0x47, 0x6b, 0x70, 0x4f, 0x32, 0x5e,
-	0x86, 0xdc, 0x0e, 0x8e, 0x88, 0x33, 0xa4, 0xe6, 0x12, 0x42, 0xd0, 0x92, 0x4c, 0x9b, 0xc5, 0x93,
-	0xef, 0x9a, 0x55, 0xd4, 0x81, 0xb6, 0x9c, 0x40, 0xf6, 0x32, 0xe4, 0x2a, 0x2e, 0xef, 0xc2, 0xa7,
-	0xe6, 0x32, 0x6a, 0x83, 0x89, 0xa9, 0x43, 0xbd, 0x31, 0xb7, 0x99, 0x1d, 0xbc, 0x26, 0xbe, 0xe7,
-	0x9a, 0x2b, 0x02, 0x43, 0x11, 0x6a, 0x45, 0x99, 0x35, 0xe1, 0xfd, 0x24, 0x8a, 0xc2, 0xe8, 0xec,
-	0xf2, 0x92, 0x51, 0x6e, 0xba, 0xdb, 0x4f, 0xe0, 0xe1, 0x82, 0xc6, 0xa3, 0x26, 0x34, 0x14, 0xf7,
-	0x82, 0x9a, 0x15, 0x61, 0xfa, 0x2a, 0x60, 0x29, 0x43, 0xdb, 0xde, 0x86, 0x7a, 0xf2, 0xc8, 0x40,
-	0xab, 0x50, 0xb3, 0x03, 0x4f, 0x5c, 0xb0, 0x66, 0x05, 0xdd, 0x87, 0xe6, 0x79, 0x44, 0x1d, 0xe2,
-	0x3b, 0x13, 0x9f, 0x88, 0xd8, 0xb5, 0xfd, 0x5f, 0xab, 0xd0, 0x88, 0xfd, 0x4c, 0x03, 0x07, 0x1d,
-	0x41, 0x3d, 0x79, 0xd4, 0xa0, 0x6e, 0xe9, 0x4b, 0x47, 0x76, 0xb0, 0xfb, 0xa8, 0xfc, 0x15, 0x14,
-	0x77, 0xe4, 0xb9, 0x42, 0x14, 0x17, 0x39, 0x7a, 0x34, 0x77, 0xed, 0x66, 0xaf, 0x88, 0xee, 0x46,
-	0xb9, 0x70, 0x0e, 0xc7, 0xf7, 0xcb, 0x70, 0xd2, 0x17, 0x41, 0x19, 0x4e, 0xee, 0x29, 0x80, 0xc1,
-	0xcc, 0x1e, 0x57, 0x03, 0x1e, 0x51, 0x32, 0x42, 0x1b, 0x73, 0xcb, 0x34, 0xf7, 0xf2, 0xea, 0xde,
-	0x29, 0xdd, 0xd2, 0x1e, 0x6b, 0xe8, 0x14, 0x20, 0x13, 0xfc, 0x17, 0x34, 0x74, 0x06, 0x46, 0xfe,
-	0xc8, 0xa1, 0x5e, 0x4e, 0xbb, 0xe4, 0x58, 0x77, 0x37, 0x17, 0xca, 0xd3, 0x74, 0x9b, 0x85, 0x93,
-	0x82, 0x66, 0x2c, 0xe6, 0xce, 0x63, 0xb7, 0xbf, 0x58, 0x21, 0xc6, 0x7c, 0xf6, 0xfe, 0xef, 0x37,
-	0x3d, 0xed, 0xed, 0x4d, 0x4f, 0xfb, 0xeb, 0xa6, 0xa7, 0xfd, 0x74, 0xdb, 0xab, 0xbc, 0xbd, 0xed,
-	0x55, 0xfe, 0xbc, 0xed, 0x55, 0xbe, 0xea, 0x2e, 0xfe, 0x0f, 0xe9, 0x62, 0x45, 0xfe, 0x39, 0xf8,
-	0x3b, 0x00, 0x00, 0xff, 0xff, 0xa7, 0xd0, 0x2d, 0x98, 0x46, 0x0d, 0x00, 0x00,
+	// 1346 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xcd, 0x72, 0x1b, 0x45,
+	0x10, 0xd6, 0xae, 0xff, 0xa4, 0xb6, 0xa4, 0xac, 0xc7, 0x72, 0x22, 0x94, 0x94, 0xa2, 0xda, 0xa2,
+	0x28, 0x97, 0x0f, 0xf9, 0x71, 0x28, 0xaa, 0x12, 0xe0, 0xe0, 0xd8, 0x0e, 0x16, 0x21, 0xb1, 0x6b,
+	0x44, 0x80, 0xe2, 0x36, 0xde, 0x6d, 0x5b, 0x0b, 0xab, 0x5d, 0xb1, 0x33, 0x4a, 0xac, 0x13, 0xc5,
+	0x89, 0x1b, 0xc5, 0x9d, 0x23, 0xcf, 0xc0, 0x89, 0x17, 0xe0, 0x18, 0x6e, 0x1c, 0xa9, 0xe4, 0x15,
+	0x78, 0x00, 0x6a, 0x66, 0x67, 0xff, 0xa4, 0x95, 0x09, 0x95, 0x8b, 0xbc, 0xfd, 0xff, 0xf5, 0x74,
+	0xcf, 0x74, 0x1b, 0xee, 0x3a, 0xe1, 0x68, 0x14, 0x06, 0x7c, 0xcc, 0x1c, 0xbc, 0xad, 0x7e, 0xf9,
+	0x34, 0x70, 0xc6, 0x51, 0x28, 0xc2, 0xdb, 0xea, 0x97, 0x67, 0xdc, 0x5b, 0x8a, 0x41, 0x6a, 0x29,
+	0xc3, 0x46, 0x68, 0x1c, 0x21, 0x73, 0x07, 0xd3, 0xc0, 0xa1, 0x2c, 0x38, 0x47, 0x42, 0x60, 0xf9,
+	0x2c, 0x0a, 0x47, 0x6d, 0xa3, 0x67, 0x6c, 0x2f, 0x53, 0xf5, 0x4d, 0x9a, 0x60, 0x8a, 0xb0, 0x6d,
+	0x2a, 0x8e, 0x29, 0x42, 0xd2, 0x82, 0x15, 0xdf, 0x1b, 0x79, 0xa2, 0xbd, 0xd4, 0x33, 0xb6, 0x1b,
+	0x34, 0x26, 0x48, 0x07, 0xaa, 0xe8, 0xe3, 0x08, 0x03, 0xc1, 0xdb, 0xcb, 0x3d, 0x63, 0xbb, 0x4a,
+	0x53, 0xda, 0xbe, 0x80, 0x66, 0x1a, 0x06, 0xf9, 0xc4, 0x17, 0x32, 0xce, 0x90, 0xf1, 0xa1, 0x8a,
+	0x53, 0xa7, 0xea, 0x9b, 0x7c, 0x94, 0xf3, 0x60, 0xf6, 0x96, 0xb6, 0xd7, 0x77, 0x7b, 0xb7, 0x32,
+	0xec, 0x45, 0x07, 0x87, 0xb1, 0x62, 0x16, 0x43, 0xa2, 0x72, 0xc2, 0x49, 0x90, 0xa2, 0x52, 0x84,
+	0xfd, 0x21, 0x6c, 0x95, 0x1a, 0xca, 0xa4, 0x3c, 0x57, 0x85, 0xaf, 0x51, 0xd3, 0x73, 0x15, 0x20,
+	0x64, 0xae, 0x4a, 0xb3, 0x46, 0xd5, 0xb7, 0xfd, 0x93, 0x01, 0x57, 0x32, 0xeb, 0xef, 0x26, 0xc8,
+	0x05, 0x69, 0xc3, 0x9a, 0xc2, 0xd4, 0x4f, 0x8c, 0x13, 0x92, 0xdc, 0x81, 0xd5, 0x48, 0x9e, 0x61,
+	0x02, 0xbe, 0x5d, 0x06, 0x5e, 0x2a, 0x50, 0xad, 0x47, 0x6e, 0x43, 0xd5, 0xf5, 0xce, 0xce, 0x3e,
+	0x9f, 0x8e, 0x51, 0xa1, 0x6e, 0xee, 0x6e, 0xe6, 0x6c, 0x0e, 0xb4, 0x88, 0xa6, 0x4a, 0xf6, 0x05,
+	0x58, 0xb9, 0x6c, 0xc6, 0x61, 0xc0, 0x91, 0xdc, 0x83, 0xb5, 0x48, 0x65, 0xc6, 0xdb, 0x86, 0x8a,
+	0xfb, 0xce, 0xc2, 0x43, 0xa3, 0x89, 0x66, 0x21, 0xb2, 0xf9, 0x26, 0x91, 0x7f, 0x31, 0x60, 0xe3,
+	0xf8, 0xf4, 0x1b, 0x74, 0x84, 0x74, 0xf7, 0x04, 0x39, 0x67, 0xe7, 0x78, 0xc9, 0x61, 0xdc, 0x80,
+	0x5a, 0x14, 0x9f, 0x58, 0x3f, 0x39, 0xd3, 0x8c, 0x21, 0xed, 0x22, 0x1c, 0xfb, 0xd3, 0xbe, 0xab,
+	0xf2, 0xae, 0xd1, 0x84, 0x94, 0x92, 0x31, 0x9b, 0xfa, 0x21, 0x73, 0x55, 0x13, 0xd5, 0x69, 0x42,
+	0xca, 0xfe, 0x0a, 0x15, 0x80, 0xbe, 0xdb, 0x5e, 0x51, 0x46, 0x29, 0x6d, 0x23, 0x58, 0x03, 0x19,
+	0xf8, 0x64, 0xc2, 0x87, 0x49, 0xa1, 0xee, 0x66, 0x9e, 0x24, 0xb6, 0xf5, 0xdd, 0x6b, 0xb9, 0x0c,
+	0x63, 0xed, 0x58, 0x9c, 0x85, 0xe8, 0x02, 0xec, 0x47, 0xe8, 0x62, 0x20, 0x3c, 0xe6, 0x2b, 0xd4,
+	0x75, 0x9a, 0xe3, 0xd8, 0x9b, 0xb0, 0x91, 0x0b, 0x13, 0x9f, 0xbf, 0x6d, 0xa7, 0xb1, 0x7d, 0x3f,
+	0x89, 0x3d, 0xd3, 0x5c, 0xf6, 0x23, 0xd8, 0xc8, 0xe9, 0xe8, 0xc2, 0xfd, 0x7f, 0x80, 0xf6, 0x0f,
+	0x26, 0xd4, 0xf3, 0x12, 0xb2, 0x07, 0xeb, 0xca, 0x46, 0xd6, 0x19, 0x23, 0xed, 0xe7, 0x66, 0xce,
+	0x0f, 0x65, 0x2f, 0x06, 0x99, 0xc2, 0x97, 0x9e, 0x18, 0xf6, 0x5d, 0x9a, 0xb7, 0x91, 0x49, 0x33,
+	0xc7, 0xd7, 0x0e, 0x93, 0xa4, 0x33, 0x0e, 0xb1, 0xa1, 0x9e, 0x51, 0x69, 0xc1, 0x0a, 0x3c, 0xb2,
+	0x0b, 0x2d, 0xe5, 0x72, 0x80, 0x42, 0x78, 0xc1, 0x39, 0x3f, 0x29, 0x94, 0xb0, 0x54, 0x46, 0x3e,
+	0x80, 0xab, 0x65, 0xfc, 0xb4, 0xba, 0x0b, 0xa4, 0xf6, 0x9f, 0x06, 0xac, 0xe7, 0x52, 0x92, 0x7d,
+	0xe1, 0xa9, 0x02, 0x89, 0xa9, 0x7e, 0x4d, 0x52, 0x5a, 0x76, 0xa1, 0xf0, 0x46, 0xc8, 0x05, 0x1b,
+	0x8d, 0x55, 0x6a, 0x4b, 0x34, 0x63, 0x48, 0xa9, 0x8a, 0x91, 0xde, 0xbf, 0x1a, 0xcd, 0x18, 0xe4,
+	0x3d, 0x68, 0xca, 0xa6, 0xf4, 0x1c, 0x26, 0xbc, 0x30, 0x78, 0x8c, 0x53, 0x95, 0xcd, 0x32, 0x9d,
+	0xe1, 0xca, 0x87, 0x83, 0x23, 0xc6, 0xa8, 0xeb, 0x54, 0x7d, 0x93, 0x5b, 0x40, 0x72, 0x47, 0x9c,
+	0x9c, 0xc6, 0xaa, 0xd2, 0x28, 0x91, 0xd8, 0x27, 0xd0, 0x2c, 0x16, 0x8a, 0xf4, 0xe6, 0x0b, 0x5b,
+	0x2f, 0xd6, 0x4d, 0xa2, 0xf7, 0xce, 0x03, 0x26, 0x26, 0x11, 0xea, 0xb2, 0x65, 0x0c, 0xfb, 0x00,
+	0x5a, 0x65, 0xa5, 0x57, 0xf7, 0x92, 0xbd, 0x28, 0x78, 0xcd, 0x18, 0xba, 0x6f, 0xcd, 0xb4, 0x6f,
+	0xff, 0x31, 0xa0, 0x35, 0xc8, 0x97, 0x61, 0x3f, 0x0c, 0x84, 0x7c, 0x3d, 0x3f, 0x86, 0x7a, 0x7c,
+	0xf9, 0x0e, 0xd0, 0x47, 0x81, 0x25, 0x0d, 0x7c, 0x9c, 0x13, 0x1f, 0x55, 0x68, 0x41, 0x9d, 0x3c,
+	0xd0, 0xd9, 0x69, 0x6b, 0x53, 0x59, 0x5f, 0x9d, 0x6d, 0xff, 0xd4, 0x38, 0xaf, 0x4c, 0xbe, 0x82,
+	0xad, 0x08, 0x99, 0xfb, 0x18, 0xa7, 0x34, 0x14, 0xaa, 0x0a, 0x27, 0xa1, 0xef, 0x39, 0x53, 0x55,
+	0xc1, 0xe2, 0xc8, 0xa0, 0x65, 0x7a, 0x47, 0x15, 0x5a, 0xee, 0xe0, 0xe1, 0x1a, 0xac, 0x3c, 0x67,
+	0xfe, 0x04, 0xed, 0x2e, 0xd4, 0xf3, 0xf0, 0xe7, 0xae, 0xf3, 0x3d, 0xdd, 0x81, 0x5a, 0xfc, 0x2e,
+	0x34, 0x5c, 0xf5, 0x15, 0x9d, 0x20, 0x46, 0xe9, 0x5b, 0x58, 0x64, 0xda, 0xdf, 0xc3, 0x56, 0x29,
+	0x1e, 0x59, 0x6a, 0x2f, 0x10, 0x18, 0x3d, 0x67, 0xfe, 0x00, 0x1d, 0x65, 0xbc, 0x44, 0xf3, 0x2c,
+	0x19, 0x60, 0xc4, 0x2e, 0xf6, 0x1c, 0x9f, 0xa2, 0x13, 0x46, 0x2e, 0x57, 0x07, 0xd6, 0xa0, 0x45,
+	0xa6, 0xbc, 0xc8, 0x23, 0x76, 0xb1, 0x3f, 0x8c, 0x67, 0x50, 0x3c, 0x05, 0x73, 0x1c, 0xfb, 0x37,
+	0x03, 0xb6, 0x0a, 0xc5, 0x1c, 0x04, 0x6c, 0xcc, 0x87, 0xa1, 0x90, 0x96, 0x31, 0x56, 0xb7, 0xef,
+	0xc6, 0x53, 0xa4, 0x46, 0x73, 0x9c, 0xf9, 0x04, 0xcd, 0x92, 0x04, 0xc9, 0x17, 0x6f, 0x59, 0x98,
+	0x05, 0x65, 0xb1, 0x7f, 0x34, 0xa0, 0x9e, 0x40, 0x3e, 0x60, 0x82, 0x91, 0xfb, 0xb0, 0xe6, 0xc4,
+	0x7d, 0xa8, 0x27, 0xde, 0xcd, 0xd9, 0xce, 0x99, 0x69, 0x57, 0x9a, 0xe8, 0xcb, 0x15, 0x83, 0xeb,
+	0xac, 0xdb, 0xe6, 0x1c, 0xac, 0xd2, 0xd3, 0xa1, 0xa9, 0x85, 0xfd, 0xad, 0x7e, 0xc6, 0x07, 0x93,
+	0x53, 0xee, 0x44, 0xde, 0x58, 0x82, 0x94, 0xef, 0x8f, 0x1e, 0x7a, 0xc9, 0xd1, 0xa5, 0x34, 0x79,
+	0x00, 0xab, 0xcc, 0x91, 0x5a, 0x7a, 0xc8, 0xda, 0x73, 0xc1, 0x72, 0x9e, 0xf6, 0x94, 0x26, 0xd5,
+	0x16, 0x76, 0x1f, 0x36, 0xf7, 0x1c, 0x7f, 0xcf, 0x75, 0xe3, 0xfa, 0xfe, 0xf7, 0xfe, 0x91, 0x1b,
+	0x9d, 0x66, 0x61, 0x74, 0xda, 0x9f, 0x41, 0xab, 0xe8, 0x4a, 0x4f, 0xa0, 0x0e, 0x54, 0x23, 0xc5,
+	0x49, 0x9d, 0xa5, 0xf4, 0x25, 0xde, 0x3e, 0x55, 0xde, 0x3e, 0x41, 0xa1, 0x1b, 0xef, 0x8d, 0x90,
+	0x31, 0xc7, 0x3f, 0xca, 0xd6, 0xab, 0x84, 0xb4, 0xef, 0xc2, 0xd6, 0x8c, 0x2f, 0x0d, 0x4d, 0x6d,
+	0x08, 0x71, 0xb3, 0xcb, 0x43, 0xad, 0xd3, 0x84, 0xdc, 0xf9, 0xdd, 0x80, 0xea, 0x61, 0x14, 0xed,
+	0x87, 0x2e, 0x72, 0xd2, 0x04, 0x78, 0x16, 0xe0, 0xc5, 0x18, 0x1d, 0x81, 0xae, 0x55, 0x21, 0x96,
+	0x9e, 0x8f, 0x4f, 0x3c, 0xce, 0xbd, 0xe0, 0xdc, 0x32, 0xc8, 0x15, 0x7d, 0x57, 0x0f, 0x2f, 0x3c,
+	0x2e, 0xb8, 0x65, 0x92, 0x4d, 0xb8, 0xa2, 0x18, 0x4f, 0x43, 0xd1, 0x0f, 0xf6, 0x99, 0x33, 0x44,
+	0x6b, 0x89, 0x10, 0x68, 0x2a, 0x66, 0x9f, 0xc7, 0x77, 0xda, 0xb5, 0x96, 0x49, 0x1b, 0x5a, 0xaa,
+	0xb3, 0xf9, 0xd3, 0x50, 0x68, 0x5c, 0xde, 0xa9, 0x8f, 0xd6, 0x0a, 0x69, 0x81, 0x45, 0xd1, 0x41,
+	0x6f, 0x2c, 0xfa, 0xbc, 0x1f, 0x3c, 0x67, 0xbe, 0xe7, 0x5a, 0xab, 0xd2, 0x87, 0x26, 0xf4, 0xb3,
+	0x6e, 0xad, 0xc9, 0xe8, 0x87, 0x51, 0x14, 0x46, 0xc7, 0x67, 0x67, 0x1c, 0x85, 0xe5, 0xee, 0xdc,
+	0x87, 0x6b, 0x0b, 0x0a, 0x4f, 0x1a, 0x50, 0xd3, 0xdc, 0x53, 0xb4, 0x2a, 0xd2, 0xf4, 0x59, 0xc0,
+	0x53, 0x86, 0xb1, 0xb3, 0x03, 0xd5, 0x64, 0x31, 0x23, 0xeb, 0xb0, 0xd6, 0x0f, 0x3c, 0xb9, 0x94,
+	0x58, 0x15, 0xb2, 0x01, 0x8d, 0x93, 0x08, 0x1d, 0xe6, 0x3b, 0x13, 0x9f, 0x49, 0xec, 0xc6, 0xee,
+	0xaf, 0xcb, 0x50, 0x8b, 0xe3, 0x4c, 0x03, 0x87, 0xec, 0x43, 0x35, 0x59, 0x04, 0x49, 0xa7, 0x74,
+	0x3b, 0x54, 0x15, 0xec, 0x5c, 0x2f, 0x95, 0xe9, 0x8a, 0x3c, 0x82, 0x5a, 0xba, 0xfc, 0x90, 0xeb,
+	0x73, 0xab, 0x4a, 0xb6, 0x79, 0x75, 0x6e, 0x94, 0x0b, 0xe7, 0xfc, 0xf8, 0x7e, 0x99, 0x1f, 0xdf,
+	0xbf, 0xc4, 0x4f, 0x6e, 0x7d, 0xa2, 0x60, 0x65, 0x0b, 0xe9, 0x40, 0x44, 0xc8, 0x46, 0xe4, 0xc6,
+	0xdc, 0x00, 0xca, 0x6d, 0xab, 0x9d, 0x4b, 0xa5, 0xdb, 0xc6, 0x1d, 0x83, 0x1c, 0x01, 0x64, 0x82,
+	0xb7, 0xf1, 0x46, 0x8e, 0xa1, 0x9e, 0xbf, 0x72, 0xa4, 0x9b, 0xd3, 0x2e, 0xb9, 0xd6, 0x9d, 0x9b,
+	0x0b, 0xe5, 0x69, 0xba, 0x8d, 0xc2, 0x4d, 0x21, 0x33, 0x16, 0x73, 0xf7, 0xb1, 0xd3, 0x5b, 0xac,
+	0x10, 0xfb, 0x7c, 0xf8, 0xfe, 0x1f, 0xaf, 0xba, 0xc6, 0xcb, 0x57, 0x5d, 0xe3, 0xef, 0x57, 0x5d,
+	0xe3, 0xe7, 0xd7, 0xdd, 0xca, 0xcb, 0xd7, 0xdd, 0xca, 0x5f, 0xaf, 0xbb, 0x95, 0xaf, 0x3b, 0x8b,
+	0xff, 0xab, 0x3c, 0x5d, 0x55, 0x7f, 0xee, 0xfd, 0x3b, 0x00, 0xd6, 0x64, 0xd6, 0x75, 0x7a, 0x0e,
+	0x00, 0x00,
 }
 
 func (m *HeadSyncRange) Marshal() (dAtA []byte, err error) {
@@ -2284,6 +2377,27 @@ func (m *SpaceSettingsContent_SpaceDelete) MarshalToSizedBuffer(dAtA []byte) (in
 	}
 	return len(dAtA) - i, nil
 }
+func (m *SpaceSettingsContent_ReadKeyRotationPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpaceSettingsContent_ReadKeyRotationPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ReadKeyRotationPolicy != nil {
+		{
+			size, err := m.ReadKeyRotationPolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSpacesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ObjectDelete) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2344,6 +2458,44 @@ func (m *SpaceDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ReadKeyRotationPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadKeyRotationPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadKeyRotationPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxChanges != 0 {
+		i = encodeVarintSpacesync(dAtA, i, uint64(m.MaxChanges))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxAclRecords != 0 {
+		i = encodeVarintSpacesync(dAtA, i, uint64(m.MaxAclRecords))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.IntervalSec != 0 {
+		i = encodeVarintSpacesync(dAtA, i, uint64(m.IntervalSec))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *SpaceSettingsSnapshot) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2364,6 +2516,18 @@ func (m *SpaceSettingsSnapshot) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ReadKeyRotationPolicy != nil {
+		{
+			size, err := m.ReadKeyRotationPolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSpacesync(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.DeleterPeerId) > 0 {
 		i -= len(m.DeleterPeerId)
 		copy(dAtA[i:], m.DeleterPeerId)
@@ -2934,6 +3098,18 @@ func (m *SpaceSettingsContent_SpaceDelete) Size() (n int) {
 	}
 	return n
 }
+func (m *SpaceSettingsContent_ReadKeyRotationPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ReadKeyRotationPolicy != nil {
+		l = m.ReadKeyRotationPolicy.Size()
+		n += 1 + l + sovSpacesync(uint64(l))
+	}
+	return n
+}
 func (m *ObjectDelete) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2960,6 +3136,24 @@ func (m *SpaceDelete) Size() (n int) {
 	return n
 }
 
+func (m *ReadKeyRotationPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IntervalSec != 0 {
+		n += 1 + sovSpacesync(uint64(m.IntervalSec))
+	}
+	if m.MaxAclRecords != 0 {
+		n += 1 + sovSpacesync(uint64(m.MaxAclRecords))
+	}
+	if m.MaxChanges != 0 {
+		n += 1 + sovSpacesync(uint64(m.MaxChanges))
+	}
+	return n
+}
+
 func (m *SpaceSettingsSnapshot) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2976,6 +3170,10 @@ func (m *SpaceSettingsSnapshot) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSpacesync(uint64(l))
 	}
+	if m.ReadKeyRotationPolicy != nil {
+		l = m.ReadKeyRotationPolicy.Size()
+		n += 1 + l + sovSpacesync(uint64(l))
+	}
 	return n
 }
 
@@ -5027,6 +5225,41 @@ func (m *SpaceSettingsContent) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &SpaceSettingsContent_SpaceDelete{v}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyRotationPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSpacesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSpacesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ReadKeyRotationPolicy{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &SpaceSettingsContent_ReadKeyRotationPolicy{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSpacesync(dAtA[iNdEx:])
@@ -5212,6 +5445,113 @@ func (m *SpaceDelete) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReadKeyRotationPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSpacesync
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadKeyRotationPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadKeyRotationPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalSec", wireType)
+			}
+			m.IntervalSec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IntervalSec |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAclRecords", wireType)
+			}
+			m.MaxAclRecords = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAclRecords |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxChanges", wireType)
+			}
+			m.MaxChanges = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxChanges |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSpacesync(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSpacesync
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *SpaceSettingsSnapshot) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5305,6 +5645,42 @@ func (m *SpaceSettingsSnapshot) Unmarshal(dAtA []byte) error {
 			}
 			m.DeleterPeerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadKeyRotationPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSpacesync
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSpacesync
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSpacesync
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReadKeyRotationPolicy == nil {
+				m.ReadKeyRotationPolicy = &ReadKeyRotationPolicy{}
+			}
+			if err := m.ReadKeyRotationPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSpacesync(dAtA[iNdEx:])
@@ -7,6 +7,12 @@ import (
 const (
 	AnysyncSpacePath = "m/SLIP-0021/anysync/space"
 	AnysyncTreePath  = "m/SLIP-0021/anysync/tree/%s"
+	// AnysyncAclSnapshotPath is used to derive the key encrypting local snapshots of acl state
+	AnysyncAclSnapshotPath = "m/SLIP-0021/anysync/aclsnapshot"
+	// AnysyncAclPendingPath is used to derive the key encrypting the local queue of pending acl records
+	AnysyncAclPendingPath = "m/SLIP-0021/anysync/aclpending"
+	// AnysyncTreeKeyPath is used to derive the key encrypting the own keys of the trees
+	AnysyncTreeKeyPath = "m/SLIP-0021/anysync/treekey"
 )
 
 // DeriveSymmetricKey derives a symmetric key from seed and path using slip-21